@@ -42,6 +42,32 @@ func (c *Client) GetDashboardByName(name string) (GetDashboardResponse, *resty.R
 	return GetDashboardResponse{}, resp, nil
 }
 
+type GetDashboardByUIDResponse struct {
+	Dashboard map[string]interface{} `json:"dashboard"`
+	Meta      map[string]interface{} `json:"meta"`
+}
+
+// GetDashboardByUID Get the JSON model of a dashboard by UID
+func (c *Client) GetDashboardByUID(uid string) (GetDashboardByUIDResponse, *resty.Response, error) {
+	var grafanaResp GetDashboardByUIDResponse
+
+	resp, err := c.resty.R().
+		SetHeader("Accept", "application/json").
+		SetResult(&grafanaResp).
+		Get(fmt.Sprintf("/api/dashboards/uid/%s", uid))
+
+	if err != nil {
+		return GetDashboardByUIDResponse{}, resp, fmt.Errorf("error making API request: %w", err)
+	}
+
+	statusCode := resp.StatusCode()
+	if statusCode != 200 {
+		return GetDashboardByUIDResponse{}, resp, fmt.Errorf("error getting dashboard, received unexpected status code %d: %s", statusCode, resp.String())
+	}
+
+	return grafanaResp, resp, nil
+}
+
 type PostDashboardRequest struct {
 	Dashboard interface{} `json:"dashboard"`
 	FolderID  int         `json:"folderId"`
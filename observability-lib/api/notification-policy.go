@@ -46,36 +46,41 @@ func PrintPolicyTree(policy alerting.NotificationPolicy, depth int) {
 	}
 }
 
-func policyExist(parent alerting.NotificationPolicy, newNotificationPolicy alerting.NotificationPolicy) bool {
-	for _, notificationPolicy := range parent.Routes {
-		matchersEqual := false
-		if notificationPolicy.ObjectMatchers != nil {
-			matchersEqual = objectMatchersEqual(*notificationPolicy.ObjectMatchers, *newNotificationPolicy.ObjectMatchers)
-		}
-		receiversEqual := reflect.DeepEqual(notificationPolicy.Receiver, newNotificationPolicy.Receiver)
-		if matchersEqual && receiversEqual {
-			return true
+func policiesMatch(a alerting.NotificationPolicy, b alerting.NotificationPolicy) bool {
+	matchersEqual := false
+	if a.ObjectMatchers != nil {
+		matchersEqual = objectMatchersEqual(*a.ObjectMatchers, *b.ObjectMatchers)
+	}
+	return matchersEqual && reflect.DeepEqual(a.Receiver, b.Receiver)
+}
+
+// GetNestedPolicy returns the policy of the tree with the same matchers and receiver as newNotificationPolicy, or nil if there is none
+func GetNestedPolicy(parent alerting.NotificationPolicy, newNotificationPolicy alerting.NotificationPolicy) *alerting.NotificationPolicy {
+	for key, notificationPolicy := range parent.Routes {
+		if policiesMatch(notificationPolicy, newNotificationPolicy) {
+			return &parent.Routes[key]
 		}
 		if notificationPolicy.Routes != nil {
-			return policyExist(notificationPolicy, newNotificationPolicy)
+			if found := GetNestedPolicy(notificationPolicy, newNotificationPolicy); found != nil {
+				return found
+			}
 		}
 	}
-	return false
+	return nil
+}
+
+func policyExist(parent alerting.NotificationPolicy, newNotificationPolicy alerting.NotificationPolicy) bool {
+	return GetNestedPolicy(parent, newNotificationPolicy) != nil
 }
 
 func updateInPlace(parent *alerting.NotificationPolicy, newNotificationPolicy alerting.NotificationPolicy) bool {
 	for key, notificationPolicy := range parent.Routes {
-		matchersEqual := false
-		if notificationPolicy.ObjectMatchers != nil {
-			matchersEqual = objectMatchersEqual(*notificationPolicy.ObjectMatchers, *newNotificationPolicy.ObjectMatchers)
-		}
-		receiversEqual := reflect.DeepEqual(notificationPolicy.Receiver, newNotificationPolicy.Receiver)
-		if matchersEqual && receiversEqual {
+		if policiesMatch(notificationPolicy, newNotificationPolicy) {
 			parent.Routes[key] = newNotificationPolicy
 			return true
 		}
-		if notificationPolicy.Routes != nil {
-			return updateInPlace(&parent.Routes[key], newNotificationPolicy)
+		if notificationPolicy.Routes != nil && updateInPlace(&parent.Routes[key], newNotificationPolicy) {
+			return true
 		}
 	}
 	return false
@@ -83,12 +88,7 @@ func updateInPlace(parent *alerting.NotificationPolicy, newNotificationPolicy al
 
 func deleteInPlace(parent *alerting.NotificationPolicy, newNotificationPolicy alerting.NotificationPolicy) bool {
 	for key, notificationPolicy := range parent.Routes {
-		matchersEqual := false
-		if notificationPolicy.ObjectMatchers != nil {
-			matchersEqual = objectMatchersEqual(*notificationPolicy.ObjectMatchers, *newNotificationPolicy.ObjectMatchers)
-		}
-		receiversEqual := reflect.DeepEqual(notificationPolicy.Receiver, newNotificationPolicy.Receiver)
-		if matchersEqual && receiversEqual {
+		if policiesMatch(notificationPolicy, newNotificationPolicy) {
 			if len(parent.Routes) == 1 {
 				parent.Routes = nil
 				return true
@@ -99,8 +99,8 @@ func deleteInPlace(parent *alerting.NotificationPolicy, newNotificationPolicy al
 				return false
 			}
 		}
-		if notificationPolicy.Routes != nil {
-			return deleteInPlace(&parent.Routes[key], newNotificationPolicy)
+		if notificationPolicy.Routes != nil && deleteInPlace(&parent.Routes[key], newNotificationPolicy) {
+			return true
 		}
 	}
 	return false
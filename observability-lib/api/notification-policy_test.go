@@ -112,6 +112,47 @@ func TestPolicyExists(t *testing.T) {
 		require.False(t, result)
 	})
 
+	t.Run("policyExists return true if policy exists after a nested policy", func(t *testing.T) {
+		notificationPolicyTree := &alerting.NotificationPolicy{
+			Receiver: Pointer("grafana-default-email"),
+			Routes: []alerting.NotificationPolicy{
+				{
+					Receiver: Pointer("slack"),
+					ObjectMatchers: &alerting.ObjectMatchers{
+						{"team", "=", "chainlink"},
+					},
+					Routes: []alerting.NotificationPolicy{
+						{
+							Receiver: Pointer("pagerduty"),
+							ObjectMatchers: &alerting.ObjectMatchers{
+								{"env", "=", "production"},
+							},
+						},
+					},
+				},
+				{
+					Receiver: Pointer("opsgenie"),
+					ObjectMatchers: &alerting.ObjectMatchers{
+						{"team", "=", "infra"},
+					},
+				},
+			},
+		}
+
+		newNotificationPolicy := alerting.NotificationPolicy{
+			Receiver: Pointer("opsgenie"),
+			ObjectMatchers: &alerting.ObjectMatchers{
+				{"team", "=", "infra"},
+			},
+		}
+		require.True(t, policyExist(*notificationPolicyTree, newNotificationPolicy))
+		require.Equal(t, notificationPolicyTree.Routes[1], *GetNestedPolicy(*notificationPolicyTree, newNotificationPolicy))
+
+		newNotificationPolicy.Continue = Pointer(true)
+		require.True(t, updateInPlace(notificationPolicyTree, newNotificationPolicy))
+		require.Equal(t, newNotificationPolicy, notificationPolicyTree.Routes[1])
+	})
+
 	t.Run("updateInPlace should update notification policy if already exists", func(t *testing.T) {
 		notificationPolicyTree := &alerting.NotificationPolicy{
 			Receiver: Pointer("grafana-default-email"),
@@ -0,0 +1,79 @@
+package plan
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/smartcontractkit/chainlink-common/observability-lib/grafana"
+	"github.com/spf13/cobra"
+)
+
+var Cmd = &cobra.Command{
+	Use:   "plan",
+	Short: "Show the changes deploying a generated dashboard would make, and optionally apply them",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		file, errRead := os.ReadFile(cmd.Flag("file").Value.String())
+		if errRead != nil {
+			return errRead
+		}
+
+		var observability grafana.Observability
+		if errUnmarshal := json.Unmarshal(file, &observability); errUnmarshal != nil {
+			return fmt.Errorf("error reading generated dashboard: %w", errUnmarshal)
+		}
+
+		enableAlerts, _ := cmd.Flags().GetBool("enable-alerts")
+		options := &grafana.DeployOptions{
+			GrafanaURL:            cmd.Flag("grafana-url").Value.String(),
+			GrafanaToken:          cmd.Flag("grafana-token").Value.String(),
+			FolderName:            cmd.Flag("folder-name").Value.String(),
+			EnableAlerts:          enableAlerts,
+			NotificationTemplates: cmd.Flag("notification-templates").Value.String(),
+		}
+
+		plan, errPlan := observability.Plan(options)
+		if errPlan != nil {
+			return errPlan
+		}
+
+		switch output := cmd.Flag("output").Value.String(); output {
+		case "text":
+			fmt.Fprint(cmd.OutOrStdout(), plan.String())
+		case "json":
+			planJSON, errMarshal := json.MarshalIndent(plan, "", "  ")
+			if errMarshal != nil {
+				return errMarshal
+			}
+			fmt.Fprintln(cmd.OutOrStdout(), string(planJSON))
+		default:
+			return fmt.Errorf("unsupported output %q, must be text or json", output)
+		}
+
+		apply, _ := cmd.Flags().GetBool("apply")
+		if !apply || !plan.HasChanges() {
+			return nil
+		}
+
+		return observability.ApplyPlan(plan, options)
+	},
+}
+
+func init() {
+	Cmd.Flags().String("file", "", "JSON file generated by Observability.GenerateJSON")
+	errFile := Cmd.MarkFlagRequired("file")
+	if errFile != nil {
+		panic(errFile)
+	}
+
+	Cmd.Flags().String("folder-name", "", "Grafana folder of the dashboard")
+	errFolderName := Cmd.MarkFlagRequired("folder-name")
+	if errFolderName != nil {
+		panic(errFolderName)
+	}
+
+	Cmd.Flags().Bool("enable-alerts", false, "Deploy the alert rules, they are deleted otherwise")
+	Cmd.Flags().String("notification-templates", "", "YAML file of notification templates, applied with alert rule changes")
+	Cmd.Flags().String("output", "text", "Output format of the plan: text or json")
+	Cmd.Flags().Bool("apply", false, "Apply the changes of the plan")
+}
@@ -0,0 +1,98 @@
+package generate
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/smartcontractkit/chainlink-common/observability-lib/grafana"
+	"github.com/spf13/cobra"
+)
+
+var Cmd = &cobra.Command{
+	Use:   "generate",
+	Short: "Generate a starter dashboard from the Prometheus metrics of a service",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		metrics, errMetrics := readMetrics(cmd.Flag("metrics").Value.String())
+		if errMetrics != nil {
+			return errMetrics
+		}
+		defer metrics.Close()
+
+		gatherer, errParse := grafana.ParseMetrics(metrics)
+		if errParse != nil {
+			return errParse
+		}
+
+		ignoredLabels, _ := cmd.Flags().GetStringSlice("ignored-labels")
+		options := &grafana.MetricsDashboardOptions{
+			Name:          cmd.Flag("name").Value.String(),
+			Datasource:    cmd.Flag("datasource").Value.String(),
+			Prefix:        cmd.Flag("prefix").Value.String(),
+			IgnoredLabels: ignoredLabels,
+		}
+
+		var output []byte
+		switch format := cmd.Flag("output").Value.String(); format {
+		case "json":
+			builder, errBuilder := grafana.NewMetricsBuilder(gatherer, options)
+			if errBuilder != nil {
+				return errBuilder
+			}
+			o, errBuild := builder.Build()
+			if errBuild != nil {
+				return errBuild
+			}
+			var errJSON error
+			output, errJSON = o.GenerateJSON()
+			if errJSON != nil {
+				return errJSON
+			}
+		case "go":
+			var errGenerate error
+			output, errGenerate = grafana.GenerateMetricsGoCode(gatherer, options, cmd.Flag("package").Value.String())
+			if errGenerate != nil {
+				return errGenerate
+			}
+		default:
+			return fmt.Errorf("unsupported output %q, must be json or go", format)
+		}
+
+		_, errWrite := fmt.Fprintln(cmd.OutOrStdout(), string(output))
+		return errWrite
+	},
+}
+
+// readMetrics opens a file of scraped metrics, or scrapes them if given a URL
+func readMetrics(source string) (io.ReadCloser, error) {
+	if !strings.HasPrefix(source, "http://") && !strings.HasPrefix(source, "https://") {
+		return os.Open(source)
+	}
+
+	resp, err := http.Get(source) //nolint:gosec // the URL is provided by the user running the command
+	if err != nil {
+		return nil, fmt.Errorf("error scraping metrics: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		return nil, fmt.Errorf("error scraping metrics, received unexpected status code %d", resp.StatusCode)
+	}
+	return resp.Body, nil
+}
+
+func init() {
+	Cmd.Flags().String("metrics", "", "File of scraped metrics in the Prometheus text format, or URL of a /metrics endpoint")
+	errMetrics := Cmd.MarkFlagRequired("metrics")
+	if errMetrics != nil {
+		panic(errMetrics)
+	}
+
+	Cmd.Flags().String("name", "", "Name of the dashboard")
+	Cmd.Flags().String("datasource", "Prometheus", "Name of the Prometheus datasource")
+	Cmd.Flags().String("prefix", "", "Only include the metrics whose name starts with the prefix")
+	Cmd.Flags().StringSlice("ignored-labels", []string{"instance", "job"}, "Labels which are not turned into template variables")
+	Cmd.Flags().String("output", "json", "Output format: json or go")
+	Cmd.Flags().String("package", "dashboard", "Package of the generated Go code")
+}
@@ -4,6 +4,7 @@ import (
 	"log"
 
 	"github.com/smartcontractkit/chainlink-common/observability-lib/cmd/api"
+	"github.com/smartcontractkit/chainlink-common/observability-lib/cmd/generate"
 	"github.com/spf13/cobra"
 )
 
@@ -14,6 +15,7 @@ var rootCmd = &cobra.Command{
 
 func init() {
 	rootCmd.AddCommand(api.Cmd)
+	rootCmd.AddCommand(generate.Cmd)
 }
 
 func Execute() {
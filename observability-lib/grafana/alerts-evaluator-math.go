@@ -0,0 +1,409 @@
+package grafana
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// mathValue is a scalar of the expression or the results of queries
+type mathValue struct {
+	scalar  bool
+	value   float64
+	results evalResults
+}
+
+type mathNode interface{}
+
+type (
+	mathNumber float64
+	mathRef    string
+	mathUnary  struct {
+		op string
+		x  mathNode
+	}
+	mathBinary struct {
+		op   string
+		x, y mathNode
+	}
+	mathCall struct {
+		function string
+		arg      mathNode
+	}
+)
+
+var mathFunctions = map[string]func(float64) float64{
+	"abs":   math.Abs,
+	"ceil":  math.Ceil,
+	"floor": math.Floor,
+	"log":   math.Log,
+	"round": math.Round,
+	"is_nan": func(v float64) float64 {
+		return mathBool(math.IsNaN(v))
+	},
+	"is_inf": func(v float64) float64 {
+		return mathBool(math.IsInf(v, 0))
+	},
+	"is_number": func(v float64) float64 {
+		return mathBool(!math.IsNaN(v) && !math.IsInf(v, 0))
+	},
+	// missing samples are not part of the series, so there are no null values
+	"is_null": func(float64) float64 {
+		return 0
+	},
+}
+
+// binary operators by precedence, from the lowest
+var mathOperators = [][]string{
+	{"||"},
+	{"&&"},
+	{"==", "!=", ">=", "<=", ">", "<"},
+	{"+", "-"},
+	{"*", "/", "%"},
+	{"**"},
+}
+
+func (r *ruleEvaluation) evaluateMath(expression string) (evalResults, error) {
+	node, err := parseMath(expression)
+	if err != nil {
+		return nil, fmt.Errorf("invalid math expression %q: %w", expression, err)
+	}
+
+	value, err := r.evaluateMathNode(node)
+	if err != nil {
+		return nil, fmt.Errorf("math expression %q: %w", expression, err)
+	}
+	if value.scalar {
+		return evalResults{{labels: map[string]string{}, value: value.value}}, nil
+	}
+	return value.results, nil
+}
+
+func (r *ruleEvaluation) evaluateMathNode(node mathNode) (mathValue, error) {
+	switch n := node.(type) {
+	case mathNumber:
+		return mathValue{scalar: true, value: float64(n)}, nil
+	case mathRef:
+		results, err := r.evaluate(string(n))
+		if err != nil {
+			return mathValue{}, err
+		}
+		return mathValue{results: results}, nil
+	case mathUnary:
+		x, err := r.evaluateMathNode(n.x)
+		if err != nil {
+			return mathValue{}, err
+		}
+		f := func(v float64) float64 { return -v }
+		if n.op == "!" {
+			f = func(v float64) float64 { return mathBool(v == 0) }
+		}
+		return mapMathValue(x, f), nil
+	case mathCall:
+		x, err := r.evaluateMathNode(n.arg)
+		if err != nil {
+			return mathValue{}, err
+		}
+		return mapMathValue(x, mathFunctions[n.function]), nil
+	case mathBinary:
+		x, err := r.evaluateMathNode(n.x)
+		if err != nil {
+			return mathValue{}, err
+		}
+		y, err := r.evaluateMathNode(n.y)
+		if err != nil {
+			return mathValue{}, err
+		}
+		return combineMathValues(x, y, mathBinaryFunc(n.op)), nil
+	default:
+		return mathValue{}, fmt.Errorf("unsupported expression %v", node)
+	}
+}
+
+func mathBool(b bool) float64 {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+func mathBinaryFunc(op string) func(x, y float64) float64 {
+	switch op {
+	case "||":
+		return func(x, y float64) float64 { return mathBool(x != 0 || y != 0) }
+	case "&&":
+		return func(x, y float64) float64 { return mathBool(x != 0 && y != 0) }
+	case "==":
+		return func(x, y float64) float64 { return mathBool(x == y) }
+	case "!=":
+		return func(x, y float64) float64 { return mathBool(x != y) }
+	case ">=":
+		return func(x, y float64) float64 { return mathBool(x >= y) }
+	case "<=":
+		return func(x, y float64) float64 { return mathBool(x <= y) }
+	case ">":
+		return func(x, y float64) float64 { return mathBool(x > y) }
+	case "<":
+		return func(x, y float64) float64 { return mathBool(x < y) }
+	case "+":
+		return func(x, y float64) float64 { return x + y }
+	case "-":
+		return func(x, y float64) float64 { return x - y }
+	case "*":
+		return func(x, y float64) float64 { return x * y }
+	case "/":
+		return func(x, y float64) float64 { return x / y }
+	case "%":
+		return math.Mod
+	default:
+		return math.Pow
+	}
+}
+
+func mapMathValue(x mathValue, f func(float64) float64) mathValue {
+	if x.scalar {
+		return mathValue{scalar: true, value: f(x.value)}
+	}
+	return mathValue{results: mapResults(x.results, f)}
+}
+
+// combineMathValues applies f to the scalars, or to the results whose labels match as Grafana does:
+// the labels of one are a subset of the labels of the other, or there is a single result on each side.
+func combineMathValues(x, y mathValue, f func(x, y float64) float64) mathValue {
+	switch {
+	case x.scalar && y.scalar:
+		return mathValue{scalar: true, value: f(x.value, y.value)}
+	case x.scalar:
+		return mapMathValue(y, func(v float64) float64 { return f(x.value, v) })
+	case y.scalar:
+		return mapMathValue(x, func(v float64) float64 { return f(v, y.value) })
+	}
+
+	var results evalResults
+	for _, a := range x.results {
+		for _, b := range y.results {
+			labels, ok := unionLabels(a.labels, b.labels)
+			if !ok && !(len(x.results) == 1 && len(y.results) == 1) {
+				continue
+			}
+			if !ok {
+				labels = a.labels
+			}
+			results = append(results, combineItems(a, b, labels, f))
+		}
+	}
+	return mathValue{results: results}
+}
+
+func unionLabels(a, b map[string]string) (map[string]string, bool) {
+	isSubset := func(small, big map[string]string) bool {
+		for name, value := range small {
+			if v, ok := big[name]; !ok || v != value {
+				return false
+			}
+		}
+		return true
+	}
+
+	if isSubset(a, b) {
+		return b, true
+	}
+	if isSubset(b, a) {
+		return a, true
+	}
+	return nil, false
+}
+
+// combineItems applies f to numbers, to each point of a series with a number, or to the points of series at the same time
+func combineItems(a, b evalItem, labels map[string]string, f func(x, y float64) float64) evalItem {
+	switch {
+	case !a.isSeries && !b.isSeries:
+		return evalItem{labels: labels, value: f(a.value, b.value)}
+	case !a.isSeries:
+		item := mapItem(b, func(v float64) float64 { return f(a.value, v) })
+		item.labels = labels
+		return item
+	case !b.isSeries:
+		item := mapItem(a, func(v float64) float64 { return f(v, b.value) })
+		item.labels = labels
+		return item
+	}
+
+	item := evalItem{labels: labels, isSeries: true}
+	for _, pa := range a.series {
+		for _, pb := range b.series {
+			if pa.t == pb.t {
+				item.series = append(item.series, evalPoint{t: pa.t, v: f(pa.v, pb.v)})
+			}
+		}
+	}
+	return item
+}
+
+// mathParser parses the math expressions of Grafana, such as `$A > 1 && abs($B) < 10`
+type mathParser struct {
+	tokens []string
+	pos    int
+}
+
+func parseMath(expression string) (mathNode, error) {
+	tokens, err := tokenizeMath(expression)
+	if err != nil {
+		return nil, err
+	}
+	p := &mathParser{tokens: tokens}
+	node, err := p.parseBinary(0)
+	if err != nil {
+		return nil, err
+	}
+	if p.pos < len(p.tokens) {
+		return nil, fmt.Errorf("unexpected %q", p.tokens[p.pos])
+	}
+	return node, nil
+}
+
+func (p *mathParser) peek() string {
+	if p.pos < len(p.tokens) {
+		return p.tokens[p.pos]
+	}
+	return ""
+}
+
+func (p *mathParser) next() string {
+	token := p.peek()
+	p.pos++
+	return token
+}
+
+func (p *mathParser) parseBinary(precedence int) (mathNode, error) {
+	if precedence == len(mathOperators) {
+		return p.parseUnary()
+	}
+
+	x, err := p.parseBinary(precedence + 1)
+	if err != nil {
+		return nil, err
+	}
+	for contains(mathOperators[precedence], p.peek()) {
+		op := p.next()
+		// ** is right associative
+		nextPrecedence := precedence + 1
+		if op == "**" {
+			nextPrecedence = precedence
+		}
+		y, err := p.parseBinary(nextPrecedence)
+		if err != nil {
+			return nil, err
+		}
+		x = mathBinary{op: op, x: x, y: y}
+	}
+	return x, nil
+}
+
+func (p *mathParser) parseUnary() (mathNode, error) {
+	token := p.next()
+	switch {
+	case token == "":
+		return nil, fmt.Errorf("unexpected end of expression")
+	case token == "-" || token == "!":
+		x, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return mathUnary{op: token, x: x}, nil
+	case token == "(":
+		x, err := p.parseBinary(0)
+		if err != nil {
+			return nil, err
+		}
+		if p.next() != ")" {
+			return nil, fmt.Errorf("missing )")
+		}
+		return x, nil
+	case strings.HasPrefix(token, "$"):
+		return mathRef(strings.Trim(token[1:], "{}")), nil
+	case mathFunctions[token] != nil:
+		if p.next() != "(" {
+			return nil, fmt.Errorf("missing ( after %s", token)
+		}
+		arg, err := p.parseBinary(0)
+		if err != nil {
+			return nil, err
+		}
+		if p.next() != ")" {
+			return nil, fmt.Errorf("missing ) after the argument of %s", token)
+		}
+		return mathCall{function: token, arg: arg}, nil
+	default:
+		v, err := strconv.ParseFloat(token, 64)
+		if err != nil {
+			return nil, fmt.Errorf("unexpected %q", token)
+		}
+		return mathNumber(v), nil
+	}
+}
+
+func tokenizeMath(expression string) ([]string, error) {
+	var tokens []string
+	for i := 0; i < len(expression); {
+		c := rune(expression[i])
+		switch {
+		case unicode.IsSpace(c):
+			i++
+		case c == '$':
+			end := i + 1
+			if end < len(expression) && expression[end] == '{' {
+				closing := strings.IndexByte(expression[end:], '}')
+				if closing < 0 {
+					return nil, fmt.Errorf("missing } in reference")
+				}
+				end += closing + 1
+			} else {
+				for end < len(expression) && isMathIdentifier(rune(expression[end])) {
+					end++
+				}
+			}
+			if end == i+1 {
+				return nil, fmt.Errorf("missing reference after $")
+			}
+			tokens = append(tokens, expression[i:end])
+			i = end
+		case unicode.IsDigit(c) || c == '.':
+			end := i
+			for end < len(expression) && (unicode.IsDigit(rune(expression[end])) || expression[end] == '.' ||
+				((expression[end] == 'e' || expression[end] == 'E') && end+1 < len(expression)) ||
+				((expression[end] == '+' || expression[end] == '-') && (expression[end-1] == 'e' || expression[end-1] == 'E'))) {
+				end++
+			}
+			tokens = append(tokens, expression[i:end])
+			i = end
+		case isMathIdentifier(c):
+			end := i
+			for end < len(expression) && isMathIdentifier(rune(expression[end])) {
+				end++
+			}
+			tokens = append(tokens, expression[i:end])
+			i = end
+		default:
+			operator := ""
+			for _, candidate := range []string{"**", "||", "&&", "==", "!=", ">=", "<=", ">", "<", "+", "-", "*", "/", "%", "!", "(", ")"} {
+				if strings.HasPrefix(expression[i:], candidate) {
+					operator = candidate
+					break
+				}
+			}
+			if operator == "" {
+				return nil, fmt.Errorf("unexpected %q", c)
+			}
+			tokens = append(tokens, operator)
+			i += len(operator)
+		}
+	}
+	return tokens, nil
+}
+
+func isMathIdentifier(c rune) bool {
+	return c == '_' || unicode.IsLetter(c) || unicode.IsDigit(c)
+}
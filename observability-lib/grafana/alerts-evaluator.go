@@ -0,0 +1,806 @@
+package grafana
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/grafana/grafana-foundation-sdk/go/alerting"
+)
+
+// TestSeries is a series of synthetic samples in the notation of promtool unit tests
+type TestSeries struct {
+	// Series is the name and labels of the series, such as `eth_balance{account="0x1"}`
+	Series string
+	// Values of the samples, one per interval, such as "10 9 8 _ 7" or "10-1x3" for "10 9 8 7".
+	// "_" is a missing sample and "_x3" three missing samples.
+	Values string
+}
+
+type AlertTestOptions struct {
+	// Interval between the samples of the series, defaults to 1m
+	Interval time.Duration
+	// EvalTime is the time of the evaluation since the first sample, defaults to the time of the last sample
+	EvalTime time.Duration
+	// InputSeries are the results of the queries which are vector selectors, such as `eth_balance{account=~"0x.*"}`
+	InputSeries []TestSeries
+	// QueryResults are the results of the other queries by PromQL expression, such as `sum(rate(errors_total[5m]))`.
+	// They take precedence over the input series.
+	QueryResults map[string][]TestSeries
+}
+
+type AlertState string
+
+const (
+	AlertStateNormal   AlertState = "Normal"
+	AlertStatePending  AlertState = "Pending"
+	AlertStateAlerting AlertState = "Alerting"
+	AlertStateNoData   AlertState = "NoData"
+	AlertStateError    AlertState = "Error"
+)
+
+type AlertInstance struct {
+	Labels map[string]string
+	State  AlertState
+	// Value of the condition
+	Value float64
+}
+
+type AlertEvaluation struct {
+	Title     string
+	State     AlertState
+	Instances []AlertInstance
+	// Error of the evaluation, the state is then set by the execution error state of the rule
+	Error error
+}
+
+type AlertEvaluations []AlertEvaluation
+
+// Firing returns the titles of the alerts which are firing
+func (e AlertEvaluations) Firing() []string {
+	var titles []string
+	for _, evaluation := range e {
+		if evaluation.State == AlertStateAlerting {
+			titles = append(titles, evaluation.Title)
+		}
+	}
+	return titles
+}
+
+// EvaluateAlerts evaluates the alert rules offline against synthetic series, as Grafana would at the evaluation time.
+// Queries to Prometheus return the test series, expressions are computed as by Grafana.
+// An alert with a pending period is firing if its condition was met at every evaluation of its rule group in the period.
+func (o *Observability) EvaluateAlerts(options *AlertTestOptions) (AlertEvaluations, error) {
+	evaluator, err := newAlertEvaluator(options)
+	if err != nil {
+		return nil, err
+	}
+
+	var evaluations AlertEvaluations
+	for _, rule := range o.Alerts {
+		evaluation, errEvaluate := evaluator.evaluateRule(rule, o.alertGroupInterval(rule))
+		if errEvaluate != nil {
+			return nil, fmt.Errorf("error evaluating alert %s: %w", rule.Title, errEvaluate)
+		}
+		evaluations = append(evaluations, evaluation)
+	}
+
+	return evaluations, nil
+}
+
+func (o *Observability) alertGroupInterval(rule alerting.Rule) time.Duration {
+	ruleGroup := rule.RuleGroup
+	if ruleGroup == "" && o.Dashboard != nil {
+		ruleGroup = *o.Dashboard.Title
+	}
+	for _, alertGroup := range o.AlertGroups {
+		if alertGroup.Title != nil && *alertGroup.Title == ruleGroup && alertGroup.Interval != nil {
+			return time.Duration(*alertGroup.Interval) * time.Second
+		}
+	}
+	return time.Minute
+}
+
+// errAlertTest is an error of the test data, which is returned instead of setting the execution error state
+var errAlertTest = errors.New("invalid test data")
+
+type evalPoint struct {
+	t time.Duration
+	v float64
+}
+
+// evalItem is a number or a series, labelled
+type evalItem struct {
+	labels   map[string]string
+	isSeries bool
+	value    float64
+	series   []evalPoint
+}
+
+// evalResults are the results of a query, no results is no data
+type evalResults []evalItem
+
+type testSeries struct {
+	labels map[string]string
+	points []evalPoint
+}
+
+type alertEvaluator struct {
+	evalTime     time.Duration
+	inputSeries  []testSeries
+	queryResults map[string][]testSeries
+}
+
+func newAlertEvaluator(options *AlertTestOptions) (*alertEvaluator, error) {
+	interval := options.Interval
+	if interval == 0 {
+		interval = time.Minute
+	}
+
+	evaluator := &alertEvaluator{
+		evalTime:     options.EvalTime,
+		queryResults: map[string][]testSeries{},
+	}
+
+	var lastSample time.Duration
+	parse := func(series []TestSeries) ([]testSeries, error) {
+		var parsed []testSeries
+		for _, s := range series {
+			p, err := parseTestSeries(s, interval)
+			if err != nil {
+				return nil, err
+			}
+			if len(p.points) > 0 && p.points[len(p.points)-1].t > lastSample {
+				lastSample = p.points[len(p.points)-1].t
+			}
+			parsed = append(parsed, p)
+		}
+		return parsed, nil
+	}
+
+	var err error
+	if evaluator.inputSeries, err = parse(options.InputSeries); err != nil {
+		return nil, err
+	}
+	for query, series := range options.QueryResults {
+		if evaluator.queryResults[strings.TrimSpace(query)], err = parse(series); err != nil {
+			return nil, err
+		}
+	}
+
+	if evaluator.evalTime == 0 {
+		evaluator.evalTime = lastSample
+	}
+
+	return evaluator, nil
+}
+
+func (e *alertEvaluator) evaluateRule(rule alerting.Rule, groupInterval time.Duration) (AlertEvaluation, error) {
+	evaluation := AlertEvaluation{Title: rule.Title}
+
+	results, err := e.evaluateCondition(rule, e.evalTime)
+	if errors.Is(err, errAlertTest) {
+		return AlertEvaluation{}, err
+	}
+	if err != nil {
+		evaluation.Error = err
+		switch rule.ExecErrState {
+		case alerting.RuleExecErrStateAlerting:
+			evaluation.State = AlertStateAlerting
+		case alerting.RuleExecErrStateOK:
+			evaluation.State = AlertStateNormal
+		default:
+			evaluation.State = AlertStateError
+		}
+		return evaluation, nil
+	}
+
+	if len(results) == 0 {
+		switch rule.NoDataState {
+		case alerting.RuleNoDataStateAlerting:
+			evaluation.State = AlertStateAlerting
+		case alerting.RuleNoDataStateOK:
+			evaluation.State = AlertStateNormal
+		default:
+			evaluation.State = AlertStateNoData
+		}
+		return evaluation, nil
+	}
+
+	pendingPeriod := time.Duration(0)
+	if rule.For != "" {
+		pendingPeriod, err = time.ParseDuration(rule.For)
+		if err != nil {
+			return AlertEvaluation{}, fmt.Errorf("invalid pending period %q: %w", rule.For, err)
+		}
+	}
+
+	// Instances are firing once their condition was met at every evaluation of the pending period
+	var previousEvaluations []map[string]bool
+	for evalTime := e.evalTime - groupInterval; evalTime >= e.evalTime-pendingPeriod; evalTime -= groupInterval {
+		firing := map[string]bool{}
+		if evalTime >= 0 {
+			previousResults, errPrevious := e.evaluateCondition(rule, evalTime)
+			if errors.Is(errPrevious, errAlertTest) {
+				return AlertEvaluation{}, errPrevious
+			}
+			for _, item := range previousResults {
+				firing[labelsKey(item.labels)] = errPrevious == nil && conditionMet(item.value)
+			}
+		}
+		previousEvaluations = append(previousEvaluations, firing)
+	}
+
+	evaluation.State = AlertStateNormal
+	for _, item := range results {
+		instance := AlertInstance{Labels: item.labels, State: AlertStateNormal, Value: item.value}
+		if conditionMet(item.value) {
+			instance.State = AlertStateAlerting
+			for _, firing := range previousEvaluations {
+				if !firing[labelsKey(item.labels)] {
+					instance.State = AlertStatePending
+				}
+			}
+		}
+
+		if instance.State == AlertStateAlerting || (instance.State == AlertStatePending && evaluation.State == AlertStateNormal) {
+			evaluation.State = instance.State
+		}
+		evaluation.Instances = append(evaluation.Instances, instance)
+	}
+	sort.Slice(evaluation.Instances, func(i, j int) bool {
+		return labelsKey(evaluation.Instances[i].Labels) < labelsKey(evaluation.Instances[j].Labels)
+	})
+
+	return evaluation, nil
+}
+
+func conditionMet(value float64) bool {
+	return value != 0 && !math.IsNaN(value)
+}
+
+// evaluateCondition evaluates the condition of the rule and checks it can be alerted on
+func (e *alertEvaluator) evaluateCondition(rule alerting.Rule, evalTime time.Duration) (evalResults, error) {
+	queries := map[string]alerting.Query{}
+	for _, query := range rule.Data {
+		if query.RefId != nil {
+			queries[*query.RefId] = query
+		}
+	}
+
+	evaluation := &ruleEvaluation{evaluator: e, evalTime: evalTime, queries: queries, results: map[string]evalResults{}}
+	results, err := evaluation.evaluate(rule.Condition)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, item := range results {
+		if item.isSeries {
+			return nil, fmt.Errorf("invalid format of the results of the condition %s: looks like time series data, only reduced data can be alerted on", rule.Condition)
+		}
+	}
+	return results, nil
+}
+
+// ruleEvaluation evaluates the queries of a rule at a time, each once
+type ruleEvaluation struct {
+	evaluator *alertEvaluator
+	evalTime  time.Duration
+	queries   map[string]alerting.Query
+	results   map[string]evalResults
+	visiting  map[string]bool
+}
+
+type queryModel struct {
+	Type        string   `json:"type"`
+	Expr        string   `json:"expr"`
+	Instant     *bool    `json:"instant"`
+	Expression  string   `json:"expression"`
+	IntervalMs  *float64 `json:"intervalMs"`
+	Reducer     string   `json:"reducer"`
+	Downsampler string   `json:"downsampler"`
+	Upsampler   string   `json:"upsampler"`
+	Settings    *struct {
+		Mode             string   `json:"mode"`
+		ReplaceWithValue *float64 `json:"replaceWithValue"`
+	} `json:"settings"`
+	Conditions []struct {
+		Evaluator struct {
+			Params []float64 `json:"params"`
+			Type   string    `json:"type"`
+		} `json:"evaluator"`
+	} `json:"conditions"`
+}
+
+func (r *ruleEvaluation) evaluate(refID string) (evalResults, error) {
+	refID = strings.TrimPrefix(strings.TrimSpace(refID), "$")
+	if results, ok := r.results[refID]; ok {
+		return results, nil
+	}
+	query, ok := r.queries[refID]
+	if !ok {
+		return nil, fmt.Errorf("query %s is not defined", refID)
+	}
+	if r.visiting == nil {
+		r.visiting = map[string]bool{}
+	}
+	if r.visiting[refID] {
+		return nil, fmt.Errorf("query %s depends on itself", refID)
+	}
+	r.visiting[refID] = true
+	defer delete(r.visiting, refID)
+
+	raw, err := json.Marshal(query.Model)
+	if err != nil {
+		return nil, err
+	}
+	var model queryModel
+	if err = json.Unmarshal(raw, &model); err != nil {
+		return nil, fmt.Errorf("invalid model of query %s: %w", refID, err)
+	}
+
+	// the time range of the query, relative to the evaluation time
+	start, end := r.evalTime-600*time.Second, r.evalTime
+	if query.RelativeTimeRange != nil {
+		if query.RelativeTimeRange.From != nil {
+			start = r.evalTime - time.Duration(*query.RelativeTimeRange.From)*time.Second
+		}
+		if query.RelativeTimeRange.To != nil {
+			end = r.evalTime - time.Duration(*query.RelativeTimeRange.To)*time.Second
+		}
+	}
+
+	var results evalResults
+	if query.DatasourceUid == nil || *query.DatasourceUid != "__expr__" {
+		results, err = r.evaluator.queryPrometheus(model.Expr, model.Instant != nil && *model.Instant, start, end)
+	} else {
+		results, err = r.evaluateExpression(refID, model, start, end)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	r.results[refID] = results
+	return results, nil
+}
+
+func (r *ruleEvaluation) evaluateExpression(refID string, model queryModel, start, end time.Duration) (evalResults, error) {
+	if model.Type == "math" {
+		return r.evaluateMath(model.Expression)
+	}
+
+	input, err := r.evaluate(model.Expression)
+	if err != nil {
+		return nil, err
+	}
+
+	switch model.Type {
+	case "reduce":
+		mode, replaceWith := "", math.NaN()
+		if model.Settings != nil {
+			mode = model.Settings.Mode
+			if model.Settings.ReplaceWithValue != nil {
+				replaceWith = *model.Settings.ReplaceWithValue
+			}
+		}
+		var results evalResults
+		for _, item := range input {
+			if !item.isSeries {
+				results = append(results, item)
+				continue
+			}
+			value, errReduce := reduceSeries(model.Reducer, mode, replaceWith, item.series)
+			if errReduce != nil {
+				return nil, fmt.Errorf("query %s: %w", refID, errReduce)
+			}
+			results = append(results, evalItem{labels: item.labels, value: value})
+		}
+		return results, nil
+	case "resample":
+		window := time.Second
+		if model.IntervalMs != nil && *model.IntervalMs > 0 {
+			window = time.Duration(*model.IntervalMs * float64(time.Millisecond))
+		}
+		var results evalResults
+		for _, item := range input {
+			if !item.isSeries {
+				return nil, fmt.Errorf("query %s: can only resample time series", refID)
+			}
+			series, errResample := resampleSeries(item.series, window, model.Downsampler, model.Upsampler, start, end)
+			if errResample != nil {
+				return nil, fmt.Errorf("query %s: %w", refID, errResample)
+			}
+			results = append(results, evalItem{labels: item.labels, isSeries: true, series: series})
+		}
+		return results, nil
+	case "threshold":
+		if len(model.Conditions) == 0 {
+			return nil, fmt.Errorf("query %s: threshold has no condition", refID)
+		}
+		evaluator := model.Conditions[0].Evaluator
+		threshold, errThreshold := thresholdFunc(evaluator.Type, evaluator.Params)
+		if errThreshold != nil {
+			return nil, fmt.Errorf("query %s: %w", refID, errThreshold)
+		}
+		return mapResults(input, threshold), nil
+	default:
+		return nil, fmt.Errorf("query %s: unsupported expression type %q", refID, model.Type)
+	}
+}
+
+// queryPrometheus returns the test series of a query, their last sample for instant queries
+func (e *alertEvaluator) queryPrometheus(expr string, instant bool, start, end time.Duration) (evalResults, error) {
+	series, ok := e.queryResults[strings.TrimSpace(expr)]
+	if !ok {
+		matchers, err := parseSeriesSelector(expr)
+		if err != nil {
+			return nil, fmt.Errorf("%w: no query results for %q, which is not a vector selector matching the input series", errAlertTest, expr)
+		}
+		for _, s := range e.inputSeries {
+			if matchers.matches(s.labels) {
+				series = append(series, s)
+			}
+		}
+	}
+
+	// Prometheus looks back 5m for the latest sample of instant queries
+	const lookback = 5 * time.Minute
+
+	var results evalResults
+	for _, s := range series {
+		// as after any PromQL function, the name of the metric is not a label of the results
+		labels := map[string]string{}
+		for name, value := range s.labels {
+			if name != "__name__" {
+				labels[name] = value
+			}
+		}
+
+		if instant {
+			for i := len(s.points) - 1; i >= 0; i-- {
+				if s.points[i].t <= end && s.points[i].t > end-lookback {
+					results = append(results, evalItem{labels: labels, value: s.points[i].v})
+					break
+				}
+			}
+			continue
+		}
+
+		var points []evalPoint
+		for _, point := range s.points {
+			if point.t >= start && point.t <= end {
+				points = append(points, point)
+			}
+		}
+		if len(points) > 0 {
+			results = append(results, evalItem{labels: labels, isSeries: true, series: points})
+		}
+	}
+	return results, nil
+}
+
+func reduceSeries(reducer string, mode string, replaceWith float64, series []evalPoint) (float64, error) {
+	values := make([]float64, 0, len(series))
+	for _, point := range series {
+		v := point.v
+		if math.IsNaN(v) || math.IsInf(v, 0) {
+			switch mode {
+			case "dropNN":
+				continue
+			case "replaceNN":
+				v = replaceWith
+			}
+		}
+		values = append(values, v)
+	}
+
+	if reducer == "count" {
+		return float64(len(values)), nil
+	}
+	if reducer == "sum" && len(values) == 0 {
+		return 0, nil
+	}
+	if len(values) == 0 {
+		return math.NaN(), nil
+	}
+
+	switch reducer {
+	case "sum", "mean":
+		sum := 0.0
+		for _, v := range values {
+			sum += v
+		}
+		if reducer == "mean" {
+			return sum / float64(len(values)), nil
+		}
+		return sum, nil
+	case "min", "max":
+		result := values[0]
+		for _, v := range values[1:] {
+			if math.IsNaN(v) {
+				return math.NaN(), nil
+			}
+			if (reducer == "min" && v < result) || (reducer == "max" && v > result) {
+				result = v
+			}
+		}
+		return result, nil
+	case "last":
+		return values[len(values)-1], nil
+	case "median":
+		sorted := append([]float64{}, values...)
+		sort.Float64s(sorted)
+		middle := len(sorted) / 2
+		if len(sorted)%2 == 0 {
+			return (sorted[middle-1] + sorted[middle]) / 2, nil
+		}
+		return sorted[middle], nil
+	default:
+		return 0, fmt.Errorf("unsupported reducer %q", reducer)
+	}
+}
+
+// resampleSeries returns the series at each window of the time range
+func resampleSeries(series []evalPoint, window time.Duration, downsampler string, upsampler string, start, end time.Duration) ([]evalPoint, error) {
+	var resampled []evalPoint
+	for t := start; t <= end; t += window {
+		var values []evalPoint
+		for _, point := range series {
+			if point.t > t-window && point.t <= t {
+				values = append(values, point)
+			}
+		}
+
+		if len(values) > 0 {
+			value, err := reduceSeries(downsampler, "", 0, values)
+			if err != nil {
+				return nil, err
+			}
+			resampled = append(resampled, evalPoint{t: t, v: value})
+			continue
+		}
+
+		value := math.NaN()
+		switch upsampler {
+		case "pad":
+			for _, point := range series {
+				if point.t <= t {
+					value = point.v
+				}
+			}
+		case "backfilling":
+			for i := len(series) - 1; i >= 0; i-- {
+				if series[i].t > t {
+					value = series[i].v
+				}
+			}
+		case "fillna":
+		default:
+			return nil, fmt.Errorf("unsupported upsampler %q", upsampler)
+		}
+		resampled = append(resampled, evalPoint{t: t, v: value})
+	}
+	return resampled, nil
+}
+
+func thresholdFunc(thresholdType string, params []float64) (func(float64) float64, error) {
+	param := func(i int) float64 {
+		if i < len(params) {
+			return params[i]
+		}
+		return 0
+	}
+	boolValue := func(b bool) float64 {
+		if b {
+			return 1
+		}
+		return 0
+	}
+
+	switch TypeThresholdType(thresholdType) {
+	case TypeThresholdTypeGt:
+		return func(v float64) float64 { return boolValue(v > param(0)) }, nil
+	case TypeThresholdTypeLt:
+		return func(v float64) float64 { return boolValue(v < param(0)) }, nil
+	case TypeThresholdTypeWithinRange:
+		return func(v float64) float64 { return boolValue(v > param(0) && v < param(1)) }, nil
+	case TypeThresholdTypeOutsideRange:
+		return func(v float64) float64 { return boolValue(v < param(0) || v > param(1)) }, nil
+	default:
+		return nil, fmt.Errorf("unsupported threshold type %q", thresholdType)
+	}
+}
+
+// mapResults applies f to the numbers and each point of the series
+func mapResults(results evalResults, f func(float64) float64) evalResults {
+	mapped := make(evalResults, 0, len(results))
+	for _, item := range results {
+		mapped = append(mapped, mapItem(item, f))
+	}
+	return mapped
+}
+
+func mapItem(item evalItem, f func(float64) float64) evalItem {
+	if !item.isSeries {
+		return evalItem{labels: item.labels, value: f(item.value)}
+	}
+	series := make([]evalPoint, 0, len(item.series))
+	for _, point := range item.series {
+		series = append(series, evalPoint{t: point.t, v: f(point.v)})
+	}
+	return evalItem{labels: item.labels, isSeries: true, series: series}
+}
+
+func labelsKey(labels map[string]string) string {
+	pairs := make([]string, 0, len(labels))
+	for _, name := range sortedKeys(labels) {
+		pairs = append(pairs, fmt.Sprintf("%s=%q", name, labels[name]))
+	}
+	return "{" + strings.Join(pairs, ", ") + "}"
+}
+
+func parseTestSeries(series TestSeries, interval time.Duration) (testSeries, error) {
+	matchers, err := parseSeriesSelector(series.Series)
+	if err != nil {
+		return testSeries{}, err
+	}
+	labels := map[string]string{}
+	for _, matcher := range matchers {
+		if matcher.op != "=" {
+			return testSeries{}, fmt.Errorf("%w: labels of series %q must be set with =", errAlertTest, series.Series)
+		}
+		labels[matcher.name] = matcher.value
+	}
+
+	values, err := expandSeriesValues(series.Values)
+	if err != nil {
+		return testSeries{}, fmt.Errorf("%w: series %q: %w", errAlertTest, series.Series, err)
+	}
+
+	parsed := testSeries{labels: labels}
+	for i, v := range values {
+		if v != nil {
+			parsed.points = append(parsed.points, evalPoint{t: time.Duration(i) * interval, v: *v})
+		}
+	}
+	return parsed, nil
+}
+
+// expandSeriesValues expands the promtool notation of values, nil for missing samples
+func expandSeriesValues(notation string) ([]*float64, error) {
+	var values []*float64
+	for _, token := range strings.Fields(notation) {
+		if token == "_" || token == "stale" {
+			values = append(values, nil)
+			continue
+		}
+
+		head, times := token, -1
+		if i := strings.LastIndex(token, "x"); i > 0 {
+			n, err := strconv.Atoi(token[i+1:])
+			if err != nil {
+				return nil, fmt.Errorf("invalid repetition in %q", token)
+			}
+			head, times = token[:i], n
+		}
+
+		if head == "_" {
+			for i := 0; i < times; i++ {
+				values = append(values, nil)
+			}
+			continue
+		}
+
+		// the head is a value, or a start and increment such as 1+2 or -1-2
+		start, increment := head, "0"
+		if times >= 0 {
+			for i := len(head) - 1; i > 0; i-- {
+				if (head[i] == '+' || head[i] == '-') && head[i-1] != 'e' && head[i-1] != 'E' {
+					start, increment = head[:i], head[i:]
+					break
+				}
+			}
+		}
+		a, err := strconv.ParseFloat(start, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid value %q", token)
+		}
+		b, err := strconv.ParseFloat(increment, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid increment %q", token)
+		}
+
+		if times < 0 {
+			values = append(values, &a)
+			continue
+		}
+		for i := 0; i <= times; i++ {
+			v := a + float64(i)*b
+			values = append(values, &v)
+		}
+	}
+	return values, nil
+}
+
+type labelMatcher struct {
+	name  string
+	op    string
+	value string
+	re    *regexp.Regexp
+}
+
+type labelMatchers []labelMatcher
+
+func (m labelMatchers) matches(labels map[string]string) bool {
+	for _, matcher := range m {
+		value := labels[matcher.name]
+		var ok bool
+		switch matcher.op {
+		case "=":
+			ok = value == matcher.value
+		case "!=":
+			ok = value != matcher.value
+		case "=~":
+			ok = matcher.re.MatchString(value)
+		case "!~":
+			ok = !matcher.re.MatchString(value)
+		}
+		if !ok {
+			return false
+		}
+	}
+	return true
+}
+
+var (
+	metricNameRegexp   = regexp.MustCompile(`^[a-zA-Z_:][a-zA-Z0-9_:]*`)
+	labelMatcherRegexp = regexp.MustCompile(`^\s*([a-zA-Z_][a-zA-Z0-9_]*)\s*(=~|!~|!=|=)\s*("(?:[^"\\]|\\.)*")\s*(,|$)`)
+)
+
+// parseSeriesSelector parses a PromQL vector selector such as `eth_balance{account=~"0x.*"}`
+func parseSeriesSelector(selector string) (labelMatchers, error) {
+	selector = strings.TrimSpace(selector)
+	var matchers labelMatchers
+
+	name := metricNameRegexp.FindString(selector)
+	if name != "" {
+		matchers = append(matchers, labelMatcher{name: "__name__", op: "=", value: name})
+	}
+	rest := selector[len(name):]
+	if rest == "" {
+		if name == "" {
+			return nil, fmt.Errorf("%w: empty selector", errAlertTest)
+		}
+		return matchers, nil
+	}
+	if !strings.HasPrefix(rest, "{") || !strings.HasSuffix(rest, "}") {
+		return nil, fmt.Errorf("%w: invalid selector %q", errAlertTest, selector)
+	}
+
+	body := strings.TrimSpace(rest[1 : len(rest)-1])
+	for body != "" {
+		match := labelMatcherRegexp.FindStringSubmatch(body)
+		if match == nil {
+			return nil, fmt.Errorf("%w: invalid selector %q", errAlertTest, selector)
+		}
+		value, err := strconv.Unquote(match[3])
+		if err != nil {
+			return nil, fmt.Errorf("%w: invalid selector %q", errAlertTest, selector)
+		}
+		matcher := labelMatcher{name: match[1], op: match[2], value: value}
+		if matcher.op == "=~" || matcher.op == "!~" {
+			if matcher.re, err = regexp.Compile("^(?:" + value + ")$"); err != nil {
+				return nil, fmt.Errorf("%w: invalid selector %q: %w", errAlertTest, selector, err)
+			}
+		}
+		matchers = append(matchers, matcher)
+		body = strings.TrimSpace(body[len(match[0]):])
+	}
+	return matchers, nil
+}
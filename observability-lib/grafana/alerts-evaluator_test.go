@@ -0,0 +1,231 @@
+package grafana_test
+
+import (
+	"testing"
+
+	"github.com/grafana/grafana-foundation-sdk/go/alerting"
+	"github.com/grafana/grafana-foundation-sdk/go/expr"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink-common/observability-lib/grafana"
+)
+
+func buildAlerts(t *testing.T, alerts ...grafana.AlertOptions) *grafana.Observability {
+	builder := grafana.NewBuilder(&grafana.BuilderOptions{})
+	builder.AddAlertGroup(grafana.NewAlertGroup(&grafana.AlertGroupOptions{Title: "Group", Interval: 60}))
+	for _, alert := range alerts {
+		alert.RuleGroupTitle = "Group"
+		builder.AddAlert(grafana.NewAlertRule(&alert))
+	}
+	o, err := builder.Build()
+	require.NoError(t, err)
+	return o
+}
+
+func TestEvaluateAlerts(t *testing.T) {
+	ethBalanceLow := grafana.AlertOptions{
+		Title: "ETH Balance low",
+		For:   "2m",
+		Query: []grafana.RuleQuery{
+			{Expr: `eth_balance`, RefID: "A", Instant: true},
+		},
+		QueryRefCondition: "B",
+		Condition: []grafana.ConditionQuery{
+			{
+				RefID: "B",
+				ThresholdExpression: &grafana.ThresholdExpression{
+					Expression: "A",
+					ThresholdConditionsOptions: grafana.ThresholdConditionsOption{
+						Params: []float64{2},
+						Type:   grafana.TypeThresholdTypeLt,
+					},
+				},
+			},
+		},
+	}
+
+	t.Run("EvaluateAlerts fires the instances whose threshold is met for the pending period", func(t *testing.T) {
+		o := buildAlerts(t, ethBalanceLow)
+
+		evaluations, err := o.EvaluateAlerts(&grafana.AlertTestOptions{
+			InputSeries: []grafana.TestSeries{
+				{Series: `eth_balance{account="0x1"}`, Values: "5 4 1x2"},
+				{Series: `eth_balance{account="0x2"}`, Values: "5x3"},
+				{Series: `eth_balance{account="0x3"}`, Values: "5 5 5 1"},
+				{Series: `link_balance{account="0x1"}`, Values: "0x3"},
+			},
+		})
+		require.NoError(t, err)
+		require.Equal(t, grafana.AlertEvaluations{
+			{
+				Title: "ETH Balance low",
+				State: grafana.AlertStateAlerting,
+				Instances: []grafana.AlertInstance{
+					{Labels: map[string]string{"account": "0x1"}, State: grafana.AlertStateAlerting, Value: 1},
+					{Labels: map[string]string{"account": "0x2"}, State: grafana.AlertStateNormal, Value: 0},
+					{Labels: map[string]string{"account": "0x3"}, State: grafana.AlertStatePending, Value: 1},
+				},
+			},
+		}, evaluations)
+		require.Equal(t, []string{"ETH Balance low"}, evaluations.Firing())
+	})
+
+	t.Run("EvaluateAlerts evaluates at the evaluation time", func(t *testing.T) {
+		o := buildAlerts(t, ethBalanceLow)
+
+		evaluations, err := o.EvaluateAlerts(&grafana.AlertTestOptions{
+			Interval:    30e9,
+			EvalTime:    60e9,
+			InputSeries: []grafana.TestSeries{{Series: `eth_balance`, Values: "5 4 3 1x10"}},
+		})
+		require.NoError(t, err)
+		require.Equal(t, grafana.AlertStateNormal, evaluations[0].State)
+		require.Equal(t, 0.0, evaluations[0].Instances[0].Value)
+		require.Empty(t, evaluations.Firing())
+	})
+
+	t.Run("EvaluateAlerts reduces and computes math expressions", func(t *testing.T) {
+		o := buildAlerts(t, grafana.AlertOptions{
+			Title: "Error rate high",
+			For:   "0s",
+			Query: []grafana.RuleQuery{
+				{Expr: `sum(rate(errors_total[5m])) by (service)`, RefID: "A"},
+			},
+			QueryRefCondition: "C",
+			Condition: []grafana.ConditionQuery{
+				{RefID: "B", ReduceExpression: &grafana.ReduceExpression{Expression: "A", Reducer: expr.TypeReduceReducerMean}},
+				{RefID: "C", MathExpression: &grafana.MathExpression{Expression: "$B > 0.5 && $B < 10"}},
+			},
+		})
+
+		evaluations, err := o.EvaluateAlerts(&grafana.AlertTestOptions{
+			QueryResults: map[string][]grafana.TestSeries{
+				`sum(rate(errors_total[5m])) by (service)`: {
+					{Series: `{service="api"}`, Values: "0+0.5x4"},
+					{Series: `{service="db"}`, Values: "0.1 0.2 _ 0.3"},
+					{Series: `{service="web"}`, Values: "20x4"},
+				},
+			},
+		})
+		require.NoError(t, err)
+		require.Equal(t, grafana.AlertStateAlerting, evaluations[0].State)
+		require.Equal(t, []grafana.AlertInstance{
+			{Labels: map[string]string{"service": "api"}, State: grafana.AlertStateAlerting, Value: 1},
+			{Labels: map[string]string{"service": "db"}, State: grafana.AlertStateNormal, Value: 0},
+			{Labels: map[string]string{"service": "web"}, State: grafana.AlertStateNormal, Value: 0},
+		}, evaluations[0].Instances)
+	})
+
+	t.Run("EvaluateAlerts resamples time series", func(t *testing.T) {
+		o := buildAlerts(t, grafana.AlertOptions{
+			Title: "Head not increasing",
+			For:   "0s",
+			Query: []grafana.RuleQuery{
+				{Expr: `head_tracker_current_head`, RefID: "A"},
+			},
+			QueryRefCondition: "D",
+			Condition: []grafana.ConditionQuery{
+				{
+					RefID:      "B",
+					IntervalMs: grafana.Pointer[float64](120000),
+					ResampleExpression: &grafana.ResampleExpression{
+						Expression:  "A",
+						DownSampler: expr.TypeResampleDownsamplerMax,
+						UpSampler:   expr.TypeResampleUpsamplerPad,
+					},
+				},
+				{RefID: "C", ReduceExpression: &grafana.ReduceExpression{Expression: "B", Reducer: expr.TypeReduceReducerCount}},
+				{
+					RefID: "D",
+					ThresholdExpression: &grafana.ThresholdExpression{
+						Expression: "C",
+						ThresholdConditionsOptions: grafana.ThresholdConditionsOption{
+							Params: []float64{3, 10},
+							Type:   grafana.TypeThresholdTypeWithinRange,
+						},
+					},
+				},
+			},
+		})
+
+		// the 10m time range resampled every 2m has 6 points
+		evaluations, err := o.EvaluateAlerts(&grafana.AlertTestOptions{
+			InputSeries: []grafana.TestSeries{{Series: `head_tracker_current_head`, Values: "1+1x20"}},
+		})
+		require.NoError(t, err)
+		require.Equal(t, grafana.AlertStateAlerting, evaluations[0].State)
+		require.Equal(t, 1.0, evaluations[0].Instances[0].Value)
+	})
+
+	t.Run("EvaluateAlerts computes math expressions as Grafana", func(t *testing.T) {
+		for expression, expected := range map[string]float64{
+			"$A * 2 + 1":                    7,
+			"($A - 1) / 4":                  0.5,
+			"2 ** 3 ** 2":                   512,
+			"$A % 2":                        1,
+			"abs(-$A) > 2 && !($A == 4)":    1,
+			"${A} >= 3 || $A < 0":           1,
+			"floor($A / 2) + ceil(0.1)":     2,
+			"is_nan($A) + is_number($A)":    1,
+			"round(1.5e1 / 10) * -1 != -2.": 0,
+		} {
+			o := buildAlerts(t, grafana.AlertOptions{
+				Title:             expression,
+				For:               "0s",
+				Query:             []grafana.RuleQuery{{Expr: `value`, RefID: "A", Instant: true}},
+				QueryRefCondition: "B",
+				Condition: []grafana.ConditionQuery{
+					{RefID: "B", MathExpression: &grafana.MathExpression{Expression: expression}},
+				},
+			})
+
+			evaluations, err := o.EvaluateAlerts(&grafana.AlertTestOptions{
+				InputSeries: []grafana.TestSeries{{Series: `value`, Values: "3"}},
+			})
+			require.NoError(t, err)
+			require.NoError(t, evaluations[0].Error, expression)
+			require.Equal(t, expected, evaluations[0].Instances[0].Value, expression)
+		}
+	})
+
+	t.Run("EvaluateAlerts sets the no data and execution error states", func(t *testing.T) {
+		noData := ethBalanceLow
+		noData.Title = "No data"
+		noData.Query = []grafana.RuleQuery{{Expr: `eth_balance{account="0x9"}`, RefID: "A", Instant: true}}
+
+		notReduced := ethBalanceLow
+		notReduced.Title = "Not reduced"
+		notReduced.Query = []grafana.RuleQuery{{Expr: `eth_balance`, RefID: "A"}}
+		notReduced.RuleExecErrState = alerting.RuleExecErrStateError
+
+		o := buildAlerts(t, noData, notReduced)
+		evaluations, err := o.EvaluateAlerts(&grafana.AlertTestOptions{
+			InputSeries: []grafana.TestSeries{{Series: `eth_balance{account="0x1"}`, Values: "1 1 1"}},
+		})
+		require.NoError(t, err)
+
+		require.Equal(t, grafana.AlertStateNoData, evaluations[0].State)
+		require.Equal(t, grafana.AlertStateError, evaluations[1].State)
+		require.ErrorContains(t, evaluations[1].Error, "only reduced data can be alerted on")
+	})
+
+	t.Run("EvaluateAlerts fails for invalid test data", func(t *testing.T) {
+		o := buildAlerts(t, grafana.AlertOptions{
+			Title:             "Rate",
+			Query:             []grafana.RuleQuery{{Expr: `rate(errors_total[5m])`, RefID: "A", Instant: true}},
+			QueryRefCondition: "A",
+		})
+		_, err := o.EvaluateAlerts(&grafana.AlertTestOptions{})
+		require.ErrorContains(t, err, `no query results for "rate(errors_total[5m])"`)
+
+		_, err = buildAlerts(t, ethBalanceLow).EvaluateAlerts(&grafana.AlertTestOptions{
+			InputSeries: []grafana.TestSeries{{Series: `eth_balance`, Values: "1 a"}},
+		})
+		require.ErrorContains(t, err, `invalid value "a"`)
+
+		_, err = buildAlerts(t, ethBalanceLow).EvaluateAlerts(&grafana.AlertTestOptions{
+			InputSeries: []grafana.TestSeries{{Series: `eth_balance{account=~"0x.*"}`, Values: "1"}},
+		})
+		require.ErrorContains(t, err, "must be set with =")
+	})
+}
@@ -3,6 +3,7 @@ package grafana
 import (
 	"encoding/json"
 	"fmt"
+	"maps"
 	"reflect"
 
 	"github.com/grafana/grafana-foundation-sdk/go/alerting"
@@ -148,27 +149,9 @@ func (o *Observability) DeployToGrafana(options *DeployOptions) error {
 
 		// Create alert rules
 		for _, alert := range o.Alerts {
-			if folder.UID != "" {
-				alert.FolderUID = folder.UID
-			}
-			if o.Dashboard != nil {
-				if alert.RuleGroup == "" {
-					alert.RuleGroup = *o.Dashboard.Title
-				}
-				if alert.Annotations["panel_title"] != "" {
-					panelId := panelIDByTitle(o.Dashboard, alert.Annotations["panel_title"])
-					// we can clean it up as it was only used to get the panelId
-					delete(alert.Annotations, "panel_title")
-					if panelId != "" {
-						// Both or none should be set
-						alert.Annotations["__panelId__"] = panelId
-						alert.Annotations["__dashboardUid__"] = *newDashboard.UID
-					}
-				}
-			} else {
-				if alert.RuleGroup == "" {
-					return fmt.Errorf("you must create at one rule group and set it to your alerts")
-				}
+			alert, errPrepareAlert := o.prepareAlert(alert, folder.UID, newDashboard.UID)
+			if errPrepareAlert != nil {
+				return errPrepareAlert
 			}
 
 			if alertRuleExist(alertsRule, alert) {
@@ -201,19 +184,8 @@ func (o *Observability) DeployToGrafana(options *DeployOptions) error {
 	}
 
 	// Create notification templates for the alerts
-	if options.NotificationTemplates != "" {
-		notificationTemplates, errNotificationTemplate := NewNotificationTemplatesFromFile(
-			options.NotificationTemplates,
-		)
-		if errNotificationTemplate != nil {
-			return errNotificationTemplate
-		}
-		for _, notificationTemplate := range notificationTemplates {
-			_, _, errPostNotificationTemplate := grafanaClient.PutNotificationTemplate(notificationTemplate)
-			if errPostNotificationTemplate != nil {
-				return errPostNotificationTemplate
-			}
-		}
+	if errNotificationTemplates := deployNotificationTemplates(grafanaClient, options.NotificationTemplates); errNotificationTemplates != nil {
+		return errNotificationTemplates
 	}
 
 	// Create contact points for the alerts
@@ -239,6 +211,55 @@ func (o *Observability) DeployToGrafana(options *DeployOptions) error {
 	return nil
 }
 
+// prepareAlert returns the alert as it is deployed to the folder, linked to its dashboard panel
+func (o *Observability) prepareAlert(alert alerting.Rule, folderUID string, dashboardUID *string) (alerting.Rule, error) {
+	if folderUID != "" {
+		alert.FolderUID = folderUID
+	}
+	if o.Dashboard != nil {
+		if alert.RuleGroup == "" {
+			alert.RuleGroup = *o.Dashboard.Title
+		}
+		if alert.Annotations["panel_title"] != "" {
+			// copy the annotations as they are shared with the alert definition
+			alert.Annotations = maps.Clone(alert.Annotations)
+			panelId := panelIDByTitle(o.Dashboard, alert.Annotations["panel_title"])
+			// we can clean it up as it was only used to get the panelId
+			delete(alert.Annotations, "panel_title")
+			if panelId != "" && dashboardUID != nil {
+				// Both or none should be set
+				alert.Annotations["__panelId__"] = panelId
+				alert.Annotations["__dashboardUid__"] = *dashboardUID
+			}
+		}
+	} else {
+		if alert.RuleGroup == "" {
+			return alerting.Rule{}, fmt.Errorf("you must create at one rule group and set it to your alerts")
+		}
+	}
+
+	return alert, nil
+}
+
+func deployNotificationTemplates(grafanaClient *api.Client, notificationTemplatesFile string) error {
+	if notificationTemplatesFile == "" {
+		return nil
+	}
+
+	notificationTemplates, errNotificationTemplate := NewNotificationTemplatesFromFile(notificationTemplatesFile)
+	if errNotificationTemplate != nil {
+		return errNotificationTemplate
+	}
+	for _, notificationTemplate := range notificationTemplates {
+		_, _, errPostNotificationTemplate := grafanaClient.PutNotificationTemplate(notificationTemplate)
+		if errPostNotificationTemplate != nil {
+			return errPostNotificationTemplate
+		}
+	}
+
+	return nil
+}
+
 func panelIDByTitle(db *dashboard.Dashboard, title string) string {
 	for _, panel := range db.Panels {
 		if panel.Panel != nil && panel.Panel.Title != nil && *panel.Panel.Title == title {
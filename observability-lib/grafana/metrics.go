@@ -0,0 +1,332 @@
+package grafana
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/prometheus/common/expfmt"
+)
+
+type MetricsDashboardOptions struct {
+	Name       string
+	Datasource string
+	// Prefix only includes the metrics whose name starts with it
+	Prefix string
+	// IgnoredLabels are not turned into template variables
+	IgnoredLabels []string
+}
+
+// ParseMetrics returns a gatherer of the metrics in the Prometheus text format, as scraped from a /metrics endpoint
+func ParseMetrics(r io.Reader) (prometheus.Gatherer, error) {
+	var parser expfmt.TextParser
+	families, err := parser.TextToMetricFamilies(r)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing metrics: %w", err)
+	}
+
+	return prometheus.GathererFunc(func() ([]*dto.MetricFamily, error) {
+		result := make([]*dto.MetricFamily, 0, len(families))
+		for _, family := range families {
+			result = append(result, family)
+		}
+		return result, nil
+	}), nil
+}
+
+// NewMetricsBuilder returns a starter dashboard for the gathered metrics, with
+// a time series panel of the rate of each counter, a heatmap of each histogram, a stat panel of each gauge,
+// and a template variable of each label
+func NewMetricsBuilder(gatherer prometheus.Gatherer, options *MetricsDashboardOptions) (*Builder, error) {
+	db, err := newMetricsDashboard(gatherer, options)
+	if err != nil {
+		return nil, err
+	}
+
+	builder := NewBuilder(&BuilderOptions{
+		Name:     db.name,
+		TimeFrom: "now-1h",
+		TimeTo:   "now",
+	})
+	for _, variable := range db.variables {
+		builder.AddVars(NewQueryVariable(&QueryVariableOptions{
+			VariableOption: &VariableOption{
+				Name:  variable.name,
+				Label: variable.name,
+			},
+			Datasource: db.datasource,
+			Query:      variable.query,
+			Multi:      true,
+			IncludeAll: true,
+		}))
+	}
+	for _, row := range db.rows {
+		builder.AddRow(row.title)
+		for _, panel := range row.panels {
+			builder.AddPanel(panel.newPanel(db.datasource))
+		}
+	}
+
+	return builder, nil
+}
+
+// GenerateMetricsGoCode returns the source of a Go file in the given package, whose NewDashboard function builds
+// the dashboard of NewMetricsBuilder, to be used as a starting point for the dashboard of a service
+func GenerateMetricsGoCode(gatherer prometheus.Gatherer, options *MetricsDashboardOptions, packageName string) ([]byte, error) {
+	db, err := newMetricsDashboard(gatherer, options)
+	if err != nil {
+		return nil, err
+	}
+
+	var src bytes.Buffer
+	fmt.Fprintf(&src, "// Code generated from Prometheus metrics by observability-lib, edit it to fit the service.\n\n")
+	fmt.Fprintf(&src, "package %s\n\n", packageName)
+	fmt.Fprintf(&src, "import %q\n\n", "github.com/smartcontractkit/chainlink-common/observability-lib/grafana")
+	fmt.Fprintf(&src, "func NewDashboard() (*grafana.Observability, error) {\n")
+	fmt.Fprintf(&src, "builder := grafana.NewBuilder(&grafana.BuilderOptions{\nName: %q,\nTimeFrom: %q,\nTimeTo: %q,\n})\n\n", db.name, "now-1h", "now")
+	for _, variable := range db.variables {
+		fmt.Fprintf(&src, "builder.AddVars(grafana.NewQueryVariable(&grafana.QueryVariableOptions{\n")
+		fmt.Fprintf(&src, "VariableOption: &grafana.VariableOption{\nName: %q,\nLabel: %q,\n},\n", variable.name, variable.name)
+		fmt.Fprintf(&src, "Datasource: %q,\nQuery: %q,\nMulti: true,\nIncludeAll: true,\n}))\n", db.datasource, variable.query)
+	}
+	for _, row := range db.rows {
+		fmt.Fprintf(&src, "\nbuilder.AddRow(%q)\n", row.title)
+		for _, panel := range row.panels {
+			fmt.Fprintf(&src, "builder.AddPanel(grafana.New%sPanel(&grafana.%sPanelOptions{\nPanelOptions: &grafana.PanelOptions{\n", panel.kind, panel.kind)
+			fmt.Fprintf(&src, "Datasource: %q,\nTitle: %q,\n", db.datasource, panel.title)
+			if panel.description != "" {
+				fmt.Fprintf(&src, "Description: %q,\n", panel.description)
+			}
+			fmt.Fprintf(&src, "Span: %d,\n", panel.span)
+			if panel.unit != "" {
+				fmt.Fprintf(&src, "Unit: %q,\n", panel.unit)
+			}
+			fmt.Fprintf(&src, "Query: []grafana.Query{\n{\nExpr: %q,\nLegend: %q,\n},\n},\n},\n}))\n", panel.query.Expr, panel.query.Legend)
+		}
+	}
+	fmt.Fprintf(&src, "\nreturn builder.Build()\n}\n")
+
+	formatted, err := format.Source(src.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("error formatting generated code: %w", err)
+	}
+	return formatted, nil
+}
+
+type metricPanelKind string
+
+const (
+	metricPanelKindTimeSeries metricPanelKind = "TimeSeries"
+	metricPanelKindHeatmap    metricPanelKind = "Heatmap"
+	metricPanelKindStat       metricPanelKind = "Stat"
+)
+
+type metricPanel struct {
+	kind        metricPanelKind
+	title       string
+	description string
+	span        uint32
+	unit        string
+	query       Query
+}
+
+func (p metricPanel) newPanel(datasource string) *Panel {
+	options := &PanelOptions{
+		Datasource:  datasource,
+		Title:       p.title,
+		Description: p.description,
+		Span:        p.span,
+		Unit:        p.unit,
+		Query:       []Query{p.query},
+	}
+
+	switch p.kind {
+	case metricPanelKindHeatmap:
+		return NewHeatmapPanel(&HeatmapPanelOptions{PanelOptions: options})
+	case metricPanelKindStat:
+		return NewStatPanel(&StatPanelOptions{PanelOptions: options})
+	default:
+		return NewTimeSeriesPanel(&TimeSeriesPanelOptions{PanelOptions: options})
+	}
+}
+
+type metricVariable struct {
+	name  string
+	query string
+}
+
+type metricRow struct {
+	title  string
+	panels []metricPanel
+}
+
+type metricsDashboard struct {
+	name       string
+	datasource string
+	variables  []metricVariable
+	rows       []metricRow
+}
+
+// Labels set by the type of the metric which are not turned into variables
+var metricTypeLabels = []string{"le", "quantile"}
+
+func newMetricsDashboard(gatherer prometheus.Gatherer, options *MetricsDashboardOptions) (*metricsDashboard, error) {
+	families, err := gatherer.Gather()
+	if err != nil {
+		return nil, fmt.Errorf("error gathering metrics: %w", err)
+	}
+	sort.Slice(families, func(i, j int) bool {
+		return families[i].GetName() < families[j].GetName()
+	})
+
+	db := &metricsDashboard{
+		name:       options.Name,
+		datasource: options.Datasource,
+	}
+	if db.name == "" {
+		db.name = "Metrics"
+	}
+	if db.datasource == "" {
+		db.datasource = "Prometheus"
+	}
+
+	// series of the first metric with each label, to query its values
+	labelSeries := map[string]string{}
+	var counters, histograms, gauges, others []metricPanel
+	for _, family := range families {
+		name := family.GetName()
+		if !strings.HasPrefix(name, options.Prefix) {
+			continue
+		}
+
+		labels := familyLabels(family, options.IgnoredLabels)
+		selector := labelSelector(labels)
+		by := ""
+		if len(labels) > 0 {
+			by = fmt.Sprintf(" by (%s)", strings.Join(labels, ", "))
+		}
+
+		panel := metricPanel{
+			title:       name,
+			description: family.GetHelp(),
+			span:        12,
+			query:       Query{Legend: labelLegend(labels, name)},
+		}
+		series := name
+
+		switch family.GetType() {
+		case dto.MetricType_COUNTER:
+			panel.kind = metricPanelKindTimeSeries
+			panel.unit = "ops"
+			if strings.HasSuffix(strings.TrimSuffix(name, "_total"), "_bytes") {
+				panel.unit = "Bps"
+			}
+			panel.query.Expr = fmt.Sprintf("sum(rate(%s%s[$__rate_interval]))%s", name, selector, by)
+			counters = append(counters, panel)
+		case dto.MetricType_HISTOGRAM, dto.MetricType_GAUGE_HISTOGRAM:
+			panel.kind = metricPanelKindHeatmap
+			panel.unit = metricUnit(name)
+			panel.query.Expr = fmt.Sprintf("sum(rate(%s_bucket%s[$__rate_interval])) by (le)", name, selector)
+			panel.query.Legend = "{{le}}"
+			series = name + "_count"
+			histograms = append(histograms, panel)
+		case dto.MetricType_GAUGE:
+			panel.kind = metricPanelKindStat
+			panel.span = 6
+			panel.unit = metricUnit(name)
+			panel.query.Expr = name + selector
+			gauges = append(gauges, panel)
+		default:
+			// summaries are shown by quantile, untyped metrics as they are
+			panel.kind = metricPanelKindTimeSeries
+			panel.unit = metricUnit(name)
+			panel.query.Expr = name + selector
+			if family.GetType() == dto.MetricType_SUMMARY {
+				panel.query.Legend = labelLegend(append([]string{"quantile"}, labels...), name)
+				series = name + "_count"
+			}
+			others = append(others, panel)
+		}
+
+		for _, label := range labels {
+			if _, ok := labelSeries[label]; !ok {
+				labelSeries[label] = series
+			}
+		}
+	}
+
+	for _, label := range sortedKeys(labelSeries) {
+		db.variables = append(db.variables, metricVariable{
+			name:  label,
+			query: fmt.Sprintf("label_values(%s, %s)", labelSeries[label], label),
+		})
+	}
+	for _, row := range []metricRow{
+		{title: "Counters", panels: counters},
+		{title: "Histograms", panels: histograms},
+		{title: "Gauges", panels: gauges},
+		{title: "Other", panels: others},
+	} {
+		if len(row.panels) > 0 {
+			db.rows = append(db.rows, row)
+		}
+	}
+
+	return db, nil
+}
+
+// familyLabels returns the sorted label names of the metrics of a family
+func familyLabels(family *dto.MetricFamily, ignored []string) []string {
+	labels := map[string]struct{}{}
+	for _, metric := range family.GetMetric() {
+		for _, label := range metric.GetLabel() {
+			if contains(metricTypeLabels, label.GetName()) || contains(ignored, label.GetName()) {
+				continue
+			}
+			labels[label.GetName()] = struct{}{}
+		}
+	}
+	return sortedKeys(labels)
+}
+
+// labelSelector filters the labels by the values selected in their variables
+func labelSelector(labels []string) string {
+	if len(labels) == 0 {
+		return ""
+	}
+	matchers := make([]string, 0, len(labels))
+	for _, label := range labels {
+		matchers = append(matchers, fmt.Sprintf(`%s=~"$%s"`, label, label))
+	}
+	return "{" + strings.Join(matchers, ", ") + "}"
+}
+
+func labelLegend(labels []string, name string) string {
+	if len(labels) == 0 {
+		return name
+	}
+	legend := make([]string, 0, len(labels))
+	for _, label := range labels {
+		legend = append(legend, "{{"+label+"}}")
+	}
+	return strings.Join(legend, " ")
+}
+
+// metricUnit returns the Grafana unit of a metric from the base unit suffix of its name
+func metricUnit(name string) string {
+	switch {
+	case strings.HasSuffix(name, "_seconds"):
+		return "s"
+	case strings.HasSuffix(name, "_bytes"):
+		return "bytes"
+	case strings.HasSuffix(name, "_ratio"):
+		return "percentunit"
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,197 @@
+package grafana_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"go/parser"
+	"go/token"
+	"strings"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/common/expfmt"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink-common/observability-lib/grafana"
+)
+
+func newMetricsRegistry() *prometheus.Registry {
+	registry := prometheus.NewRegistry()
+	factory := promauto.With(registry)
+
+	factory.NewCounterVec(prometheus.CounterOpts{
+		Name: "service_requests_total",
+		Help: "Number of requests",
+	}, []string{"method"}).WithLabelValues("get").Inc()
+	factory.NewHistogramVec(prometheus.HistogramOpts{
+		Name: "service_request_duration_seconds",
+		Help: "Duration of requests",
+	}, []string{"method"}).WithLabelValues("get").Observe(0.1)
+	factory.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "service_queue_size",
+		Help: "Size of the queue",
+	}, []string{"queue", "instance"}).WithLabelValues("mailbox", "node-1").Set(3)
+	factory.NewSummary(prometheus.SummaryOpts{
+		Name:       "service_latency_seconds",
+		Objectives: map[float64]float64{0.5: 0.05},
+	}).Observe(1)
+	factory.NewGauge(prometheus.GaugeOpts{Name: "other_up"}).Set(1)
+
+	return registry
+}
+
+func TestNewMetricsBuilder(t *testing.T) {
+	options := &grafana.MetricsDashboardOptions{
+		Name:          "Service",
+		Prefix:        "service_",
+		IgnoredLabels: []string{"instance"},
+	}
+
+	t.Run("NewMetricsBuilder builds a panel per metric and a variable per label", func(t *testing.T) {
+		builder, err := grafana.NewMetricsBuilder(newMetricsRegistry(), options)
+		require.NoError(t, err)
+		o, err := builder.Build()
+		require.NoError(t, err)
+
+		require.Equal(t, "Service", *o.Dashboard.Title)
+
+		var variables []string
+		for _, variable := range o.Dashboard.Templating.List {
+			variables = append(variables, variable.Name+": "+*variable.Query.String)
+		}
+		require.Equal(t, []string{
+			"method: label_values(service_request_duration_seconds_count, method)",
+			"queue: label_values(service_queue_size, queue)",
+		}, variables)
+
+		type panel struct {
+			Type    string
+			Title   string
+			Unit    string
+			Expr    string
+			Legend  string
+			Heatmap bool
+		}
+		var panels []panel
+		for _, p := range o.Dashboard.Panels {
+			if p.RowPanel != nil {
+				panels = append(panels, panel{Type: "row", Title: *p.RowPanel.Title})
+				continue
+			}
+			raw, err := json.Marshal(p.Panel)
+			require.NoError(t, err)
+			var decoded struct {
+				Type        string
+				Title       string
+				FieldConfig struct{ Defaults struct{ Unit string } }
+				Targets     []struct{ Expr, LegendFormat, Format string }
+			}
+			require.NoError(t, json.Unmarshal(raw, &decoded))
+			require.Len(t, decoded.Targets, 1)
+			panels = append(panels, panel{
+				Type:    decoded.Type,
+				Title:   decoded.Title,
+				Unit:    decoded.FieldConfig.Defaults.Unit,
+				Expr:    decoded.Targets[0].Expr,
+				Legend:  decoded.Targets[0].LegendFormat,
+				Heatmap: decoded.Targets[0].Format == "heatmap",
+			})
+		}
+		require.Equal(t, []panel{
+			{Type: "row", Title: "Counters"},
+			{
+				Type:   "timeseries",
+				Title:  "service_requests_total",
+				Unit:   "ops",
+				Expr:   `sum(rate(service_requests_total{method=~"$method"}[$__rate_interval])) by (method)`,
+				Legend: "{{method}}",
+			},
+			{Type: "row", Title: "Histograms"},
+			{
+				Type:    "heatmap",
+				Title:   "service_request_duration_seconds",
+				Unit:    "s",
+				Expr:    `sum(rate(service_request_duration_seconds_bucket{method=~"$method"}[$__rate_interval])) by (le)`,
+				Legend:  "{{le}}",
+				Heatmap: true,
+			},
+			{Type: "row", Title: "Gauges"},
+			{
+				Type:   "stat",
+				Title:  "service_queue_size",
+				Expr:   `service_queue_size{queue=~"$queue"}`,
+				Legend: "{{queue}}",
+			},
+			{Type: "row", Title: "Other"},
+			{
+				Type:   "timeseries",
+				Title:  "service_latency_seconds",
+				Unit:   "s",
+				Expr:   `service_latency_seconds`,
+				Legend: "{{quantile}}",
+			},
+		}, panels)
+	})
+
+	t.Run("NewMetricsBuilder builds the same dashboard from scraped metrics", func(t *testing.T) {
+		registry := newMetricsRegistry()
+		families, err := registry.Gather()
+		require.NoError(t, err)
+		var scraped bytes.Buffer
+		for _, family := range families {
+			_, err = expfmt.MetricFamilyToText(&scraped, family)
+			require.NoError(t, err)
+		}
+		gatherer, err := grafana.ParseMetrics(&scraped)
+		require.NoError(t, err)
+
+		generateJSON := func(gatherer prometheus.Gatherer) string {
+			builder, err := grafana.NewMetricsBuilder(gatherer, options)
+			require.NoError(t, err)
+			o, err := builder.Build()
+			require.NoError(t, err)
+			output, err := o.GenerateJSON()
+			require.NoError(t, err)
+			return string(output)
+		}
+		require.JSONEq(t, generateJSON(registry), generateJSON(gatherer))
+	})
+
+	t.Run("ParseMetrics fails for invalid metrics", func(t *testing.T) {
+		_, err := grafana.ParseMetrics(strings.NewReader("# TYPE foo counter\nfoo{bar} 1\n"))
+		require.Error(t, err)
+	})
+}
+
+func TestGenerateMetricsGoCode(t *testing.T) {
+	t.Run("GenerateMetricsGoCode returns Go code building the dashboard", func(t *testing.T) {
+		src, err := grafana.GenerateMetricsGoCode(newMetricsRegistry(), &grafana.MetricsDashboardOptions{
+			Name:          "Service",
+			Prefix:        "service_",
+			IgnoredLabels: []string{"instance"},
+		}, "dashboard")
+		require.NoError(t, err)
+
+		file, err := parser.ParseFile(token.NewFileSet(), "dashboard.go", src, 0)
+		require.NoError(t, err)
+		require.Equal(t, "dashboard", file.Name.Name)
+
+		code := string(src)
+		for _, expected := range []string{
+			`func NewDashboard() (*grafana.Observability, error) {`,
+			`Name:     "Service",`,
+			`Query:      "label_values(service_queue_size, queue)",`,
+			`builder.AddRow("Counters")`,
+			`builder.AddPanel(grafana.NewTimeSeriesPanel(&grafana.TimeSeriesPanelOptions{`,
+			`builder.AddPanel(grafana.NewHeatmapPanel(&grafana.HeatmapPanelOptions{`,
+			`builder.AddPanel(grafana.NewStatPanel(&grafana.StatPanelOptions{`,
+			`Expr:   "sum(rate(service_requests_total{method=~\"$method\"}[$__rate_interval])) by (method)",`,
+			`Description: "Number of requests",`,
+			`return builder.Build()`,
+		} {
+			require.Contains(t, code, expected)
+		}
+		require.NotContains(t, code, "other_up")
+	})
+}
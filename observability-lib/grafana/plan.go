@@ -0,0 +1,515 @@
+package grafana
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/grafana/grafana-foundation-sdk/go/alerting"
+	"github.com/smartcontractkit/chainlink-common/observability-lib/api"
+)
+
+type ChangeAction string
+
+const (
+	ChangeActionAdd    ChangeAction = "add"
+	ChangeActionRemove ChangeAction = "remove"
+	ChangeActionModify ChangeAction = "modify"
+)
+
+// Change is a resource which is added, removed or modified by a deployment
+type Change struct {
+	Action ChangeAction `json:"action"`
+	// Name identifies the resource, by title for dashboards, panels and alert rules, by name for contact points
+	// and by receiver and matchers for notification policies
+	Name string `json:"name"`
+	// UID of the resource in Grafana, for removed and modified alert rules
+	UID string `json:"uid,omitempty"`
+	// Fields which differ between Grafana and the resource, for modified resources
+	Fields []string `json:"fields,omitempty"`
+}
+
+// Plan lists the changes DeployToGrafana would make to Grafana
+type Plan struct {
+	FolderName           string   `json:"folderName"`
+	CreateFolder         bool     `json:"createFolder"`
+	Dashboard            *Change  `json:"dashboard,omitempty"`
+	Panels               []Change `json:"panels,omitempty"`
+	AlertRules           []Change `json:"alertRules,omitempty"`
+	ContactPoints        []Change `json:"contactPoints,omitempty"`
+	NotificationPolicies []Change `json:"notificationPolicies,omitempty"`
+}
+
+// HasChanges returns true if applying the plan would change Grafana
+func (p *Plan) HasChanges() bool {
+	return p.CreateFolder || p.Dashboard != nil || len(p.Panels) > 0 || len(p.AlertRules) > 0 ||
+		len(p.ContactPoints) > 0 || len(p.NotificationPolicies) > 0
+}
+
+// String returns a human-readable summary of the plan, one change per line
+func (p *Plan) String() string {
+	if !p.HasChanges() {
+		return "No changes\n"
+	}
+
+	var sb strings.Builder
+	if p.CreateFolder {
+		fmt.Fprintf(&sb, "Folder %q will be created\n", p.FolderName)
+	}
+	if p.Dashboard != nil {
+		sb.WriteString("Dashboard:\n")
+		writeChange(&sb, *p.Dashboard)
+	}
+	for _, section := range []struct {
+		title   string
+		changes []Change
+	}{
+		{"Panels", p.Panels},
+		{"Alert rules", p.AlertRules},
+		{"Contact points", p.ContactPoints},
+		{"Notification policies", p.NotificationPolicies},
+	} {
+		if len(section.changes) == 0 {
+			continue
+		}
+		sb.WriteString(section.title + ":\n")
+		for _, change := range section.changes {
+			writeChange(&sb, change)
+		}
+	}
+
+	return sb.String()
+}
+
+func writeChange(sb *strings.Builder, change Change) {
+	symbol := map[ChangeAction]string{
+		ChangeActionAdd:    "+",
+		ChangeActionRemove: "-",
+		ChangeActionModify: "~",
+	}[change.Action]
+	fmt.Fprintf(sb, "  %s %s", symbol, change.Name)
+	if len(change.Fields) > 0 {
+		fmt.Fprintf(sb, " (%s)", strings.Join(change.Fields, ", "))
+	}
+	sb.WriteString("\n")
+}
+
+// Fields set by Grafana which are not compared
+var (
+	dashboardIgnoredFields = []string{"id", "uid", "version", "iteration", "schemaVersion", "panels"}
+	panelIgnoredFields     = []string{"pluginVersion", "panels"}
+	alertRuleIgnoredFields = []string{"id", "uid", "updated", "provenance", "orgID"}
+	contactPointIgnored    = []string{"uid", "provenance"}
+)
+
+// Plan fetches the current state of Grafana and returns the changes DeployToGrafana would make with the same options
+func (o *Observability) Plan(options *DeployOptions) (*Plan, error) {
+	grafanaClient := api.NewClient(
+		options.GrafanaURL,
+		options.GrafanaToken,
+	)
+
+	plan := &Plan{FolderName: options.FolderName}
+
+	var folder *api.Folder
+	if options.FolderName != "" {
+		var errFolder error
+		folder, errFolder = grafanaClient.GetFolderByTitle(options.FolderName)
+		if errFolder != nil {
+			return nil, errFolder
+		}
+		plan.CreateFolder = folder == nil
+	}
+
+	// The dashboard and alerts are only deployed to a folder
+	var dashboardUID *string
+	if options.FolderName != "" && o.Dashboard != nil {
+		var errDashboard error
+		dashboardUID, errDashboard = o.planDashboard(grafanaClient, plan)
+		if errDashboard != nil {
+			return nil, errDashboard
+		}
+	}
+
+	if options.FolderName != "" && len(o.Alerts) > 0 {
+		var currentAlerts []alerting.Rule
+		// A new folder or dashboard has no alert rules yet
+		if folder != nil && (o.Dashboard == nil || dashboardUID != nil) {
+			var errGetAlertRules error
+			currentAlerts, errGetAlertRules = getAlertRules(grafanaClient, dashboardUID, folder.UID, o.AlertGroups)
+			if errGetAlertRules != nil {
+				return nil, errGetAlertRules
+			}
+		}
+
+		folderUID := ""
+		if folder != nil {
+			folderUID = folder.UID
+		}
+		var errAlerts error
+		plan.AlertRules, errAlerts = o.planAlertRules(currentAlerts, options.EnableAlerts, folderUID, dashboardUID)
+		if errAlerts != nil {
+			return nil, errAlerts
+		}
+	}
+
+	if len(o.ContactPoints) > 0 {
+		currentContactPoints, _, errGetContactPoints := grafanaClient.GetContactPoints()
+		if errGetContactPoints != nil {
+			return nil, errGetContactPoints
+		}
+
+		for _, contactPoint := range o.ContactPoints {
+			var current *alerting.ContactPoint
+			for i := range currentContactPoints {
+				if *currentContactPoints[i].Name == *contactPoint.Name {
+					current = &currentContactPoints[i]
+				}
+			}
+
+			change, errDiff := diffResource(*contactPoint.Name, current, contactPoint, contactPointIgnored)
+			if errDiff != nil {
+				return nil, errDiff
+			}
+			if change != nil {
+				plan.ContactPoints = append(plan.ContactPoints, *change)
+			}
+		}
+	}
+
+	if len(o.NotificationPolicies) > 0 {
+		notificationPolicyTree, _, errGetNotificationPolicy := grafanaClient.GetNotificationPolicy()
+		if errGetNotificationPolicy != nil {
+			return nil, errGetNotificationPolicy
+		}
+
+		for _, notificationPolicy := range o.NotificationPolicies {
+			current := api.GetNestedPolicy(alerting.NotificationPolicy(notificationPolicyTree), notificationPolicy)
+			change, errDiff := diffResource(policyName(notificationPolicy), current, notificationPolicy, nil)
+			if errDiff != nil {
+				return nil, errDiff
+			}
+			if change != nil {
+				plan.NotificationPolicies = append(plan.NotificationPolicies, *change)
+			}
+		}
+	}
+
+	return plan, nil
+}
+
+// planDashboard adds the changes of the dashboard and its panels to the plan, and returns the UID of the current dashboard
+func (o *Observability) planDashboard(grafanaClient *api.Client, plan *Plan) (*string, error) {
+	title := *o.Dashboard.Title
+	desired, errDesired := toJSONMap(o.Dashboard)
+	if errDesired != nil {
+		return nil, errDesired
+	}
+
+	existing, _, errGetDashboard := grafanaClient.GetDashboardByName(title)
+	if errGetDashboard != nil {
+		return nil, errGetDashboard
+	}
+
+	if existing.UID == nil {
+		plan.Dashboard = &Change{Action: ChangeActionAdd, Name: title}
+		for _, name := range sortedKeys(dashboardPanels(desired)) {
+			plan.Panels = append(plan.Panels, Change{Action: ChangeActionAdd, Name: name})
+		}
+		return nil, nil
+	}
+
+	current, _, errGetDashboardByUID := grafanaClient.GetDashboardByUID(*existing.UID)
+	if errGetDashboardByUID != nil {
+		return nil, errGetDashboardByUID
+	}
+
+	if fields := diffFields(current.Dashboard, desired, dashboardIgnoredFields); len(fields) > 0 {
+		plan.Dashboard = &Change{Action: ChangeActionModify, Name: title, Fields: fields}
+	}
+	plan.Panels = diffMaps(dashboardPanels(current.Dashboard), dashboardPanels(desired), panelIgnoredFields)
+
+	return existing.UID, nil
+}
+
+func (o *Observability) planAlertRules(currentAlerts []alerting.Rule, enableAlerts bool, folderUID string, dashboardUID *string) ([]Change, error) {
+	current := map[string]map[string]interface{}{}
+	uids := map[string]string{}
+	for _, alert := range currentAlerts {
+		alertMap, err := toJSONMap(alert)
+		if err != nil {
+			return nil, err
+		}
+		current[alert.Title] = alertMap
+		if alert.Uid != nil {
+			uids[alert.Title] = *alert.Uid
+		}
+	}
+
+	// Disabled alerts are deleted
+	desired := map[string]map[string]interface{}{}
+	if enableAlerts {
+		for _, alert := range o.Alerts {
+			prepared, err := o.prepareAlert(alert, folderUID, dashboardUID)
+			if err != nil {
+				return nil, err
+			}
+			alertMap, err := toJSONMap(prepared)
+			if err != nil {
+				return nil, err
+			}
+			desired[alert.Title] = alertMap
+		}
+	}
+
+	changes := diffMaps(current, desired, alertRuleIgnoredFields)
+	for i := range changes {
+		changes[i].UID = uids[changes[i].Name]
+	}
+	return changes, nil
+}
+
+// ApplyPlan makes the changes of a plan returned by Plan with the same options, leaving other resources untouched.
+// Alert rule groups and notification templates are not part of the plan and are updated whenever alert rules change.
+func (o *Observability) ApplyPlan(plan *Plan, options *DeployOptions) error {
+	grafanaClient := api.NewClient(
+		options.GrafanaURL,
+		options.GrafanaToken,
+	)
+
+	var folder *api.Folder
+	var errFolder error
+	if options.FolderName != "" {
+		folder, errFolder = grafanaClient.FindOrCreateFolder(options.FolderName)
+		if errFolder != nil {
+			return errFolder
+		}
+	}
+
+	var dashboardUID *string
+	if folder != nil && o.Dashboard != nil {
+		if plan.Dashboard != nil || len(plan.Panels) > 0 {
+			newDashboard, _, errPostDashboard := grafanaClient.PostDashboard(api.PostDashboardRequest{
+				Dashboard: o.Dashboard,
+				Overwrite: true,
+				FolderID:  int(folder.ID),
+			})
+			if errPostDashboard != nil {
+				return errPostDashboard
+			}
+			dashboardUID = newDashboard.UID
+		} else {
+			existing, _, errGetDashboard := grafanaClient.GetDashboardByName(*o.Dashboard.Title)
+			if errGetDashboard != nil {
+				return errGetDashboard
+			}
+			dashboardUID = existing.UID
+		}
+	}
+
+	if folder != nil && len(plan.AlertRules) > 0 {
+		for _, change := range plan.AlertRules {
+			if change.Action == ChangeActionRemove {
+				_, _, errDeleteAlertRule := grafanaClient.DeleteAlertRule(change.UID)
+				if errDeleteAlertRule != nil {
+					return errDeleteAlertRule
+				}
+				continue
+			}
+
+			alert := getAlertRuleByTitle(o.Alerts, change.Name)
+			if alert == nil {
+				return fmt.Errorf("alert rule %q of the plan is not defined", change.Name)
+			}
+			prepared, errPrepare := o.prepareAlert(*alert, folder.UID, dashboardUID)
+			if errPrepare != nil {
+				return errPrepare
+			}
+
+			if change.Action == ChangeActionAdd {
+				_, _, errPostAlertRule := grafanaClient.PostAlertRule(prepared)
+				if errPostAlertRule != nil {
+					return errPostAlertRule
+				}
+			} else {
+				_, _, errPutAlertRule := grafanaClient.UpdateAlertRule(change.UID, prepared)
+				if errPutAlertRule != nil {
+					return errPutAlertRule
+				}
+			}
+		}
+
+		for _, alertGroup := range o.AlertGroups {
+			_, _, errPostAlertGroup := grafanaClient.UpdateAlertRuleGroup(folder.UID, alertGroup)
+			if errPostAlertGroup != nil {
+				return errPostAlertGroup
+			}
+		}
+
+		if errTemplates := deployNotificationTemplates(grafanaClient, options.NotificationTemplates); errTemplates != nil {
+			return errTemplates
+		}
+	}
+
+	for _, change := range plan.ContactPoints {
+		for _, contactPoint := range o.ContactPoints {
+			if *contactPoint.Name != change.Name {
+				continue
+			}
+			errCreateOrUpdateContactPoint := grafanaClient.CreateOrUpdateContactPoint(contactPoint)
+			if errCreateOrUpdateContactPoint != nil {
+				return errCreateOrUpdateContactPoint
+			}
+		}
+	}
+
+	for _, change := range plan.NotificationPolicies {
+		for _, notificationPolicy := range o.NotificationPolicies {
+			if policyName(notificationPolicy) != change.Name {
+				continue
+			}
+			errAddNestedPolicy := grafanaClient.AddNestedPolicy(notificationPolicy)
+			if errAddNestedPolicy != nil {
+				return errAddNestedPolicy
+			}
+		}
+	}
+
+	return nil
+}
+
+func policyName(policy alerting.NotificationPolicy) string {
+	receiver := ""
+	if policy.Receiver != nil {
+		receiver = *policy.Receiver
+	}
+	var matchers []string
+	if policy.ObjectMatchers != nil {
+		for _, matcher := range *policy.ObjectMatchers {
+			matchers = append(matchers, strings.Join(matcher, ""))
+		}
+	}
+	sort.Strings(matchers)
+	return fmt.Sprintf("%s [%s]", receiver, strings.Join(matchers, ", "))
+}
+
+// dashboardPanels returns the panels of a dashboard JSON model by title, including the panels of collapsed rows
+func dashboardPanels(dashboardJSON map[string]interface{}) map[string]map[string]interface{} {
+	panels := map[string]map[string]interface{}{}
+
+	var add func(list interface{})
+	add = func(list interface{}) {
+		items, _ := list.([]interface{})
+		for _, item := range items {
+			panel, ok := item.(map[string]interface{})
+			if !ok {
+				continue
+			}
+
+			name, _ := panel["title"].(string)
+			if name == "" {
+				name = fmt.Sprintf("panel %v", panel["id"])
+			}
+			// Panels with the same title are told apart by their order
+			for i := 2; panels[name] != nil; i++ {
+				name = fmt.Sprintf("%s (%d)", strings.TrimSuffix(name, fmt.Sprintf(" (%d)", i-1)), i)
+			}
+			panels[name] = panel
+
+			add(panel["panels"])
+		}
+	}
+	add(dashboardJSON["panels"])
+
+	return panels
+}
+
+// diffResource returns the change from current to desired, nil if they are equal.
+// A nil current is added.
+func diffResource[T any](name string, current *T, desired T, ignored []string) (*Change, error) {
+	if current == nil {
+		return &Change{Action: ChangeActionAdd, Name: name}, nil
+	}
+
+	currentMap, err := toJSONMap(current)
+	if err != nil {
+		return nil, err
+	}
+	desiredMap, err := toJSONMap(desired)
+	if err != nil {
+		return nil, err
+	}
+
+	if fields := diffFields(currentMap, desiredMap, ignored); len(fields) > 0 {
+		return &Change{Action: ChangeActionModify, Name: name, Fields: fields}, nil
+	}
+	return nil, nil
+}
+
+// diffMaps returns the changes from the current to the desired resources, by name
+func diffMaps(current, desired map[string]map[string]interface{}, ignored []string) []Change {
+	var changes []Change
+	for _, name := range sortedKeys(current) {
+		if _, ok := desired[name]; !ok {
+			changes = append(changes, Change{Action: ChangeActionRemove, Name: name})
+		}
+	}
+	for _, name := range sortedKeys(desired) {
+		currentResource, ok := current[name]
+		if !ok {
+			changes = append(changes, Change{Action: ChangeActionAdd, Name: name})
+			continue
+		}
+		if fields := diffFields(currentResource, desired[name], ignored); len(fields) > 0 {
+			changes = append(changes, Change{Action: ChangeActionModify, Name: name, Fields: fields})
+		}
+	}
+	return changes
+}
+
+// diffFields returns the sorted top level fields of desired whose values differ from current.
+// Fields only set in current are defaults filled in by Grafana and are not compared.
+func diffFields(current, desired map[string]interface{}, ignored []string) []string {
+	var fields []string
+	for _, field := range sortedKeys(desired) {
+		if contains(ignored, field) {
+			continue
+		}
+		if !reflect.DeepEqual(current[field], desired[field]) {
+			fields = append(fields, field)
+		}
+	}
+	return fields
+}
+
+func toJSONMap(v interface{}) (map[string]interface{}, error) {
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	var m map[string]interface{}
+	if err = json.Unmarshal(raw, &m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func contains(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,366 @@
+package grafana_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/grafana/grafana-foundation-sdk/go/alerting"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink-common/observability-lib/grafana"
+)
+
+func TestPlan(t *testing.T) {
+	fake := newFakeGrafana()
+	server := httptest.NewServer(fake)
+	defer server.Close()
+
+	options := &grafana.DeployOptions{
+		GrafanaURL:   server.URL,
+		GrafanaToken: "token",
+		FolderName:   "Folder",
+		EnableAlerts: true,
+	}
+
+	t.Run("Plan adds every resource to an empty Grafana", func(t *testing.T) {
+		o := buildObservability(t, false)
+
+		plan, err := o.Plan(options)
+		require.NoError(t, err)
+		require.True(t, plan.CreateFolder)
+		require.Equal(t, &grafana.Change{Action: grafana.ChangeActionAdd, Name: "Dashboard Name"}, plan.Dashboard)
+		require.Equal(t, []grafana.Change{
+			{Action: grafana.ChangeActionAdd, Name: "ETH Balance"},
+			{Action: grafana.ChangeActionAdd, Name: "LINK Balance"},
+		}, plan.Panels)
+		require.Equal(t, []grafana.Change{
+			{Action: grafana.ChangeActionAdd, Name: "ETH Balance"},
+			{Action: grafana.ChangeActionAdd, Name: "LINK Balance low"},
+		}, plan.AlertRules)
+		require.Equal(t, []grafana.Change{{Action: grafana.ChangeActionAdd, Name: "slack"}}, plan.ContactPoints)
+		require.Equal(t, []grafana.Change{{Action: grafana.ChangeActionAdd, Name: "slack [team=chainlink]"}}, plan.NotificationPolicies)
+		require.Empty(t, fake.takeRequests(), "planning should not change Grafana")
+
+		require.NoError(t, o.ApplyPlan(plan, options))
+		require.Equal(t, []string{
+			"POST /api/folders",
+			"POST /api/dashboards/db",
+			"POST /api/v1/provisioning/alert-rules",
+			"POST /api/v1/provisioning/alert-rules",
+			"POST /api/v1/provisioning/contact-points",
+			"PUT /api/v1/provisioning/policies",
+		}, fake.takeRequests())
+
+		plan, err = o.Plan(options)
+		require.NoError(t, err)
+		require.False(t, plan.HasChanges(), plan.String())
+		require.Equal(t, "No changes\n", plan.String())
+	})
+
+	t.Run("Plan only contains the changed resources", func(t *testing.T) {
+		o := buildObservability(t, true)
+
+		plan, err := o.Plan(options)
+		require.NoError(t, err)
+		require.False(t, plan.CreateFolder)
+		require.Nil(t, plan.Dashboard)
+		require.Equal(t, []grafana.Change{
+			{Action: grafana.ChangeActionRemove, Name: "LINK Balance"},
+			{Action: grafana.ChangeActionModify, Name: "ETH Balance", Fields: []string{"fieldConfig"}},
+			{Action: grafana.ChangeActionAdd, Name: "Gas Price"},
+		}, plan.Panels)
+
+		ethAlertUID := fake.alertRuleUID("ETH Balance")
+		linkAlertUID := fake.alertRuleUID("LINK Balance low")
+		require.Equal(t, []grafana.Change{
+			{Action: grafana.ChangeActionRemove, Name: "LINK Balance low", UID: linkAlertUID},
+			{Action: grafana.ChangeActionModify, Name: "ETH Balance", UID: ethAlertUID, Fields: []string{"for"}},
+		}, plan.AlertRules)
+		require.Equal(t, []grafana.Change{
+			{Action: grafana.ChangeActionModify, Name: "slack", Fields: []string{"settings"}},
+		}, plan.ContactPoints)
+		require.Empty(t, plan.NotificationPolicies)
+
+		require.Equal(t, `Panels:
+  - LINK Balance
+  ~ ETH Balance (fieldConfig)
+  + Gas Price
+Alert rules:
+  - LINK Balance low
+  ~ ETH Balance (for)
+Contact points:
+  ~ slack (settings)
+`, plan.String())
+
+		require.NoError(t, o.ApplyPlan(plan, options))
+		require.Equal(t, []string{
+			"POST /api/dashboards/db",
+			"DELETE /api/v1/provisioning/alert-rules/" + linkAlertUID,
+			"PUT /api/v1/provisioning/alert-rules/" + ethAlertUID,
+			"PUT /api/v1/provisioning/contact-points/" + fake.contactPointUID("slack"),
+		}, fake.takeRequests())
+
+		plan, err = o.Plan(options)
+		require.NoError(t, err)
+		require.False(t, plan.HasChanges(), plan.String())
+	})
+
+	t.Run("Plan removes alert rules when alerts are disabled", func(t *testing.T) {
+		o := buildObservability(t, true)
+
+		plan, err := o.Plan(&grafana.DeployOptions{
+			GrafanaURL:   server.URL,
+			GrafanaToken: "token",
+			FolderName:   "Folder",
+		})
+		require.NoError(t, err)
+		require.Equal(t, []grafana.Change{
+			{Action: grafana.ChangeActionRemove, Name: "ETH Balance", UID: fake.alertRuleUID("ETH Balance")},
+		}, plan.AlertRules)
+	})
+
+	t.Run("Plan fails if Grafana is unavailable", func(t *testing.T) {
+		o := buildObservability(t, false)
+
+		_, err := o.Plan(&grafana.DeployOptions{GrafanaURL: "http://127.0.0.1:0", FolderName: "Folder"})
+		require.Error(t, err)
+	})
+}
+
+// buildObservability builds a dashboard with alerts, a contact point and a notification policy.
+// The updated version removes a panel and its alert, changes a panel and its alert, adds a panel and changes the contact point.
+func buildObservability(t *testing.T, updated bool) *grafana.Observability {
+	builder := grafana.NewBuilder(&grafana.BuilderOptions{
+		Name:       "Dashboard Name",
+		AlertsTags: map[string]string{"team": "chainlink"},
+	})
+
+	ethDecimals, ethFor, slackURL := 2.0, "1m", "https://hooks.slack.com/1"
+	if updated {
+		ethDecimals, ethFor, slackURL = 4, "10m", "https://hooks.slack.com/2"
+	}
+
+	builder.AddPanel(grafana.NewTimeSeriesPanel(&grafana.TimeSeriesPanelOptions{
+		PanelOptions: &grafana.PanelOptions{
+			Datasource: "datasource-name",
+			Title:      "ETH Balance",
+			Decimals:   ethDecimals,
+			Query:      []grafana.Query{{Expr: `eth_balance`}},
+		},
+		AlertsOptions: []grafana.AlertOptions{{For: ethFor}},
+	}))
+	if updated {
+		builder.AddPanel(grafana.NewTimeSeriesPanel(&grafana.TimeSeriesPanelOptions{
+			PanelOptions: &grafana.PanelOptions{
+				Datasource: "datasource-name",
+				Title:      "Gas Price",
+				Query:      []grafana.Query{{Expr: `gas_price`}},
+			},
+		}))
+	} else {
+		builder.AddPanel(grafana.NewTimeSeriesPanel(&grafana.TimeSeriesPanelOptions{
+			PanelOptions: &grafana.PanelOptions{
+				Datasource: "datasource-name",
+				Title:      "LINK Balance",
+				Query:      []grafana.Query{{Expr: `link_balance`}},
+			},
+			AlertsOptions: []grafana.AlertOptions{{Title: "LINK Balance low"}},
+		}))
+	}
+
+	builder.AddContactPoint(grafana.NewContactPoint(&grafana.ContactPointOptions{
+		Name:     "slack",
+		Type:     "slack",
+		Settings: map[string]interface{}{"url": slackURL},
+	}))
+	builder.AddNotificationPolicy(grafana.NewNotificationPolicy(&grafana.NotificationPolicyOptions{
+		Receiver:       "slack",
+		ObjectMatchers: []alerting.ObjectMatcher{{"team", "=", "chainlink"}},
+	}))
+
+	o, err := builder.Build()
+	require.NoError(t, err)
+	return o
+}
+
+// fakeGrafana is a stand-in for the Grafana HTTP API endpoints used by Plan and ApplyPlan.
+// It stores resources as JSON objects and records the requests changing them.
+type fakeGrafana struct {
+	mu            sync.Mutex
+	folders       []map[string]interface{}
+	dashboards    []map[string]interface{}
+	alertRules    []map[string]interface{}
+	contactPoints []map[string]interface{}
+	policies      map[string]interface{}
+	requests      []string
+	lastUID       int
+}
+
+func newFakeGrafana() *fakeGrafana {
+	return &fakeGrafana{
+		policies: map[string]interface{}{"receiver": "grafana-default-email"},
+	}
+}
+
+func (f *fakeGrafana) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if r.Method != http.MethodGet {
+		f.requests = append(f.requests, r.Method+" "+r.URL.Path)
+	}
+
+	var body map[string]interface{}
+	if r.Body != nil && r.Method != http.MethodGet && r.Method != http.MethodDelete {
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+	}
+
+	path := r.URL.Path
+	switch {
+	case path == "/api/folders" && r.Method == http.MethodGet:
+		writeJSON(w, http.StatusOK, f.folders)
+	case path == "/api/folders" && r.Method == http.MethodPost:
+		folder := map[string]interface{}{"id": len(f.folders) + 1, "uid": f.newUID("folder"), "title": body["title"]}
+		f.folders = append(f.folders, folder)
+		writeJSON(w, http.StatusOK, folder)
+	case path == "/api/search":
+		var results []map[string]interface{}
+		for _, db := range f.dashboards {
+			if strings.Contains(db["title"].(string), r.URL.Query().Get("query")) {
+				results = append(results, map[string]interface{}{"id": db["id"], "uid": db["uid"], "title": db["title"]})
+			}
+		}
+		writeJSON(w, http.StatusOK, results)
+	case strings.HasPrefix(path, "/api/dashboards/uid/"):
+		db := find(f.dashboards, "uid", strings.TrimPrefix(path, "/api/dashboards/uid/"))
+		if db == nil {
+			http.NotFound(w, r)
+			return
+		}
+		writeJSON(w, http.StatusOK, map[string]interface{}{"dashboard": db, "meta": map[string]interface{}{}})
+	case path == "/api/dashboards/db":
+		db := body["dashboard"].(map[string]interface{})
+		existing := find(f.dashboards, "title", db["title"].(string))
+		if existing == nil {
+			db["uid"], db["id"], db["version"] = f.newUID("dashboard"), len(f.dashboards)+1, 1
+			f.dashboards = append(f.dashboards, db)
+		} else {
+			db["uid"], db["id"], db["version"] = existing["uid"], existing["id"], existing["version"].(int)+1
+			replace(f.dashboards, existing, db)
+		}
+		writeJSON(w, http.StatusOK, map[string]interface{}{"uid": db["uid"], "id": db["id"], "version": db["version"]})
+	case path == "/api/v1/provisioning/alert-rules" && r.Method == http.MethodGet:
+		writeJSON(w, http.StatusOK, f.alertRules)
+	case path == "/api/v1/provisioning/alert-rules" && r.Method == http.MethodPost:
+		body["uid"], body["provenance"] = f.newUID("rule"), "api"
+		f.alertRules = append(f.alertRules, body)
+		writeJSON(w, http.StatusCreated, body)
+	case strings.HasPrefix(path, "/api/v1/provisioning/alert-rules/"):
+		uid := strings.TrimPrefix(path, "/api/v1/provisioning/alert-rules/")
+		existing := find(f.alertRules, "uid", uid)
+		if existing == nil {
+			http.NotFound(w, r)
+			return
+		}
+		if r.Method == http.MethodDelete {
+			f.alertRules = remove(f.alertRules, existing)
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		body["uid"], body["provenance"] = uid, "api"
+		replace(f.alertRules, existing, body)
+		writeJSON(w, http.StatusOK, body)
+	case strings.HasPrefix(path, "/api/v1/provisioning/folder/"):
+		writeJSON(w, http.StatusOK, body)
+	case path == "/api/v1/provisioning/contact-points" && r.Method == http.MethodGet:
+		writeJSON(w, http.StatusOK, f.contactPoints)
+	case path == "/api/v1/provisioning/contact-points" && r.Method == http.MethodPost:
+		body["uid"] = f.newUID("contact-point")
+		f.contactPoints = append(f.contactPoints, body)
+		writeJSON(w, http.StatusAccepted, body)
+	case strings.HasPrefix(path, "/api/v1/provisioning/contact-points/"):
+		uid := strings.TrimPrefix(path, "/api/v1/provisioning/contact-points/")
+		existing := find(f.contactPoints, "uid", uid)
+		if existing == nil {
+			http.NotFound(w, r)
+			return
+		}
+		body["uid"] = uid
+		replace(f.contactPoints, existing, body)
+		writeJSON(w, http.StatusAccepted, body)
+	case path == "/api/v1/provisioning/policies" && r.Method == http.MethodGet:
+		writeJSON(w, http.StatusOK, f.policies)
+	case path == "/api/v1/provisioning/policies" && r.Method == http.MethodPut:
+		f.policies = body
+		writeJSON(w, http.StatusAccepted, body)
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func (f *fakeGrafana) newUID(kind string) string {
+	f.lastUID++
+	return fmt.Sprintf("%s-%d", kind, f.lastUID)
+}
+
+// takeRequests returns the requests changing Grafana since the last call
+func (f *fakeGrafana) takeRequests() []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	requests := f.requests
+	f.requests = nil
+	return requests
+}
+
+func (f *fakeGrafana) alertRuleUID(title string) string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return find(f.alertRules, "title", title)["uid"].(string)
+}
+
+func (f *fakeGrafana) contactPointUID(name string) string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return find(f.contactPoints, "name", name)["uid"].(string)
+}
+
+func find(resources []map[string]interface{}, key string, value string) map[string]interface{} {
+	for _, resource := range resources {
+		if resource[key] == value {
+			return resource
+		}
+	}
+	return nil
+}
+
+func replace(resources []map[string]interface{}, existing map[string]interface{}, resource map[string]interface{}) {
+	for i := range resources {
+		if resources[i]["uid"] == existing["uid"] {
+			resources[i] = resource
+		}
+	}
+}
+
+func remove(resources []map[string]interface{}, existing map[string]interface{}) []map[string]interface{} {
+	var kept []map[string]interface{}
+	for _, resource := range resources {
+		if resource["uid"] != existing["uid"] {
+			kept = append(kept, resource)
+		}
+	}
+	return kept
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
@@ -9,6 +9,7 @@ import (
 	"math/big"
 	"sort"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/shopspring/decimal"
@@ -23,8 +24,13 @@ import (
 )
 
 const (
-	AGGREGATION_METHOD_MEDIAN = "median"
-	AGGREGATION_METHOD_MODE   = "mode"
+	AGGREGATION_METHOD_MEDIAN              = "median"
+	AGGREGATION_METHOD_MODE                = "mode"
+	AGGREGATION_METHOD_INTERPOLATED_MEDIAN = "interpolated_median"
+	AGGREGATION_METHOD_TRIMMED_MEAN        = "trimmed_mean"
+	AGGREGATION_METHOD_WEIGHTED_MEDIAN     = "weighted_median"
+	AGGREGATION_METHOD_MIN                 = "min"
+	AGGREGATION_METHOD_MAX                 = "max"
 	// DEVIATION_TYPE_NONE is no deviation check
 	DEVIATION_TYPE_NONE = "none"
 	// DEVIATION_TYPE_ANY is any difference from the previous value to the next value
@@ -39,6 +45,9 @@ const (
 	MODE_QUORUM_OCR         = "ocr"
 	MODE_QUORUM_ANY         = "any"
 
+	// HEARTBEAT_STATE_KEY is the key in the aggregator state holding the time of the last report
+	HEARTBEAT_STATE_KEY = "INTERNAL_LAST_REPORTED_AT"
+
 	DEFAULT_REPORT_FORMAT     = REPORT_FORMAT_MAP
 	DEFAULT_OUTPUT_FIELD_NAME = "Reports"
 	DEFAULT_MODE_QUORUM       = MODE_QUORUM_ANY
@@ -54,6 +63,10 @@ type ReduceAggConfig struct {
 	// Optional key name, that when given will contain a nested map with designated Fields moved into it
 	// If given, one or more fields must be given SubMapField: true
 	SubMapKey string `mapstructure:"subMapKey" json:"subMapKey" default:""`
+	// Optional number of seconds after the last report at which a report is forced, even if no field deviated.
+	// The time is the consensus timestamp of the OCR round, so every node makes the same decision.
+	// Has no effect when no field defines a deviation, as every round is reported.
+	Heartbeat int `mapstructure:"heartbeat" json:"heartbeat,omitempty"`
 }
 
 type AggregationField struct {
@@ -74,11 +87,21 @@ type AggregationField struct {
 	// How the data set should be aggregated to a single value
 	// * median - take the centermost value of the sorted data set of observations. can only be used on numeric types. not a true median, because no average if two middle values.
 	// * mode - take the most frequent value. if tied, use the "first". use "ModeQuorom" to configure the minimum number of seen values.
-	Method string `mapstructure:"method" json:"method" jsonschema:"enum=median,enum=mode" required:"true"`
+	// * interpolated_median - a true median, the two middle values are averaged when there is an even number of observations.
+	// * trimmed_mean - drop the f highest and f lowest observations and average the rest.
+	// * weighted_median - the lower weighted median, using "Weights" to weigh each oracle's observation.
+	// * min - the (f+1)th lowest value, so that at least one honest oracle observed a value at or below the result.
+	// * max - the (f+1)th highest value, so that at least one honest oracle observed a value at or above the result.
+	// Methods that average values (interpolated_median, trimmed_mean) truncate integer results towards zero
+	// and average time.Time values at second precision.
+	Method string `mapstructure:"method" json:"method" jsonschema:"enum=median,enum=mode,enum=interpolated_median,enum=trimmed_mean,enum=weighted_median,enum=min,enum=max" required:"true"`
 	// When using Method=mode, this will configure the minimum number of values that must be seen
 	// * ocr - (default) enforces that the number of matching values must be at least f+1, otherwise consensus fails
 	// * any - do not enforce any limit on the minimum viable count. this may result in unexpected answers if every observation is unique.
 	ModeQuorum string `mapstructure:"modeQuorum" json:"modeQuorum,omitempty" jsonschema:"enum=ocr,enum=any" default:"ocr"`
+	// When using Method=weighted_median, the weight of each oracle's observation, indexed by oracle ID.
+	// Oracles without an entry have a weight of 1. Weights cannot be negative.
+	Weights []int64 `mapstructure:"weights" json:"weights,omitempty"`
 	// The key that the aggregated data is put under
 	// If omitted, the InputKey will be used
 	OutputKey string `mapstructure:"outputKey" json:"outputKey"`
@@ -91,10 +114,16 @@ type reduceAggregator struct {
 	config ReduceAggConfig
 }
 
-var _ types.Aggregator = (*reduceAggregator)(nil)
+var _ types.TimestampedAggregator = (*reduceAggregator)(nil)
 
 // Condenses multiple observations into a single encodable outcome
+// Heartbeats are not checked, as there is no consensus timestamp, use AggregateAt instead.
 func (a *reduceAggregator) Aggregate(lggr logger.Logger, previousOutcome *types.AggregationOutcome, observations map[ocrcommon.OracleID][]values.Value, f int) (*types.AggregationOutcome, error) {
+	return a.AggregateAt(lggr, previousOutcome, observations, f, time.Time{})
+}
+
+// Condenses multiple observations into a single encodable outcome, forcing a report if the heartbeat has elapsed at timestamp
+func (a *reduceAggregator) AggregateAt(lggr logger.Logger, previousOutcome *types.AggregationOutcome, observations map[ocrcommon.OracleID][]values.Value, f int, timestamp time.Time) (*types.AggregationOutcome, error) {
 	if len(observations) < 2*f+1 {
 		return nil, fmt.Errorf("not enough observations, have %d want %d", len(observations), 2*f+1)
 	}
@@ -105,17 +134,18 @@ func (a *reduceAggregator) Aggregate(lggr logger.Logger, previousOutcome *types.
 	}
 
 	report := map[string]any{}
+	reduced := map[string]values.Value{}
 	shouldReport := false
 
 	for _, field := range a.config.Fields {
-		vals := a.extractValues(lggr, observations, field.InputKey)
+		vals, oracleIDs := a.extractValues(lggr, observations, field.InputKey)
 
 		// only proceed if every field has reached the minimum number of observations
 		if len(vals) < 2*f+1 {
 			return nil, fmt.Errorf("not enough observations provided %s, have %d want %d", field.InputKey, len(vals), 2*f+1)
 		}
 
-		singleValue, err := reduce(field.Method, vals, f, field.ModeQuorum)
+		singleValue, err := reduce(field, vals, oracleIDs, f)
 		if err != nil {
 			return nil, fmt.Errorf("unable to reduce on method %s, err: %s", field.Method, err.Error())
 		}
@@ -125,6 +155,7 @@ func (a *reduceAggregator) Aggregate(lggr logger.Logger, previousOutcome *types.
 			return nil, fmt.Errorf("unable to determine if should report, err: %s", err.Error())
 		}
 
+		reduced[field.OutputKey] = singleValue
 		if shouldReportField || field.DeviationType == DEVIATION_TYPE_NONE {
 			(*currentState)[field.OutputKey] = singleValue
 		}
@@ -170,6 +201,23 @@ func (a *reduceAggregator) Aggregate(lggr logger.Logger, previousOutcome *types.
 		shouldReport = true
 	}
 
+	if a.config.Heartbeat > 0 && !hasNoDeviation {
+		heartbeatElapsed, err := a.heartbeatElapsed(lggr, timestamp, currentState)
+		if err != nil {
+			return nil, fmt.Errorf("unable to determine if heartbeat elapsed, err: %s", err.Error())
+		}
+		if heartbeatElapsed && !shouldReport {
+			// the report carries every field, so the next deviation is measured from these values
+			for _, field := range a.config.Fields {
+				(*currentState)[field.OutputKey] = reduced[field.OutputKey]
+			}
+			shouldReport = true
+		}
+		if shouldReport && !timestamp.IsZero() {
+			(*currentState)[HEARTBEAT_STATE_KEY] = values.NewTime(timestamp)
+		}
+	}
+
 	stateValuesMap, err := values.WrapMap(currentState)
 	if err != nil {
 		return nil, fmt.Errorf("aggregate state wrapmap error: %s", err.Error())
@@ -263,6 +311,32 @@ func (a *reduceAggregator) shouldReport(lggr logger.Logger, field AggregationFie
 	return false, nil
 }
 
+func (a *reduceAggregator) heartbeatElapsed(lggr logger.Logger, timestamp time.Time, currentState *map[string]values.Value) (bool, error) {
+	if timestamp.IsZero() {
+		lggr.Warnw("heartbeat configured, but no timestamp given, skipping heartbeat check")
+		return false, nil
+	}
+
+	lastReportedAt := (*currentState)[HEARTBEAT_STATE_KEY]
+	// this means there has not been a report yet
+	if lastReportedAt == nil {
+		return true, nil
+	}
+
+	var last time.Time
+	if err := lastReportedAt.UnwrapTo(&last); err != nil {
+		return false, err
+	}
+
+	elapsed := timestamp.Sub(last)
+	if elapsed >= time.Duration(a.config.Heartbeat)*time.Second {
+		lggr.Debugw("heartbeat elapsed", "lastReportedAt", last, "timestamp", timestamp, "heartbeat", a.config.Heartbeat, "shouldReport", true)
+		return true, nil
+	}
+
+	return false, nil
+}
+
 func (a *reduceAggregator) initializeCurrentState(lggr logger.Logger, previousOutcome *types.AggregationOutcome) (*map[string]values.Value, error) {
 	currentState := map[string]values.Value{}
 
@@ -283,7 +357,7 @@ func (a *reduceAggregator) initializeCurrentState(lggr logger.Logger, previousOu
 	return &currentState, nil
 }
 
-func (a *reduceAggregator) extractValues(lggr logger.Logger, observations map[ocrcommon.OracleID][]values.Value, aggregationKey string) (vals []values.Value) {
+func (a *reduceAggregator) extractValues(lggr logger.Logger, observations map[ocrcommon.OracleID][]values.Value, aggregationKey string) (vals []values.Value, oracleIDs []ocrcommon.OracleID) {
 	for nodeID, nodeObservations := range observations {
 		// we only expect a single observation per node
 		if len(nodeObservations) == 0 || nodeObservations[0] == nil {
@@ -317,6 +391,7 @@ func (a *reduceAggregator) extractValues(lggr logger.Logger, observations map[oc
 				continue
 			}
 			vals = append(vals, rewrapped)
+			oracleIDs = append(oracleIDs, nodeID)
 		case []interface{}:
 			i, err := strconv.Atoi(aggregationKey)
 			if err != nil {
@@ -329,21 +404,23 @@ func (a *reduceAggregator) extractValues(lggr logger.Logger, observations map[oc
 				continue
 			}
 			vals = append(vals, rewrapped)
+			oracleIDs = append(oracleIDs, nodeID)
 		default:
 			// not a complex type, use raw value
 			if len(aggregationKey) == 0 {
 				vals = append(vals, nodeObservations[0])
+				oracleIDs = append(oracleIDs, nodeID)
 			} else {
 				lggr.Warnf("aggregation key %s provided, but value is not an indexable type", aggregationKey)
 			}
 		}
 	}
 
-	return vals
+	return vals, oracleIDs
 }
 
-func reduce(method string, items []values.Value, f int, modeQuorum string) (values.Value, error) {
-	switch method {
+func reduce(field AggregationField, items []values.Value, oracleIDs []ocrcommon.OracleID, f int) (values.Value, error) {
+	switch field.Method {
 	case AGGREGATION_METHOD_MEDIAN:
 		return median(items)
 	case AGGREGATION_METHOD_MODE:
@@ -351,14 +428,24 @@ func reduce(method string, items []values.Value, f int, modeQuorum string) (valu
 		if err != nil {
 			return value, err
 		}
-		err = modeHasQuorum(modeQuorum, count, f)
+		err = modeHasQuorum(field.ModeQuorum, count, f)
 		if err != nil {
 			return value, err
 		}
 		return value, err
+	case AGGREGATION_METHOD_INTERPOLATED_MEDIAN:
+		return interpolatedMedian(items)
+	case AGGREGATION_METHOD_TRIMMED_MEAN:
+		return trimmedMean(items, f)
+	case AGGREGATION_METHOD_WEIGHTED_MEDIAN:
+		return weightedMedian(items, oracleIDs, field.Weights)
+	case AGGREGATION_METHOD_MIN:
+		return minWithFloor(items, f)
+	case AGGREGATION_METHOD_MAX:
+		return maxWithFloor(items, f)
 	default:
 		// invariant, config should be validated
-		return nil, fmt.Errorf("unsupported aggregation method %s", method)
+		return nil, fmt.Errorf("unsupported aggregation method %s", field.Method)
 	}
 }
 
@@ -393,6 +480,148 @@ func sortAsDecimal(items []values.Value) error {
 	return nil
 }
 
+// decimalItem pairs an observation with its decimal representation and the oracle that observed it
+type decimalItem struct {
+	value    values.Value
+	decimal  decimal.Decimal
+	oracleID ocrcommon.OracleID
+}
+
+// sortedAscending converts items to decimals and sorts them in ascending order.
+// Ties are broken by oracle ID so that the order does not depend on map iteration.
+func sortedAscending(items []values.Value, oracleIDs []ocrcommon.OracleID) ([]decimalItem, error) {
+	if len(items) == 0 {
+		// invariant, as long as f > 0 there should be items
+		return nil, errors.New("items cannot be empty")
+	}
+	sorted := make([]decimalItem, len(items))
+	for i, item := range items {
+		deci, err := toDecimal(item)
+		if err != nil {
+			return nil, err
+		}
+		sorted[i] = decimalItem{value: item, decimal: deci}
+		if i < len(oracleIDs) {
+			sorted[i].oracleID = oracleIDs[i]
+		}
+	}
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if c := sorted[i].decimal.Cmp(sorted[j].decimal); c != 0 {
+			return c < 0
+		}
+		return sorted[i].oracleID < sorted[j].oracleID
+	})
+	return sorted, nil
+}
+
+func interpolatedMedian(items []values.Value) (values.Value, error) {
+	sorted, err := sortedAscending(items, nil)
+	if err != nil {
+		return nil, err
+	}
+	mid := len(sorted) / 2
+	if len(sorted)%2 == 1 {
+		return sorted[mid].value, nil
+	}
+	avg := sorted[mid-1].decimal.Add(sorted[mid].decimal).Div(decimal.NewFromInt(2))
+	return fromDecimal(sorted[mid].value, avg)
+}
+
+func trimmedMean(items []values.Value, f int) (values.Value, error) {
+	sorted, err := sortedAscending(items, nil)
+	if err != nil {
+		return nil, err
+	}
+	if len(sorted) <= 2*f {
+		return nil, fmt.Errorf("not enough items to trim, have %d want more than %d", len(sorted), 2*f)
+	}
+	trimmed := sorted[f : len(sorted)-f]
+	sum := decimal.NewFromInt(0)
+	for _, item := range trimmed {
+		sum = sum.Add(item.decimal)
+	}
+	return fromDecimal(trimmed[0].value, sum.Div(decimal.NewFromInt(int64(len(trimmed)))))
+}
+
+func weightedMedian(items []values.Value, oracleIDs []ocrcommon.OracleID, weights []int64) (values.Value, error) {
+	sorted, err := sortedAscending(items, oracleIDs)
+	if err != nil {
+		return nil, err
+	}
+	weightOf := func(oracleID ocrcommon.OracleID) int64 {
+		if int(oracleID) < len(weights) {
+			return weights[oracleID]
+		}
+		return 1
+	}
+	var total int64
+	for _, item := range sorted {
+		total += weightOf(item.oracleID)
+	}
+	if total <= 0 {
+		return nil, errors.New("total weight of observations must be greater than zero")
+	}
+	var cumulative int64
+	for _, item := range sorted {
+		cumulative += weightOf(item.oracleID)
+		if 2*cumulative >= total {
+			return item.value, nil
+		}
+	}
+	// invariant: cumulative reaches total on the last item
+	return nil, errors.New("unable to find weighted median")
+}
+
+func minWithFloor(items []values.Value, f int) (values.Value, error) {
+	sorted, err := sortedAscending(items, nil)
+	if err != nil {
+		return nil, err
+	}
+	if len(sorted) <= f {
+		return nil, fmt.Errorf("not enough items for min, have %d want at least %d", len(sorted), f+1)
+	}
+	return sorted[f].value, nil
+}
+
+func maxWithFloor(items []values.Value, f int) (values.Value, error) {
+	sorted, err := sortedAscending(items, nil)
+	if err != nil {
+		return nil, err
+	}
+	if len(sorted) <= f {
+		return nil, fmt.Errorf("not enough items for max, have %d want at least %d", len(sorted), f+1)
+	}
+	return sorted[len(sorted)-1-f].value, nil
+}
+
+// fromDecimal wraps deci in the same numeric type as like
+func fromDecimal(like values.Value, deci decimal.Decimal) (values.Value, error) {
+	unwrapped, err := like.Unwrap()
+	if err != nil {
+		return nil, err
+	}
+
+	switch v := unwrapped.(type) {
+	case string:
+		return values.Wrap(deci.String())
+	case decimal.Decimal:
+		// drop the trailing zeros left over from division
+		return values.Wrap(decimal.RequireFromString(deci.String()))
+	case int64:
+		return values.Wrap(deci.IntPart())
+	case *big.Int:
+		// undo the exponent toDecimal applies to big.Int
+		return values.Wrap(deci.Shift(-10).BigInt())
+	case time.Time:
+		return values.Wrap(time.Unix(deci.IntPart(), 0).UTC())
+	case float64:
+		return values.Wrap(deci.InexactFloat64())
+	default:
+		// unsupported type
+		return nil, fmt.Errorf("unable to convert decimal to type %T", v)
+	}
+}
+
 func toDecimal(item values.Value) (decimal.Decimal, error) {
 	unwrapped, err := item.Unwrap()
 	if err != nil {
@@ -588,8 +817,28 @@ func ParseConfigReduceAggregator(config values.Map) (ReduceAggConfig, error) {
 			}
 			parsedConfig.Fields[i].Deviation = deci
 		}
-		if len(field.Method) == 0 || !isOneOf(field.Method, []string{AGGREGATION_METHOD_MEDIAN, AGGREGATION_METHOD_MODE}) {
-			return ReduceAggConfig{}, fmt.Errorf("aggregation field must contain a method. options: [%s, %s]", AGGREGATION_METHOD_MEDIAN, AGGREGATION_METHOD_MODE)
+		methods := []string{
+			AGGREGATION_METHOD_MEDIAN,
+			AGGREGATION_METHOD_MODE,
+			AGGREGATION_METHOD_INTERPOLATED_MEDIAN,
+			AGGREGATION_METHOD_TRIMMED_MEAN,
+			AGGREGATION_METHOD_WEIGHTED_MEDIAN,
+			AGGREGATION_METHOD_MIN,
+			AGGREGATION_METHOD_MAX,
+		}
+		if len(field.Method) == 0 || !isOneOf(field.Method, methods) {
+			return ReduceAggConfig{}, fmt.Errorf("aggregation field must contain a method. options: [%s]", strings.Join(methods, ", "))
+		}
+		if len(field.Weights) == 0 {
+			parsedConfig.Fields[i].Weights = nil
+		}
+		if len(field.Weights) > 0 && field.Method != AGGREGATION_METHOD_WEIGHTED_MEDIAN {
+			return ReduceAggConfig{}, fmt.Errorf("aggregation field can only have weights with a method of %s", AGGREGATION_METHOD_WEIGHTED_MEDIAN)
+		}
+		for oracleID, weight := range field.Weights {
+			if weight < 0 {
+				return ReduceAggConfig{}, fmt.Errorf("aggregation field weight for oracle %d cannot be negative", oracleID)
+			}
 		}
 		if field.Method == AGGREGATION_METHOD_MODE && len(field.ModeQuorum) == 0 {
 			field.ModeQuorum = MODE_QUORUM_OCR
@@ -604,11 +853,17 @@ func ParseConfigReduceAggregator(config values.Map) (ReduceAggConfig, error) {
 		if field.SubMapField {
 			hasSubMapField = true
 		}
+		if field.OutputKey == HEARTBEAT_STATE_KEY {
+			return ReduceAggConfig{}, fmt.Errorf("aggregation field cannot use the reserved outputkey %s", HEARTBEAT_STATE_KEY)
+		}
 		if outputKeyCount[field.OutputKey] {
 			return ReduceAggConfig{}, errors.New("multiple fields have the same outputkey, which will overwrite each other")
 		}
 		outputKeyCount[field.OutputKey] = true
 	}
+	if parsedConfig.Heartbeat < 0 {
+		return ReduceAggConfig{}, fmt.Errorf("heartbeat cannot be negative. received: %d", parsedConfig.Heartbeat)
+	}
 	if len(parsedConfig.SubMapKey) > 0 && !hasSubMapField {
 		return ReduceAggConfig{}, fmt.Errorf("sub Map key %s given, but no fields are marked as sub map fields", parsedConfig.SubMapKey)
 	}
@@ -667,6 +667,264 @@ func TestReduceAggregator_Aggregate(t *testing.T) {
 	})
 }
 
+func TestReduceAggregator_AggregateMethods(t *testing.T) {
+	numericTypes := []struct {
+		name string
+		wrap func(i int64) any
+		// fromDecimal is the expected result for a decimal that is not a whole number
+		fromDecimal func(d decimal.Decimal) any
+	}{
+		{
+			name:        "int64",
+			wrap:        func(i int64) any { return i },
+			fromDecimal: func(d decimal.Decimal) any { return d.IntPart() },
+		},
+		{
+			name:        "decimal",
+			wrap:        func(i int64) any { return decimal.NewFromInt(i) },
+			fromDecimal: func(d decimal.Decimal) any { return d },
+		},
+		{
+			name:        "big.Int",
+			wrap:        func(i int64) any { return big.NewInt(i) },
+			fromDecimal: func(d decimal.Decimal) any { return d.BigInt() },
+		},
+		{
+			name:        "float64",
+			wrap:        func(i int64) any { return float64(i) },
+			fromDecimal: func(d decimal.Decimal) any { return d.InexactFloat64() },
+		},
+		{
+			name:        "time.Time",
+			wrap:        func(i int64) any { return time.Unix(i, 0).UTC() },
+			fromDecimal: func(d decimal.Decimal) any { return time.Unix(d.IntPart(), 0).UTC() },
+		},
+	}
+
+	cases := []struct {
+		name         string
+		method       string
+		weights      []int64
+		observations []int64
+		// expected is either a whole number, or a decimal passed to fromDecimal
+		expected        int64
+		expectedDecimal *decimal.Decimal
+	}{
+		{
+			name:         "interpolated median odd",
+			method:       aggregators.AGGREGATION_METHOD_INTERPOLATED_MEDIAN,
+			observations: []int64{100, 10, 30, 20, 70},
+			expected:     30,
+		},
+		{
+			name:            "interpolated median even",
+			method:          aggregators.AGGREGATION_METHOD_INTERPOLATED_MEDIAN,
+			observations:    []int64{100, 10, 35, 20},
+			expectedDecimal: ptr(decimal.NewFromFloat(27.5)),
+		},
+		{
+			name:         "trimmed mean",
+			method:       aggregators.AGGREGATION_METHOD_TRIMMED_MEAN,
+			observations: []int64{100, 10, 30, 20, 70},
+			expected:     40,
+		},
+		{
+			name:         "weighted median with default weights",
+			method:       aggregators.AGGREGATION_METHOD_WEIGHTED_MEDIAN,
+			observations: []int64{100, 10, 30, 20, 70},
+			expected:     30,
+		},
+		{
+			name:         "weighted median with heavy low oracle",
+			method:       aggregators.AGGREGATION_METHOD_WEIGHTED_MEDIAN,
+			weights:      []int64{1, 5},
+			observations: []int64{100, 10, 30, 20, 70},
+			expected:     10,
+		},
+		{
+			name:         "weighted median with heavy high oracle",
+			method:       aggregators.AGGREGATION_METHOD_WEIGHTED_MEDIAN,
+			weights:      []int64{5},
+			observations: []int64{100, 10, 30, 20, 70},
+			expected:     100,
+		},
+		{
+			name:         "min with f+1 floor",
+			method:       aggregators.AGGREGATION_METHOD_MIN,
+			observations: []int64{100, 10, 30, 20, 70},
+			expected:     20,
+		},
+		{
+			name:         "max with f+1 floor",
+			method:       aggregators.AGGREGATION_METHOD_MAX,
+			observations: []int64{100, 10, 30, 20, 70},
+			expected:     70,
+		},
+	}
+
+	for _, nt := range numericTypes {
+		for _, tt := range cases {
+			t.Run(nt.name+" "+tt.name, func(t *testing.T) {
+				fields := []aggregators.AggregationField{
+					{
+						OutputKey: "Price",
+						Method:    tt.method,
+						Weights:   tt.weights,
+					},
+				}
+				config := getConfigReduceAggregator(t, fields, map[string]any{"reportFormat": "map"})
+				agg, err := aggregators.NewReduceAggregator(*config)
+				require.NoError(t, err)
+
+				observations := map[commontypes.OracleID][]values.Value{}
+				for i, o := range tt.observations {
+					v, err := values.Wrap(nt.wrap(o))
+					require.NoError(t, err)
+					observations[commontypes.OracleID(i)] = []values.Value{v}
+				}
+
+				outcome, err := agg.Aggregate(logger.Nop(), nil, observations, 1)
+				require.NoError(t, err)
+				require.True(t, outcome.ShouldReport)
+
+				val, err := values.FromMapValueProto(outcome.EncodableOutcome)
+				require.NoError(t, err)
+				topLevelMap, err := val.Unwrap()
+				require.NoError(t, err)
+
+				expected := nt.wrap(tt.expected)
+				if tt.expectedDecimal != nil {
+					expected = nt.fromDecimal(*tt.expectedDecimal)
+				}
+				require.Equal(t, map[string]any{"Reports": map[string]any{"Price": expected}}, topLevelMap)
+			})
+		}
+	}
+
+	t.Run("weighted median with zero total weight", func(t *testing.T) {
+		fields := []aggregators.AggregationField{
+			{
+				OutputKey: "Price",
+				Method:    aggregators.AGGREGATION_METHOD_WEIGHTED_MEDIAN,
+				Weights:   []int64{0, 0, 0},
+			},
+		}
+		config := getConfigReduceAggregator(t, fields, map[string]any{})
+		agg, err := aggregators.NewReduceAggregator(*config)
+		require.NoError(t, err)
+
+		mockValue, err := values.Wrap(int64(100))
+		require.NoError(t, err)
+		_, err = agg.Aggregate(logger.Nop(), nil, map[commontypes.OracleID][]values.Value{0: {mockValue}, 1: {mockValue}, 2: {mockValue}}, 1)
+		require.ErrorContains(t, err, "total weight")
+	})
+
+	t.Run("unsupported type", func(t *testing.T) {
+		fields := []aggregators.AggregationField{
+			{
+				OutputKey: "Price",
+				Method:    aggregators.AGGREGATION_METHOD_TRIMMED_MEAN,
+			},
+		}
+		config := getConfigReduceAggregator(t, fields, map[string]any{})
+		agg, err := aggregators.NewReduceAggregator(*config)
+		require.NoError(t, err)
+
+		mockValue, err := values.Wrap(true)
+		require.NoError(t, err)
+		_, err = agg.Aggregate(logger.Nop(), nil, map[commontypes.OracleID][]values.Value{0: {mockValue}, 1: {mockValue}, 2: {mockValue}}, 1)
+		require.Error(t, err)
+	})
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
+
+func TestReduceAggregator_Heartbeat(t *testing.T) {
+	fields := []aggregators.AggregationField{
+		{
+			InputKey:        "BenchmarkPrice",
+			OutputKey:       "Price",
+			Method:          "median",
+			DeviationString: "0.1",
+			DeviationType:   "percent",
+		},
+		{
+			InputKey:  "Timestamp",
+			OutputKey: "Timestamp",
+			Method:    "median",
+		},
+	}
+	config := getConfigReduceAggregator(t, fields, map[string]any{"heartbeat": 60})
+	agg, err := aggregators.NewReduceAggregator(*config)
+	require.NoError(t, err)
+	tagg, ok := agg.(types.TimestampedAggregator)
+	require.True(t, ok)
+
+	observations := func(price int64, ts int64) map[commontypes.OracleID][]values.Value {
+		mockValue, err := values.WrapMap(map[string]any{
+			"BenchmarkPrice": price,
+			"Timestamp":      ts,
+		})
+		require.NoError(t, err)
+		return map[commontypes.OracleID][]values.Value{1: {mockValue}, 2: {mockValue}, 3: {mockValue}}
+	}
+	state := func(outcome *types.AggregationOutcome) map[string]any {
+		pb := &pb.Map{}
+		require.NoError(t, proto.Unmarshal(outcome.Metadata, pb))
+		vmap, err := values.FromMapValueProto(pb)
+		require.NoError(t, err)
+		unwrapped, err := vmap.Unwrap()
+		require.NoError(t, err)
+		return unwrapped.(map[string]any)
+	}
+
+	start := time.Unix(1700000000, 0).UTC()
+
+	// first round always reports
+	outcome, err := tagg.AggregateAt(logger.Nop(), nil, observations(100, 1), 1, start)
+	require.NoError(t, err)
+	require.True(t, outcome.ShouldReport)
+	require.Equal(t, map[string]any{
+		"Price":                         int64(100),
+		"Timestamp":                     int64(1),
+		aggregators.HEARTBEAT_STATE_KEY: start,
+	}, state(outcome))
+
+	// no deviation and heartbeat has not elapsed
+	outcome, err = tagg.AggregateAt(logger.Nop(), outcome, observations(101, 2), 1, start.Add(59*time.Second))
+	require.NoError(t, err)
+	require.False(t, outcome.ShouldReport)
+
+	// no deviation, but heartbeat has elapsed, so every field is reported
+	outcome, err = tagg.AggregateAt(logger.Nop(), outcome, observations(102, 3), 1, start.Add(60*time.Second))
+	require.NoError(t, err)
+	require.True(t, outcome.ShouldReport)
+	require.Equal(t, map[string]any{
+		"Price":                         int64(102),
+		"Timestamp":                     int64(3),
+		aggregators.HEARTBEAT_STATE_KEY: start.Add(60 * time.Second),
+	}, state(outcome))
+
+	// the heartbeat restarts from the last report
+	outcome, err = tagg.AggregateAt(logger.Nop(), outcome, observations(102, 4), 1, start.Add(90*time.Second))
+	require.NoError(t, err)
+	require.False(t, outcome.ShouldReport)
+
+	// a deviation report also restarts the heartbeat
+	outcome, err = tagg.AggregateAt(logger.Nop(), outcome, observations(200, 5), 1, start.Add(100*time.Second))
+	require.NoError(t, err)
+	require.True(t, outcome.ShouldReport)
+	require.Equal(t, start.Add(100*time.Second), state(outcome)[aggregators.HEARTBEAT_STATE_KEY])
+
+	// without a timestamp the heartbeat is not checked
+	outcome, err = agg.Aggregate(logger.Nop(), outcome, observations(200, 6), 1)
+	require.NoError(t, err)
+	require.False(t, outcome.ShouldReport)
+	require.Equal(t, start.Add(100*time.Second), state(outcome)[aggregators.HEARTBEAT_STATE_KEY])
+}
+
 func TestInputChanges(t *testing.T) {
 	fields := []aggregators.AggregationField{
 		{
@@ -1190,6 +1448,73 @@ func TestMedianAggregator_ParseConfig(t *testing.T) {
 					return vMap
 				},
 			},
+			{
+				name: "weights without weighted median",
+				configFactory: func() *values.Map {
+					vMap, err := values.NewMap(map[string]any{
+						"fields": []aggregators.AggregationField{
+							{
+								InputKey:  "Price",
+								Method:    "median",
+								Weights:   []int64{1, 2, 3},
+								OutputKey: "Price",
+							},
+						},
+					})
+					require.NoError(t, err)
+					return vMap
+				},
+			},
+			{
+				name: "negative weight",
+				configFactory: func() *values.Map {
+					vMap, err := values.NewMap(map[string]any{
+						"fields": []aggregators.AggregationField{
+							{
+								InputKey:  "Price",
+								Method:    "weighted_median",
+								Weights:   []int64{1, -2, 3},
+								OutputKey: "Price",
+							},
+						},
+					})
+					require.NoError(t, err)
+					return vMap
+				},
+			},
+			{
+				name: "negative heartbeat",
+				configFactory: func() *values.Map {
+					vMap, err := values.NewMap(map[string]any{
+						"fields": []aggregators.AggregationField{
+							{
+								InputKey:  "Price",
+								Method:    "median",
+								OutputKey: "Price",
+							},
+						},
+						"heartbeat": -1,
+					})
+					require.NoError(t, err)
+					return vMap
+				},
+			},
+			{
+				name: "reserved output key",
+				configFactory: func() *values.Map {
+					vMap, err := values.NewMap(map[string]any{
+						"fields": []aggregators.AggregationField{
+							{
+								InputKey:  "Price",
+								Method:    "median",
+								OutputKey: aggregators.HEARTBEAT_STATE_KEY,
+							},
+						},
+					})
+					require.NoError(t, err)
+					return vMap
+				},
+			},
 		}
 
 		for _, tt := range cases {
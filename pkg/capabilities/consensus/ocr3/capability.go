@@ -3,6 +3,7 @@ package ocr3
 import (
 	"context"
 	"fmt"
+	"slices"
 	"sync"
 	"time"
 
@@ -45,8 +46,9 @@ type capability struct {
 	requestTimeout time.Duration
 	clock          clockwork.Clock
 
-	aggregatorFactory types.AggregatorFactory
-	aggregators       map[string]types.Aggregator
+	aggregatorFactory  types.AggregatorFactory
+	aggregatorRegistry *types.AggregatorRegistry
+	aggregators        map[string]types.Aggregator
 
 	encoderFactory types.EncoderFactory
 	encoders       map[string]types.Encoder
@@ -54,6 +56,7 @@ type capability struct {
 	callbackChannelBufferSize int
 
 	registeredWorkflowsIDs map[string]bool
+	workflowPriorities     map[string]requests.Priority
 	mu                     sync.RWMutex
 }
 
@@ -77,6 +80,7 @@ func newCapability(s *requests.Store, clock clockwork.Clock, requestTimeout time
 
 		callbackChannelBufferSize: callbackChannelBufferSize,
 		registeredWorkflowsIDs:    map[string]bool{},
+		workflowPriorities:        map[string]requests.Priority{},
 	}
 	return o
 }
@@ -111,6 +115,33 @@ func (o *capability) HealthReport() map[string]error {
 	return map[string]error{o.Name(): o.Healthy()}
 }
 
+// ValidateConfig validates config against the capability's schema, as well as the config schema
+// of the aggregator if it is a custom aggregator.
+func (o *capability) ValidateConfig(config *values.Map) (*config, error) {
+	c, err := o.Validator.ValidateConfig(config)
+	if err != nil {
+		return nil, err
+	}
+
+	if slices.Contains(types.BuiltinAggregationMethods, c.AggregationMethod) {
+		return c, nil
+	}
+
+	if o.aggregatorRegistry == nil || !o.aggregatorRegistry.Has(c.AggregationMethod) {
+		return nil, fmt.Errorf("unknown aggregation method %s", c.AggregationMethod)
+	}
+
+	if c.AggregationConfig == nil {
+		return nil, fmt.Errorf("aggregation method %s requires an aggregation config", c.AggregationMethod)
+	}
+
+	if err = o.aggregatorRegistry.ValidateConfig(c.AggregationMethod, *c.AggregationConfig); err != nil {
+		return nil, err
+	}
+
+	return c, nil
+}
+
 func (o *capability) RegisterToWorkflow(ctx context.Context, request capabilities.RegisterToWorkflowRequest) error {
 	c, err := o.ValidateConfig(request.Config)
 	if err != nil {
@@ -119,7 +150,11 @@ func (o *capability) RegisterToWorkflow(ctx context.Context, request capabilitie
 
 	o.mu.Lock()
 	defer o.mu.Unlock()
-	agg, err := o.aggregatorFactory(c.AggregationMethod, *c.AggregationConfig, o.lggr)
+	aggregatorFactory := o.aggregatorFactory
+	if o.aggregatorRegistry != nil {
+		aggregatorFactory = o.aggregatorRegistry.Factory(o.aggregatorFactory)
+	}
+	agg, err := aggregatorFactory(c.AggregationMethod, *c.AggregationConfig, o.lggr)
 	if err != nil {
 		return err
 	}
@@ -131,6 +166,11 @@ func (o *capability) RegisterToWorkflow(ctx context.Context, request capabilitie
 	}
 	o.encoders[request.Metadata.WorkflowID] = encoder
 	o.registeredWorkflowsIDs[request.Metadata.WorkflowID] = true
+	if c.Priority != "" {
+		o.workflowPriorities[request.Metadata.WorkflowID] = requests.Priority(c.Priority)
+	} else {
+		delete(o.workflowPriorities, request.Metadata.WorkflowID)
+	}
 	return nil
 }
 
@@ -156,6 +196,16 @@ func (o *capability) getEncoderByName(encoderName string, config *values.Map) (t
 	return o.encoderFactory(encoderName, config, o.lggr)
 }
 
+func (o *capability) getWorkflowPriority(workflowID string) requests.Priority {
+	o.mu.RLock()
+	defer o.mu.RUnlock()
+	priority, ok := o.workflowPriorities[workflowID]
+	if !ok {
+		return requests.PriorityNormal
+	}
+	return priority
+}
+
 func (o *capability) getRegisteredWorkflowsIDs() []string {
 	o.mu.RLock()
 	defer o.mu.RUnlock()
@@ -179,6 +229,7 @@ func (o *capability) UnregisterFromWorkflow(ctx context.Context, request capabil
 	delete(o.registeredWorkflowsIDs, request.Metadata.WorkflowID)
 	delete(o.aggregators, request.Metadata.WorkflowID)
 	delete(o.encoders, request.Metadata.WorkflowID)
+	delete(o.workflowPriorities, request.Metadata.WorkflowID)
 	return nil
 }
 
@@ -330,6 +330,79 @@ func TestOCR3Capability_Registration(t *testing.T) {
 	assert.ErrorContains(t, err, "no aggregator found for")
 }
 
+type customAggregator struct {
+	mockAggregator
+	threshold int
+}
+
+func TestOCR3Capability_RegistrationCustomAggregator(t *testing.T) {
+	n := time.Now()
+	fc := clockwork.NewFakeClockAt(n)
+	lggr := logger.Test(t)
+
+	ctx := tests.Context(t)
+	s := requests.NewStore()
+	cp := newCapability(s, fc, 1*time.Second, mockAggregatorFactory, mockEncoderFactory, lggr, 10)
+	cp.aggregatorRegistry = types.NewAggregatorRegistry()
+	require.NoError(t, cp.aggregatorRegistry.Register(types.CustomAggregator{
+		Name:         "custom",
+		ConfigSchema: `{"type": "object", "properties": {"threshold": {"type": "integer", "minimum": 1}}, "required": ["threshold"]}`,
+		Factory: func(config values.Map, _ logger.Logger) (types.Aggregator, error) {
+			agg := &customAggregator{}
+			return agg, config.Underlying["threshold"].UnwrapTo(&agg.threshold)
+		},
+	}))
+	require.NoError(t, cp.Start(ctx))
+
+	newConfig := func(method string, aggregationConfig map[string]any) *values.Map {
+		config, err := values.NewMap(map[string]any{
+			"aggregation_method": method,
+			"aggregation_config": aggregationConfig,
+			"encoder":            "",
+			"encoder_config":     map[string]any{},
+			"report_id":          "000f",
+			"key_id":             "evm",
+		})
+		require.NoError(t, err)
+		return config
+	}
+	register := func(config *values.Map) error {
+		return cp.RegisterToWorkflow(ctx, capabilities.RegisterToWorkflowRequest{
+			Metadata: capabilities.RegistrationMetadata{
+				WorkflowID: workflowTestID,
+			},
+			Config: config,
+		})
+	}
+
+	t.Run("custom aggregator", func(t *testing.T) {
+		require.NoError(t, register(newConfig("custom", map[string]any{"threshold": 3})))
+
+		agg, err := cp.getAggregator(workflowTestID)
+		require.NoError(t, err)
+		require.IsType(t, &customAggregator{}, agg)
+		assert.Equal(t, 3, agg.(*customAggregator).threshold)
+	})
+
+	t.Run("built-in aggregator", func(t *testing.T) {
+		require.NoError(t, register(newConfig("reduce", map[string]any{})))
+
+		agg, err := cp.getAggregator(workflowTestID)
+		require.NoError(t, err)
+		require.IsType(t, &mockAggregator{}, agg)
+	})
+
+	t.Run("invalid custom aggregator config", func(t *testing.T) {
+		err := register(newConfig("custom", map[string]any{"threshold": 0}))
+		assert.ErrorContains(t, err, "invalid config for aggregator custom")
+	})
+
+	t.Run("unknown aggregator", func(t *testing.T) {
+		err := register(newConfig("unknown", map[string]any{}))
+		assert.ErrorContains(t, err, "unknown aggregation method unknown")
+	})
+}
+
 func TestOCR3Capability_ValidateConfig(t *testing.T) {
 	n := time.Now()
 	fc := clockwork.NewFakeClockAt(n)
@@ -0,0 +1,50 @@
+package encoders
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/capabilities/consensus/ocr3/types"
+	"github.com/smartcontractkit/chainlink-common/pkg/codec/encodings"
+	"github.com/smartcontractkit/chainlink-common/pkg/codec/encodings/abi"
+)
+
+// NewABIEncoder creates an encoder for the parameters of an ABI type signature, see parseSignature.
+// Reports are the encoded metadata, followed by the fields of the input encoded like abi.encode(parameters...),
+// so they can be decoded in Solidity with abi.decode(report[109:], (parameter types...)).
+// Byte fields can be given as bytes or 0x prefixed hex strings.
+func NewABIEncoder(signature string) (types.Encoder, error) {
+	return newTypedEncoder(signature, abiCodec, abi.NewTuple)
+}
+
+func abiCodec(t *paramType) (encodings.TypeCodec, error) {
+	builder := abi.Builder()
+	switch t.base {
+	case typeBool:
+		return builder.Bool(), nil
+	case typeInt, typeUint:
+		return builder.BigInt(uint(t.size/8), t.base == typeInt)
+	case typeAddress:
+		return abi.Address(), nil
+	case typeFixedBytes:
+		return abi.FixedBytes(uint(t.size))
+	case typeBytes:
+		return abi.Bytes(math.MaxUint32), nil
+	case typeString:
+		return builder.String(math.MaxUint32)
+	case typeArray, typeSlice:
+		elem, err := abiCodec(t.elem)
+		if err != nil {
+			return nil, err
+		}
+
+		if t.base == typeSlice {
+			return abi.NewSlice(elem)
+		}
+		return abi.NewArray(t.size, elem)
+	case typeTuple:
+		return fieldsCodec(t.fields, abiCodec, abi.NewTuple)
+	default:
+		return nil, fmt.Errorf("unsupported type %s", t.base)
+	}
+}
@@ -0,0 +1,56 @@
+package encoders
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/capabilities/consensus/ocr3/types"
+	"github.com/smartcontractkit/chainlink-common/pkg/codec/encodings"
+	"github.com/smartcontractkit/chainlink-common/pkg/codec/encodings/borsh"
+)
+
+// NewBorshEncoder creates an encoder for the parameters of a type signature, see parseSignature, encoded with Borsh.
+// Reports are the encoded metadata, followed by the fields of the input encoded as a Borsh struct.
+// Integers must have 8, 16, 32, 64 or 128 bits, address and bytesN are fixed arrays of u8,
+// bytes and T[] are Vec<u8> and Vec<T>, and tuples are structs.
+// Byte fields can be given as bytes or 0x prefixed hex strings.
+func NewBorshEncoder(signature string) (types.Encoder, error) {
+	return newTypedEncoder(signature, borshCodec, encodings.NewStructCodec)
+}
+
+func borshCodec(t *paramType) (encodings.TypeCodec, error) {
+	builder := borsh.Builder()
+	switch t.base {
+	case typeBool:
+		return builder.Bool(), nil
+	case typeInt, typeUint:
+		switch t.size {
+		case 8, 16, 32, 64, 128:
+			return builder.BigInt(uint(t.size/8), t.base == typeInt)
+		default:
+			return nil, fmt.Errorf("borsh integers must have 8, 16, 32, 64 or 128 bits, got %d", t.size)
+		}
+	case typeAddress:
+		return encodings.NewArray(20, builder.Uint8())
+	case typeFixedBytes:
+		return encodings.NewArray(t.size, builder.Uint8())
+	case typeBytes:
+		return borsh.NewSlice(builder.Uint8())
+	case typeString:
+		return builder.String(math.MaxUint32)
+	case typeArray, typeSlice:
+		elem, err := borshCodec(t.elem)
+		if err != nil {
+			return nil, err
+		}
+
+		if t.base == typeSlice {
+			return borsh.NewSlice(elem)
+		}
+		return encodings.NewArray(t.size, elem)
+	case typeTuple:
+		return fieldsCodec(t.fields, borshCodec, encodings.NewStructCodec)
+	default:
+		return nil, fmt.Errorf("unsupported type %s", t.base)
+	}
+}
@@ -0,0 +1,212 @@
+package encoders_test
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/hex"
+	"math/big"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/capabilities/consensus/ocr3/encoders"
+	"github.com/smartcontractkit/chainlink-common/pkg/capabilities/consensus/ocr3/types"
+	"github.com/smartcontractkit/chainlink-common/pkg/logger"
+	"github.com/smartcontractkit/chainlink-common/pkg/utils/tests"
+	"github.com/smartcontractkit/chainlink-common/pkg/values"
+)
+
+var metadata = types.Metadata{
+	Version:          1,
+	ExecutionID:      strings.Repeat("11", 32),
+	Timestamp:        0x01020304,
+	DONID:            7,
+	DONConfigVersion: 3,
+	WorkflowID:       "0x" + strings.Repeat("22", 32),
+	WorkflowName:     "name",
+	WorkflowOwner:    strings.Repeat("33", 20),
+	ReportID:         "0001",
+}
+
+func reports(t *testing.T) []any {
+	price, ok := new(big.Int).SetString("123456789012345678901234567890", 10)
+	require.True(t, ok)
+
+	return []any{
+		map[string]any{"feedId": "0x" + strings.Repeat("aa", 32), "ts": int64(1700000000), "price": price},
+		map[string]any{"feedId": "0x" + strings.Repeat("bb", 32), "ts": int64(1700000001), "price": big.NewInt(-5)},
+	}
+}
+
+func newInput(t *testing.T, fields map[string]any) values.Map {
+	meta, err := values.Wrap(metadata)
+	require.NoError(t, err)
+
+	fields[types.MetadataFieldName] = meta
+	input, err := values.NewMap(fields)
+	require.NoError(t, err)
+	return *input
+}
+
+func newEncoder(t *testing.T, name string, config map[string]any) types.Encoder {
+	wrapped, err := values.NewMap(config)
+	require.NoError(t, err)
+
+	encoder, err := encoders.Factory(name, wrapped, logger.Test(t))
+	require.NoError(t, err)
+	return encoder
+}
+
+// readHexFixture reads a fixture of hex bytes, ignoring whitespace and lines starting with #.
+func readHexFixture(t *testing.T, name string) []byte {
+	f, err := os.Open("./testdata/fixtures/" + name)
+	require.NoError(t, err)
+	defer f.Close()
+
+	var raw strings.Builder
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		if line := strings.TrimSpace(scanner.Text()); !strings.HasPrefix(line, "#") {
+			raw.WriteString(line)
+		}
+	}
+	require.NoError(t, scanner.Err())
+
+	decoded, err := hex.DecodeString(raw.String())
+	require.NoError(t, err)
+	return decoded
+}
+
+func TestEncoders_Fixtures(t *testing.T) {
+	t.Parallel()
+
+	jsonFixture, err := os.ReadFile("./testdata/fixtures/json.json")
+	require.NoError(t, err)
+
+	for _, test := range []struct {
+		name     string
+		config   map[string]any
+		fields   map[string]any
+		expected []byte
+	}{
+		{
+			name:     encoders.NameABI,
+			config:   map[string]any{"abi": "(bytes32 feedId, uint32 ts, int192 price)[] reports"},
+			fields:   map[string]any{"reports": reports(t)},
+			expected: readHexFixture(t, "abi.hex"),
+		},
+		{
+			name:     encoders.NameBorsh,
+			config:   map[string]any{"schema": "(bytes32 feedId, uint32 ts, int128 price)[] reports"},
+			fields:   map[string]any{"reports": reports(t)},
+			expected: readHexFixture(t, "borsh.hex"),
+		},
+		{
+			name: encoders.NameJSON,
+			fields: map[string]any{
+				"reports": reports(t),
+				"at":      time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+				"bytes":   []byte{0xde, 0xad},
+				"flag":    true,
+				"note":    "<a & b>",
+				"ratio":   decimal.RequireFromString("1.50"),
+			},
+			expected: append(readHexFixture(t, "metadata.hex"), bytes.TrimSuffix(jsonFixture, []byte("\n"))...),
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			encoder := newEncoder(t, test.name, test.config)
+			encoded, err := encoder.Encode(tests.Context(t), newInput(t, test.fields))
+			require.NoError(t, err)
+			assert.Equal(t, hex.EncodeToString(test.expected), hex.EncodeToString(encoded))
+
+			decoded, _, err := types.DecodeMetadata(encoded)
+			require.NoError(t, err)
+			assert.Equal(t, metadata.ExecutionID, decoded.ExecutionID)
+			assert.Equal(t, "name      ", decoded.WorkflowName)
+		})
+	}
+}
+
+func TestEncoders_Errors(t *testing.T) {
+	t.Parallel()
+	abiConfig := map[string]any{"abi": "(bytes32 feedId, uint32 ts, int192 price)[] reports"}
+
+	t.Run("Encode returns an error if the input doesn't match the signature", func(t *testing.T) {
+		encoder := newEncoder(t, encoders.NameABI, abiConfig)
+		valid := reports(t)[0].(map[string]any)
+
+		for name, report := range map[string]map[string]any{
+			"missing field":   {"feedId": valid["feedId"], "ts": valid["ts"]},
+			"unknown field":   {"feedId": valid["feedId"], "ts": valid["ts"], "price": valid["price"], "other": 1},
+			"overflow":        {"feedId": valid["feedId"], "ts": int64(-1), "price": valid["price"]},
+			"wrong length":    {"feedId": "0xaa", "ts": valid["ts"], "price": valid["price"]},
+			"not an integer":  {"feedId": valid["feedId"], "ts": valid["ts"], "price": decimal.RequireFromString("1.5")},
+			"wrong bool type": {"feedId": valid["feedId"], "ts": true, "price": valid["price"]},
+		} {
+			t.Run(name, func(t *testing.T) {
+				_, err := encoder.Encode(tests.Context(t), newInput(t, map[string]any{"reports": []any{report}}))
+				assert.Error(t, err)
+			})
+		}
+	})
+
+	t.Run("Encode returns an error without metadata", func(t *testing.T) {
+		input, err := values.NewMap(map[string]any{"reports": reports(t)})
+		require.NoError(t, err)
+
+		for _, name := range []string{encoders.NameABI, encoders.NameJSON} {
+			_, err = newEncoder(t, name, abiConfig).Encode(tests.Context(t), *input)
+			assert.Error(t, err)
+		}
+	})
+
+	t.Run("Factory returns an error for invalid configs", func(t *testing.T) {
+		for _, test := range []struct {
+			name   string
+			config map[string]any
+		}{
+			{name: "unknown"},
+			{name: encoders.NameABI},
+			{name: encoders.NameBorsh},
+			{name: encoders.NameABI, config: map[string]any{"abi": 1}},
+			{name: encoders.NameBorsh, config: map[string]any{"schema": "int192 price"}},
+			{name: encoders.NameABI, config: map[string]any{"abi": "uint7 a"}},
+			{name: encoders.NameABI, config: map[string]any{"abi": "bytes33 a"}},
+			{name: encoders.NameABI, config: map[string]any{"abi": "float a"}},
+			{name: encoders.NameABI, config: map[string]any{"abi": "uint32"}},
+			{name: encoders.NameABI, config: map[string]any{"abi": "uint32 a, bool A"}},
+			{name: encoders.NameABI, config: map[string]any{"abi": "(uint32 a bool b"}},
+			{name: encoders.NameABI, config: map[string]any{"abi": "uint32[0] a"}},
+			{name: encoders.NameABI, config: map[string]any{"abi": "uint32[ a"}},
+			{name: encoders.NameABI, config: map[string]any{"abi": "uint32 a)"}},
+			{name: encoders.NameABI, config: map[string]any{"abi": "uint32 _a"}},
+		} {
+			config, err := values.NewMap(test.config)
+			require.NoError(t, err)
+
+			_, err = encoders.Factory(test.name, config, logger.Test(t))
+			assert.Error(t, err, "%s %v", test.name, test.config)
+		}
+	})
+
+	t.Run("Factory supports nested types", func(t *testing.T) {
+		encoder := newEncoder(t, encoders.NameABI, map[string]any{
+			"abi": "(address owner, bytes data, string name, (bool ok, int[2] values) inner)[] items, uint8 n",
+		})
+
+		item := map[string]any{
+			"owner": "0x" + strings.Repeat("44", 20),
+			"data":  []byte{1, 2, 3},
+			"name":  "item",
+			"inner": map[string]any{"ok": true, "values": []any{int64(-1), int64(1)}},
+		}
+		_, err := encoder.Encode(tests.Context(t), newInput(t, map[string]any{"items": []any{item}, "n": int64(1)}))
+		require.NoError(t, err)
+	})
+}
@@ -0,0 +1,56 @@
+// Package encoders contains the built-in types.Encoder implementations of the OCR3 consensus capability.
+// Every encoder prefixes reports with the types.Metadata of the outcome, encoded by types.Metadata.Encode,
+// so consumers can read it the same way whichever encoder the workflow uses.
+package encoders
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/capabilities/consensus/ocr3/types"
+	"github.com/smartcontractkit/chainlink-common/pkg/logger"
+	"github.com/smartcontractkit/chainlink-common/pkg/values"
+)
+
+const (
+	// NameABI is the name of the encoder created by NewABIEncoder, configured with the type signature in "abi".
+	NameABI = "abi"
+	// NameBorsh is the name of the encoder created by NewBorshEncoder, configured with the type signature in "schema".
+	NameBorsh = "borsh"
+	// NameJSON is the name of the JSONEncoder, which has no config.
+	NameJSON = "json"
+)
+
+type signatureConfig struct {
+	ABI    string `mapstructure:"abi"`
+	Schema string `mapstructure:"schema"`
+}
+
+// Factory is a types.EncoderFactory for the encoders of this package.
+func Factory(name string, config *values.Map, _ logger.Logger) (types.Encoder, error) {
+	cfg := signatureConfig{}
+	if config != nil {
+		if err := config.UnwrapTo(&cfg); err != nil {
+			return nil, fmt.Errorf("invalid %s encoder config: %w", name, err)
+		}
+	}
+
+	switch name {
+	case NameABI:
+		if cfg.ABI == "" {
+			return nil, errors.New("abi encoder requires a type signature in abi")
+		}
+		return NewABIEncoder(cfg.ABI)
+	case NameBorsh:
+		if cfg.Schema == "" {
+			return nil, errors.New("borsh encoder requires a type signature in schema")
+		}
+		return NewBorshEncoder(cfg.Schema)
+	case NameJSON:
+		return JSONEncoder{}, nil
+	default:
+		return nil, fmt.Errorf("unknown encoder %s", name)
+	}
+}
+
+var _ types.EncoderFactory = Factory
@@ -0,0 +1,79 @@
+package encoders
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/capabilities/consensus/ocr3/types"
+	"github.com/smartcontractkit/chainlink-common/pkg/values"
+)
+
+// JSONEncoder encodes reports as the encoded metadata, followed by the fields of the input as canonical JSON:
+// objects have their keys sorted, there is no insignificant whitespace and HTML characters are not escaped.
+// Integers are encoded as JSON numbers with all their digits, decimals as strings to keep their precision,
+// bytes as 0x prefixed hex strings and times as RFC3339Nano strings.
+type JSONEncoder struct{}
+
+var _ types.Encoder = JSONEncoder{}
+
+func (JSONEncoder) Encode(_ context.Context, input values.Map) ([]byte, error) {
+	meta, fields, err := types.SplitMetadata(input)
+	if err != nil {
+		return nil, err
+	}
+
+	encoded, err := meta.Encode()
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode metadata: %w", err)
+	}
+
+	raw, err := fields.Unwrap()
+	if err != nil {
+		return nil, err
+	}
+
+	buf := bytes.NewBuffer(encoded)
+	encoder := json.NewEncoder(buf)
+	encoder.SetEscapeHTML(false)
+	if err = encoder.Encode(canonical(raw)); err != nil {
+		return nil, err
+	}
+
+	// Encode terminates the value with a newline
+	return bytes.TrimSuffix(buf.Bytes(), []byte("\n")), nil
+}
+
+// canonical converts unwrapped values to the values they are encoded as. Maps are sorted by json.Marshal.
+func canonical(value any) any {
+	switch v := value.(type) {
+	case map[string]any:
+		converted := make(map[string]any, len(v))
+		for key, elem := range v {
+			converted[key] = canonical(elem)
+		}
+		return converted
+	case []any:
+		converted := make([]any, len(v))
+		for i, elem := range v {
+			converted[i] = canonical(elem)
+		}
+		return converted
+	case []byte:
+		return "0x" + hex.EncodeToString(v)
+	case *big.Int:
+		return json.Number(v.String())
+	case decimal.Decimal:
+		return v.String()
+	case time.Time:
+		return v.UTC().Format(time.RFC3339Nano)
+	default:
+		return v
+	}
+}
@@ -0,0 +1,206 @@
+package encoders
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// param is a named parameter of a type signature, such as "uint32 timestamp".
+type param struct {
+	name string
+	tpe  *paramType
+}
+
+type baseType string
+
+const (
+	typeBool       baseType = "bool"
+	typeInt        baseType = "int"
+	typeUint       baseType = "uint"
+	typeAddress    baseType = "address"
+	typeFixedBytes baseType = "bytesN"
+	typeBytes      baseType = "bytes"
+	typeString     baseType = "string"
+	typeArray      baseType = "array"
+	typeSlice      baseType = "slice"
+	typeTuple      baseType = "tuple"
+)
+
+// paramType is a type of a signature, in the syntax of Solidity types.
+type paramType struct {
+	base baseType
+	// size is the number of bits of integers, the number of bytes of bytesN and the length of arrays.
+	size   int
+	elem   *paramType
+	fields []param
+}
+
+// parseSignature parses a comma separated list of named parameters, like the parameters of a Solidity function.
+// Types are elementary types such as uint32, int192, bool, address, bytes32, bytes and string, tuples of named
+// parameters in parentheses, and arrays of them with a fixed length like T[2] or a dynamic length like T[].
+// For example, "(bytes32 feedId, uint32 ts, int192 price)[] reports".
+func parseSignature(signature string) ([]param, error) {
+	p := &signatureParser{signature: signature}
+	params, err := p.params()
+	if err != nil {
+		return nil, err
+	}
+
+	if p.skipSpaces(); p.pos != len(p.signature) {
+		return nil, p.errorf("unexpected %q", p.signature[p.pos])
+	}
+	return params, nil
+}
+
+type signatureParser struct {
+	signature string
+	pos       int
+}
+
+func (p *signatureParser) params() ([]param, error) {
+	var params []param
+	names := map[string]bool{}
+	for {
+		tpe, err := p.paramType()
+		if err != nil {
+			return nil, err
+		}
+
+		p.skipSpaces()
+		name := p.identifier()
+		if name == "" {
+			return nil, p.errorf("expected parameter name")
+		}
+
+		// fields are matched to the keys of the encoded values case-insensitively
+		lower := strings.ToLower(name)
+		if names[lower] {
+			return nil, p.errorf("duplicate parameter name %s", name)
+		}
+		names[lower] = true
+		params = append(params, param{name: name, tpe: tpe})
+
+		if p.skipSpaces(); !p.consume(',') {
+			return params, nil
+		}
+	}
+}
+
+func (p *signatureParser) paramType() (*paramType, error) {
+	p.skipSpaces()
+
+	var tpe *paramType
+	if p.consume('(') {
+		fields, err := p.params()
+		if err != nil {
+			return nil, err
+		}
+
+		if p.skipSpaces(); !p.consume(')') {
+			return nil, p.errorf("expected )")
+		}
+		tpe = &paramType{base: typeTuple, fields: fields}
+	} else {
+		name := p.identifier()
+		if name == "" {
+			return nil, p.errorf("expected type")
+		}
+
+		var err error
+		if tpe, err = elementaryType(name); err != nil {
+			return nil, p.errorf("%s", err)
+		}
+	}
+
+	for p.consume('[') {
+		start := p.pos
+		for p.pos < len(p.signature) && p.signature[p.pos] >= '0' && p.signature[p.pos] <= '9' {
+			p.pos++
+		}
+		length := p.signature[start:p.pos]
+
+		if !p.consume(']') {
+			return nil, p.errorf("expected ]")
+		}
+
+		if length == "" {
+			tpe = &paramType{base: typeSlice, elem: tpe}
+			continue
+		}
+
+		n, err := strconv.Atoi(length)
+		if err != nil || n == 0 {
+			return nil, p.errorf("invalid array length %s", length)
+		}
+		tpe = &paramType{base: typeArray, size: n, elem: tpe}
+	}
+	return tpe, nil
+}
+
+func elementaryType(name string) (*paramType, error) {
+	switch name {
+	case "bool", "address", "bytes", "string":
+		return &paramType{base: baseType(name)}, nil
+	case "int", "uint":
+		return &paramType{base: baseType(name), size: 256}, nil
+	}
+
+	for _, prefix := range []baseType{typeUint, typeInt, typeBytes} {
+		if !strings.HasPrefix(name, string(prefix)) {
+			continue
+		}
+
+		size, err := strconv.Atoi(strings.TrimPrefix(name, string(prefix)))
+		if err != nil {
+			break
+		}
+
+		if prefix == typeBytes {
+			if size < 1 || size > 32 {
+				return nil, fmt.Errorf("bytesN must have between 1 and 32 bytes, got %s", name)
+			}
+			return &paramType{base: typeFixedBytes, size: size}, nil
+		}
+
+		if size < 8 || size > 256 || size%8 != 0 {
+			return nil, fmt.Errorf("integers must have a multiple of 8 bits between 8 and 256, got %s", name)
+		}
+		return &paramType{base: prefix, size: size}, nil
+	}
+
+	return nil, fmt.Errorf("unknown type %s", name)
+}
+
+// identifier parses a name starting with an ASCII letter, so it can be exported as the name of a struct field.
+func (p *signatureParser) identifier() string {
+	start := p.pos
+	for p.pos < len(p.signature) {
+		c := p.signature[p.pos]
+		isLetter := (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+		if !isLetter && (p.pos == start || (c != '_' && (c < '0' || c > '9'))) {
+			break
+		}
+		p.pos++
+	}
+	return p.signature[start:p.pos]
+}
+
+func (p *signatureParser) consume(c byte) bool {
+	if p.pos < len(p.signature) && p.signature[p.pos] == c {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+func (p *signatureParser) skipSpaces() {
+	for p.pos < len(p.signature) && unicode.IsSpace(rune(p.signature[p.pos])) {
+		p.pos++
+	}
+}
+
+func (p *signatureParser) errorf(format string, args ...any) error {
+	return fmt.Errorf("invalid type signature %q at position %d: %s", p.signature, p.pos, fmt.Sprintf(format, args...))
+}
@@ -0,0 +1,111 @@
+package encoders
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"reflect"
+	"strings"
+
+	"github.com/go-viper/mapstructure/v2"
+	"github.com/shopspring/decimal"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/capabilities/consensus/ocr3/types"
+	"github.com/smartcontractkit/chainlink-common/pkg/codec"
+	"github.com/smartcontractkit/chainlink-common/pkg/codec/encodings"
+	"github.com/smartcontractkit/chainlink-common/pkg/utils/hex"
+	"github.com/smartcontractkit/chainlink-common/pkg/values"
+)
+
+// typedEncoder encodes the fields of its input as the struct of its codec, built from a type signature.
+type typedEncoder struct {
+	codec encodings.TypeCodec
+}
+
+// newTypedEncoder builds the codecs of a type signature with toCodec, and encodes its parameters as a struct.
+func newTypedEncoder(
+	signature string,
+	toCodec func(*paramType) (encodings.TypeCodec, error),
+	toStruct func([]encodings.NamedTypeCodec) (encodings.TopLevelCodec, error),
+) (*typedEncoder, error) {
+	params, err := parseSignature(signature)
+	if err != nil {
+		return nil, err
+	}
+
+	c, err := fieldsCodec(params, toCodec, toStruct)
+	if err != nil {
+		return nil, err
+	}
+	return &typedEncoder{codec: c}, nil
+}
+
+func fieldsCodec(
+	params []param,
+	toCodec func(*paramType) (encodings.TypeCodec, error),
+	toStruct func([]encodings.NamedTypeCodec) (encodings.TopLevelCodec, error),
+) (encodings.TypeCodec, error) {
+	fields := make([]encodings.NamedTypeCodec, len(params))
+	for i, p := range params {
+		c, err := toCodec(p.tpe)
+		if err != nil {
+			return nil, fmt.Errorf("invalid type of %s: %w", p.name, err)
+		}
+		fields[i] = encodings.NamedTypeCodec{Name: strings.ToUpper(p.name[:1]) + p.name[1:], Codec: c}
+	}
+	return toStruct(fields)
+}
+
+// Encode encodes the metadata of the input followed by its other fields.
+func (e *typedEncoder) Encode(_ context.Context, input values.Map) ([]byte, error) {
+	meta, fields, err := types.SplitMetadata(input)
+	if err != nil {
+		return nil, err
+	}
+
+	encoded, err := meta.Encode()
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode metadata: %w", err)
+	}
+
+	raw, err := fields.Unwrap()
+	if err != nil {
+		return nil, err
+	}
+
+	item := reflect.New(e.codec.GetType().Elem())
+	decoder, err := mapstructure.NewDecoder(&mapstructure.DecoderConfig{
+		Result:      item.Interface(),
+		DecodeHook:  mapstructure.ComposeDecodeHookFunc(valueHook, codec.BigIntHook, codec.SliceToArrayVerifySizeHook),
+		ErrorUnused: true,
+		ErrorUnset:  true,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err = decoder.Decode(raw); err != nil {
+		return nil, fmt.Errorf("input does not match the type signature: %w", err)
+	}
+
+	return e.codec.Encode(item.Interface(), encoded)
+}
+
+// valueHook converts values that don't map onto the types of codecs directly,
+// 0x prefixed hex strings into bytes and whole decimals into *big.Int.
+func valueHook(_, to reflect.Type, data any) (any, error) {
+	switch v := data.(type) {
+	case string:
+		if (to.Kind() == reflect.Slice || to.Kind() == reflect.Array) && to.Elem().Kind() == reflect.Uint8 && hex.HasPrefix(v) {
+			return hex.DecodeString(v)
+		}
+	case decimal.Decimal:
+		if to == reflect.TypeOf(&big.Int{}) {
+			if !v.IsInteger() {
+				return nil, fmt.Errorf("%s is not an integer", v)
+			}
+			return v.BigInt(), nil
+		}
+	}
+	return data, nil
+}
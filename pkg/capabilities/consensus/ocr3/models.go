@@ -5,12 +5,14 @@ import (
 )
 
 type config struct {
-	AggregationMethod string      `mapstructure:"aggregation_method" json:"aggregation_method" jsonschema:"enum=data_feeds,enum=identical,enum=reduce"`
+	AggregationMethod string      `mapstructure:"aggregation_method" json:"aggregation_method"`
 	AggregationConfig *values.Map `mapstructure:"aggregation_config" json:"aggregation_config"`
 	Encoder           string      `mapstructure:"encoder" json:"encoder"`
 	EncoderConfig     *values.Map `mapstructure:"encoder_config" json:"encoder_config"`
 	ReportID          string      `mapstructure:"report_id" json:"report_id" jsonschema:"required,pattern=^[a-f0-9]{4}$"`
 	RequestTimeoutMS  int64       `mapstructure:"request_timeout_ms" json:"request_timeout_ms"`
+	// Priority is the scheduling class of the workflow's requests, defaults to normal
+	Priority string `mapstructure:"priority" json:"priority,omitempty" jsonschema:"enum=high,enum=normal,enum=low"`
 
 	KeyID string `mapstructure:"key_id" json:"key_id,omitempty" jsonschema:"required"`
 }
@@ -31,8 +31,12 @@ type Config struct {
 	OutcomePruningThreshold uint64
 	Logger                  logger.Logger
 	AggregatorFactory       types.AggregatorFactory
-	EncoderFactory          types.EncoderFactory
-	SendBufferSize          int
+	// AggregatorRegistry is optional, and holds custom aggregators in addition to those of the AggregatorFactory
+	AggregatorRegistry *types.AggregatorRegistry
+	EncoderFactory     types.EncoderFactory
+	SendBufferSize     int
+	// RequestStoreBackend is optional, and persists pending requests so they survive a restart
+	RequestStoreBackend requests.Backend
 
 	store      *requests.Store
 	capability *capability
@@ -69,12 +73,17 @@ func NewOCR3(config Config) *Capability {
 	}
 
 	if config.store == nil {
-		config.store = requests.NewStore()
+		if config.RequestStoreBackend != nil {
+			config.store = requests.NewPersistentStore(config.RequestStoreBackend, config.Logger)
+		} else {
+			config.store = requests.NewStore()
+		}
 	}
 
 	if config.capability == nil {
 		ci := newCapability(config.store, config.clock, *config.RequestTimeout, config.AggregatorFactory, config.EncoderFactory, config.Logger,
 			config.SendBufferSize)
+		ci.aggregatorRegistry = config.AggregatorRegistry
 		config.capability = ci
 	}
 
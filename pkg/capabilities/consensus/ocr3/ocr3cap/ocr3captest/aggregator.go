@@ -0,0 +1,112 @@
+package ocr3captest
+
+import (
+	"fmt"
+	"time"
+
+	ocrcommon "github.com/smartcontractkit/libocr/commontypes"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/capabilities/consensus/ocr3/types"
+	"github.com/smartcontractkit/chainlink-common/pkg/logger"
+	"github.com/smartcontractkit/chainlink-common/pkg/values"
+)
+
+// AggregatorHarness runs an aggregator through simulated OCR rounds, feeding the outcome of each round
+// into the next round as the previous outcome, the same way the OCR3 reporting plugin does.
+type AggregatorHarness struct {
+	Aggregator types.Aggregator
+	F          int
+	Logger     logger.Logger
+
+	// Timestamp is the consensus timestamp of the next round, used by a types.TimestampedAggregator
+	Timestamp time.Time
+	// RoundInterval is added to Timestamp after each round
+	RoundInterval time.Duration
+
+	previousOutcome *types.AggregationOutcome
+}
+
+// NewAggregatorHarness creates a harness for agg with a DON tolerating f faulty oracles.
+func NewAggregatorHarness(agg types.Aggregator, f int) *AggregatorHarness {
+	return &AggregatorHarness{
+		Aggregator:    agg,
+		F:             f,
+		Logger:        logger.Nop(),
+		Timestamp:     time.Unix(0, 0).UTC(),
+		RoundInterval: time.Second,
+	}
+}
+
+// N is the number of oracles in the simulated DON.
+func (h *AggregatorHarness) N() int {
+	return 3*h.F + 1
+}
+
+// Round runs a single round where oracle i observes observations[i].
+// Observations are wrapped with values.Wrap, and a nil observation simulates an oracle that did not observe.
+// The previous outcome is only replaced if the round succeeds, just as a failed aggregation is skipped by the reporting plugin.
+func (h *AggregatorHarness) Round(observations ...any) (*types.AggregationOutcome, error) {
+	if len(observations) > h.N() {
+		return nil, fmt.Errorf("too many observations for f=%d, have %d want at most %d", h.F, len(observations), h.N())
+	}
+
+	wrapped := map[ocrcommon.OracleID][]values.Value{}
+	for i, observation := range observations {
+		if observation == nil {
+			continue
+		}
+		v, err := values.Wrap(observation)
+		if err != nil {
+			return nil, fmt.Errorf("could not wrap observation of oracle %d: %w", i, err)
+		}
+		wrapped[ocrcommon.OracleID(i)] = []values.Value{v}
+	}
+
+	return h.RoundWithValues(wrapped)
+}
+
+// RoundWithValues runs a single round with observations given as they are passed to the aggregator.
+func (h *AggregatorHarness) RoundWithValues(observations map[ocrcommon.OracleID][]values.Value) (*types.AggregationOutcome, error) {
+	var outcome *types.AggregationOutcome
+	var err error
+	if tagg, ok := h.Aggregator.(types.TimestampedAggregator); ok {
+		outcome, err = tagg.AggregateAt(h.Logger, h.previousOutcome, observations, h.F, h.Timestamp)
+	} else {
+		outcome, err = h.Aggregator.Aggregate(h.Logger, h.previousOutcome, observations, h.F)
+	}
+	h.Timestamp = h.Timestamp.Add(h.RoundInterval)
+	if err != nil {
+		return nil, err
+	}
+
+	h.previousOutcome = outcome
+	return outcome, nil
+}
+
+// Rounds runs one round per element of rounds, see Round.
+// It stops at the first round that fails, returning the outcomes of the previous rounds.
+func (h *AggregatorHarness) Rounds(rounds ...[]any) ([]*types.AggregationOutcome, error) {
+	outcomes := make([]*types.AggregationOutcome, 0, len(rounds))
+	for i, round := range rounds {
+		outcome, err := h.Round(round...)
+		if err != nil {
+			return outcomes, fmt.Errorf("round %d failed: %w", i, err)
+		}
+		outcomes = append(outcomes, outcome)
+	}
+	return outcomes, nil
+}
+
+// PreviousOutcome returns the outcome of the last successful round, or nil if no round succeeded.
+func (h *AggregatorHarness) PreviousOutcome() *types.AggregationOutcome {
+	return h.previousOutcome
+}
+
+// DecodeOutcome unwraps the encodable outcome of an aggregation into to.
+func DecodeOutcome(outcome *types.AggregationOutcome, to any) error {
+	m, err := values.FromMapValueProto(outcome.EncodableOutcome)
+	if err != nil {
+		return err
+	}
+	return m.UnwrapTo(to)
+}
@@ -0,0 +1,73 @@
+package ocr3captest_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/capabilities/consensus/ocr3/aggregators"
+	"github.com/smartcontractkit/chainlink-common/pkg/capabilities/consensus/ocr3/ocr3cap/ocr3captest"
+	"github.com/smartcontractkit/chainlink-common/pkg/values"
+)
+
+func TestAggregatorHarness(t *testing.T) {
+	config, err := values.NewMap(map[string]any{
+		"fields": []aggregators.AggregationField{
+			{
+				OutputKey:       "Price",
+				Method:          aggregators.AGGREGATION_METHOD_MEDIAN,
+				DeviationString: "0.1",
+				DeviationType:   aggregators.DEVIATION_TYPE_PERCENT,
+			},
+		},
+		"reportFormat": aggregators.REPORT_FORMAT_MAP,
+		"heartbeat":    60,
+	})
+	require.NoError(t, err)
+	agg, err := aggregators.NewReduceAggregator(*config)
+	require.NoError(t, err)
+
+	h := ocr3captest.NewAggregatorHarness(agg, 1)
+	h.RoundInterval = 30 * time.Second
+	require.Equal(t, 4, h.N())
+
+	outcomes, err := h.Rounds(
+		[]any{int64(100), int64(100), int64(100), int64(100)},
+		// an oracle that does not observe is tolerated
+		[]any{int64(101), nil, int64(101), int64(101)},
+		// the heartbeat elapses
+		[]any{int64(102), int64(102), int64(102), int64(102)},
+		// a faulty oracle cannot move the median
+		[]any{int64(102), int64(102), int64(102), int64(1000)},
+	)
+	require.NoError(t, err)
+	require.Len(t, outcomes, 4)
+
+	shouldReport := make([]bool, len(outcomes))
+	for i, outcome := range outcomes {
+		shouldReport[i] = outcome.ShouldReport
+	}
+	assert.Equal(t, []bool{true, false, true, false}, shouldReport)
+
+	decoded := struct {
+		Reports struct {
+			Price int64
+		}
+	}{}
+	require.NoError(t, ocr3captest.DecodeOutcome(h.PreviousOutcome(), &decoded))
+	assert.Equal(t, int64(102), decoded.Reports.Price)
+
+	t.Run("not enough observations", func(t *testing.T) {
+		previous := h.PreviousOutcome()
+		_, err := h.Round(int64(100), int64(100))
+		require.ErrorContains(t, err, "not enough observations")
+		assert.Equal(t, previous, h.PreviousOutcome())
+	})
+
+	t.Run("too many observations", func(t *testing.T) {
+		_, err := h.Round(1, 2, 3, 4, 5)
+		require.ErrorContains(t, err, "too many observations")
+	})
+}
@@ -33,6 +33,7 @@ type capabilityIface interface {
 	getEncoderByWorkflowID(workflowID string) (pbtypes.Encoder, error)
 	getEncoderByName(encoderName string, config *values.Map) (pbtypes.Encoder, error)
 	getRegisteredWorkflowsIDs() []string
+	getWorkflowPriority(workflowID string) requests.Priority
 	unregisterWorkflowID(workflowID string)
 }
 
@@ -58,7 +59,7 @@ func newReportingPlugin(s *requests.Store, r capabilityIface, batchSize int, con
 }
 
 func (r *reportingPlugin) Query(ctx context.Context, outctx ocr3types.OutcomeContext) (types.Query, error) {
-	batch, err := r.s.FirstN(r.batchSize)
+	batch, err := r.s.FairN(r.batchSize, r.r.getWorkflowPriority)
 	if err != nil {
 		r.lggr.Errorw("could not retrieve batch", "error", err)
 		return nil, err
@@ -336,7 +337,12 @@ func (r *reportingPlugin) Outcome(ctx context.Context, outctx ocr3types.OutcomeC
 			continue
 		}
 
-		outcome, err2 := agg.Aggregate(lggr, workflowOutcome, obs, r.config.F)
+		var outcome *pbtypes.AggregationOutcome
+		if tagg, ok := agg.(pbtypes.TimestampedAggregator); ok {
+			outcome, err2 = tagg.AggregateAt(lggr, workflowOutcome, obs, r.config.F, finalTimestamp.AsTime())
+		} else {
+			outcome, err2 = agg.Aggregate(lggr, workflowOutcome, obs, r.config.F)
+		}
 		if err2 != nil {
 			lggr.Errorw("error aggregating outcome", "error", err2)
 			continue
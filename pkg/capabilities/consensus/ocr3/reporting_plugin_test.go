@@ -30,7 +30,7 @@ func TestReportingPlugin_Query_ErrorInQueueCall(t *testing.T) {
 	lggr := logger.Test(t)
 	s := requests.NewStore()
 	batchSize := 0
-	rp, err := newReportingPlugin(s, nil, batchSize, ocr3types.ReportingPluginConfig{}, defaultOutcomePruningThreshold, lggr)
+	rp, err := newReportingPlugin(s, &mockCapability{}, batchSize, ocr3types.ReportingPluginConfig{}, defaultOutcomePruningThreshold, lggr)
 	require.NoError(t, err)
 
 	outcomeCtx := ocr3types.OutcomeContext{
@@ -44,13 +44,13 @@ func TestReportingPlugin_Query(t *testing.T) {
 	ctx := tests.Context(t)
 	lggr := logger.Test(t)
 	s := requests.NewStore()
-	rp, err := newReportingPlugin(s, nil, defaultBatchSize, ocr3types.ReportingPluginConfig{}, defaultOutcomePruningThreshold, lggr)
+	rp, err := newReportingPlugin(s, &mockCapability{}, defaultBatchSize, ocr3types.ReportingPluginConfig{}, defaultOutcomePruningThreshold, lggr)
 	require.NoError(t, err)
 
 	eid := uuid.New().String()
 	wowner := uuid.New().String()
 
-	err = s.Add(&requests.Request{
+	err = s.Add(ctx, &requests.Request{
 		WorkflowID:          workflowTestID,
 		WorkflowExecutionID: eid,
 		WorkflowOwner:       wowner,
@@ -74,12 +74,58 @@ func TestReportingPlugin_Query(t *testing.T) {
 	assert.Equal(t, qry.Ids[0].WorkflowExecutionId, eid)
 }
 
+func TestReportingPlugin_Query_FairAcrossWorkflows(t *testing.T) {
+	ctx := tests.Context(t)
+	lggr := logger.Test(t)
+	s := requests.NewStore()
+	noisyID := workflowTestID
+	quietID := "quiet-workflow"
+	mcap := &mockCapability{
+		priorities: map[string]requests.Priority{quietID: requests.PriorityLow},
+	}
+	batchSize := 4
+	rp, err := newReportingPlugin(s, mcap, batchSize, ocr3types.ReportingPluginConfig{}, defaultOutcomePruningThreshold, lggr)
+	require.NoError(t, err)
+
+	for i := 0; i < 10; i++ {
+		err = s.Add(ctx, &requests.Request{
+			WorkflowID:          noisyID,
+			WorkflowExecutionID: uuid.New().String(),
+			ExpiresAt:           time.Now().Add(time.Hour),
+		})
+		require.NoError(t, err)
+	}
+	quietEid := uuid.New().String()
+	err = s.Add(ctx, &requests.Request{
+		WorkflowID:          quietID,
+		WorkflowExecutionID: quietEid,
+		ExpiresAt:           time.Now().Add(time.Hour),
+	})
+	require.NoError(t, err)
+
+	q, err := rp.Query(ctx, ocr3types.OutcomeContext{})
+	require.NoError(t, err)
+
+	qry := &pbtypes.Query{}
+	require.NoError(t, proto.Unmarshal(q, qry))
+	require.Len(t, qry.Ids, batchSize)
+
+	var quietIncluded bool
+	for _, id := range qry.Ids {
+		if id.WorkflowExecutionId == quietEid {
+			quietIncluded = true
+		}
+	}
+	assert.True(t, quietIncluded, "expected the quiet workflow to be scheduled")
+}
+
 type mockCapability struct {
 	t                   *testing.T
 	aggregator          pbtypes.Aggregator
 	encoder             *enc
 	registeredWorkflows map[string]bool
 	expectedEncoderName string
+	priorities          map[string]requests.Priority
 }
 
 type aggregator struct {
@@ -117,6 +163,16 @@ func (a *erroringAggregator) Aggregate(lggr logger.Logger, pout *pbtypes.Aggrega
 	return a.aggregator.Aggregate(lggr, pout, observations, i)
 }
 
+type timestampedAggregator struct {
+	aggregator
+	gotTimestamp time.Time
+}
+
+func (a *timestampedAggregator) AggregateAt(lggr logger.Logger, pout *pbtypes.AggregationOutcome, observations map[commontypes.OracleID][]values.Value, i int, timestamp time.Time) (*pbtypes.AggregationOutcome, error) {
+	a.gotTimestamp = timestamp
+	return a.aggregator.Aggregate(lggr, pout, observations, i)
+}
+
 type enc struct {
 	gotInput values.Map
 }
@@ -130,6 +186,14 @@ func (mc *mockCapability) getAggregator(workflowID string) (pbtypes.Aggregator,
 	return mc.aggregator, nil
 }
 
+func (mc *mockCapability) getWorkflowPriority(workflowID string) requests.Priority {
+	priority, ok := mc.priorities[workflowID]
+	if !ok {
+		return requests.PriorityNormal
+	}
+	return priority
+}
+
 func (mc *mockCapability) getEncoderByWorkflowID(workflowID string) (pbtypes.Encoder, error) {
 	return mc.encoder, nil
 }
@@ -171,7 +235,7 @@ func TestReportingPlugin_Observation(t *testing.T) {
 
 	eid := uuid.New().String()
 	wowner := uuid.New().String()
-	err = s.Add(&requests.Request{
+	err = s.Add(ctx, &requests.Request{
 		WorkflowID:          workflowTestID,
 		WorkflowExecutionID: eid,
 		WorkflowOwner:       wowner,
@@ -330,6 +394,53 @@ func TestReportingPlugin_Outcome(t *testing.T) {
 	assert.EqualExportedValues(t, opb.Outcomes[workflowTestID], aggregator.outcome)
 }
 
+func TestReportingPlugin_Outcome_TimestampedAggregator(t *testing.T) {
+	lggr := logger.Test(t)
+	s := requests.NewStore()
+	aggregator := &timestampedAggregator{}
+	mcap := &mockCapability{
+		aggregator: aggregator,
+		encoder:    &enc{},
+	}
+	rp, err := newReportingPlugin(s, mcap, defaultBatchSize, ocr3types.ReportingPluginConfig{}, defaultOutcomePruningThreshold, lggr)
+	require.NoError(t, err)
+
+	id := &pbtypes.Id{
+		WorkflowExecutionId: uuid.New().String(),
+		WorkflowId:          workflowTestID,
+		WorkflowOwner:       uuid.New().String(),
+		WorkflowName:        workflowTestName,
+		ReportId:            reportTestID,
+	}
+	qb, err := proto.Marshal(&pbtypes.Query{Ids: []*pbtypes.Id{id}})
+	require.NoError(t, err)
+	o, err := values.NewList([]any{"hello"})
+	require.NoError(t, err)
+	ts := time.Unix(1700000000, 0)
+	obs := &pbtypes.Observations{
+		Observations: []*pbtypes.Observation{
+			{
+				Id:           id,
+				Observations: values.Proto(o).GetListValue(),
+			},
+		},
+		Timestamp: timestamppb.New(ts),
+	}
+	rawObs, err := proto.Marshal(obs)
+	require.NoError(t, err)
+	aos := []types.AttributedObservation{
+		{
+			Observation: rawObs,
+			Observer:    commontypes.OracleID(1),
+		},
+	}
+
+	_, err = rp.Outcome(tests.Context(t), ocr3types.OutcomeContext{}, qb, aos)
+	require.NoError(t, err)
+
+	assert.True(t, ts.Equal(aggregator.gotTimestamp))
+}
+
 func TestReportingPlugin_Outcome_AggregatorErrorDoesntInterruptOtherWorkflows(t *testing.T) {
 	lggr := logger.Test(t)
 	s := requests.NewStore()
@@ -0,0 +1,159 @@
+package requests
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/sqlutil"
+	"github.com/smartcontractkit/chainlink-common/pkg/values"
+	"github.com/smartcontractkit/chainlink-common/pkg/values/pb"
+)
+
+// DBBackendSchema creates the table used by DBBackend.
+// It is compatible with both Postgres and the in-memory test database.
+const DBBackendSchema = `CREATE TABLE IF NOT EXISTS ocr3_capability_requests (
+	workflow_execution_id TEXT PRIMARY KEY,
+	workflow_id TEXT NOT NULL,
+	workflow_owner TEXT NOT NULL,
+	workflow_name TEXT NOT NULL,
+	workflow_don_id BIGINT NOT NULL,
+	workflow_don_config_version BIGINT NOT NULL,
+	report_id TEXT NOT NULL,
+	key_id TEXT NOT NULL,
+	observations BYTEA,
+	overridden_encoder_name TEXT NOT NULL,
+	overridden_encoder_config BYTEA,
+	expires_at TIMESTAMPTZ NOT NULL
+)`
+
+// DBBackend persists pending requests on top of a sqlutil.DataSource.
+// The callback and stop channels of a request are not persisted.
+type DBBackend struct {
+	ds sqlutil.DataSource
+}
+
+var _ Backend = (*DBBackend)(nil)
+
+func NewDBBackend(ds sqlutil.DataSource) *DBBackend {
+	return &DBBackend{ds: ds}
+}
+
+// EnsureSchema creates the requests table if it does not exist yet.
+func (b *DBBackend) EnsureSchema(ctx context.Context) error {
+	_, err := b.ds.ExecContext(ctx, DBBackendSchema)
+	return err
+}
+
+type dbRequest struct {
+	WorkflowExecutionID      string    `db:"workflow_execution_id"`
+	WorkflowID               string    `db:"workflow_id"`
+	WorkflowOwner            string    `db:"workflow_owner"`
+	WorkflowName             string    `db:"workflow_name"`
+	WorkflowDonID            int64     `db:"workflow_don_id"`
+	WorkflowDonConfigVersion int64     `db:"workflow_don_config_version"`
+	ReportID                 string    `db:"report_id"`
+	KeyID                    string    `db:"key_id"`
+	Observations             []byte    `db:"observations"`
+	OverriddenEncoderName    string    `db:"overridden_encoder_name"`
+	OverriddenEncoderConfig  []byte    `db:"overridden_encoder_config"`
+	ExpiresAt                time.Time `db:"expires_at"`
+}
+
+func (b *DBBackend) Add(ctx context.Context, req *Request) error {
+	var observations, encoderConfig []byte
+	var err error
+	if req.Observations != nil {
+		observations, err = proto.MarshalOptions{Deterministic: true}.Marshal(values.Proto(req.Observations).GetListValue())
+		if err != nil {
+			return fmt.Errorf("failed to marshal observations: %w", err)
+		}
+	}
+	if req.OverriddenEncoderConfig != nil {
+		encoderConfig, err = proto.MarshalOptions{Deterministic: true}.Marshal(values.ProtoMap(req.OverriddenEncoderConfig))
+		if err != nil {
+			return fmt.Errorf("failed to marshal overridden encoder config: %w", err)
+		}
+	}
+
+	_, err = b.ds.ExecContext(ctx, `INSERT INTO ocr3_capability_requests (
+	workflow_execution_id, workflow_id, workflow_owner, workflow_name, workflow_don_id, workflow_don_config_version,
+	report_id, key_id, observations, overridden_encoder_name, overridden_encoder_config, expires_at
+) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+ON CONFLICT (workflow_execution_id) DO UPDATE SET
+	workflow_id = EXCLUDED.workflow_id, workflow_owner = EXCLUDED.workflow_owner, workflow_name = EXCLUDED.workflow_name,
+	workflow_don_id = EXCLUDED.workflow_don_id, workflow_don_config_version = EXCLUDED.workflow_don_config_version,
+	report_id = EXCLUDED.report_id, key_id = EXCLUDED.key_id, observations = EXCLUDED.observations,
+	overridden_encoder_name = EXCLUDED.overridden_encoder_name, overridden_encoder_config = EXCLUDED.overridden_encoder_config,
+	expires_at = EXCLUDED.expires_at`,
+		req.WorkflowExecutionID, req.WorkflowID, req.WorkflowOwner, req.WorkflowName, int64(req.WorkflowDonID), int64(req.WorkflowDonConfigVersion),
+		req.ReportID, req.KeyID, observations, req.OverriddenEncoderName, encoderConfig, req.ExpiresAt.UTC())
+	if err != nil {
+		return fmt.Errorf("failed to upsert request %s: %w", req.WorkflowExecutionID, err)
+	}
+	return nil
+}
+
+func (b *DBBackend) Remove(ctx context.Context, requestID string) error {
+	_, err := b.ds.ExecContext(ctx, `DELETE FROM ocr3_capability_requests WHERE workflow_execution_id = $1`, requestID)
+	if err != nil {
+		return fmt.Errorf("failed to delete request %s: %w", requestID, err)
+	}
+	return nil
+}
+
+// Load returns every persisted request, ordered by expiry.
+// Since all requests share the same timeout, this approximates the order in which they were added.
+func (b *DBBackend) Load(ctx context.Context) ([]*Request, error) {
+	var rows []dbRequest
+	err := b.ds.SelectContext(ctx, &rows, `SELECT workflow_execution_id, workflow_id, workflow_owner, workflow_name,
+	workflow_don_id, workflow_don_config_version, report_id, key_id, observations, overridden_encoder_name,
+	overridden_encoder_config, expires_at
+FROM ocr3_capability_requests ORDER BY expires_at, workflow_execution_id`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load requests: %w", err)
+	}
+
+	reqs := make([]*Request, 0, len(rows))
+	for _, row := range rows {
+		req := &Request{
+			WorkflowExecutionID:      row.WorkflowExecutionID,
+			WorkflowID:               row.WorkflowID,
+			WorkflowOwner:            row.WorkflowOwner,
+			WorkflowName:             row.WorkflowName,
+			WorkflowDonID:            uint32(row.WorkflowDonID),            //nolint:gosec // stored from a uint32
+			WorkflowDonConfigVersion: uint32(row.WorkflowDonConfigVersion), //nolint:gosec // stored from a uint32
+			ReportID:                 row.ReportID,
+			KeyID:                    row.KeyID,
+			OverriddenEncoderName:    row.OverriddenEncoderName,
+			ExpiresAt:                row.ExpiresAt,
+		}
+
+		if len(row.Observations) > 0 {
+			l := &pb.List{}
+			if err = proto.Unmarshal(row.Observations, l); err != nil {
+				return nil, fmt.Errorf("failed to unmarshal observations of request %s: %w", row.WorkflowExecutionID, err)
+			}
+			req.Observations, err = values.FromListValueProto(l)
+			if err != nil {
+				return nil, fmt.Errorf("failed to decode observations of request %s: %w", row.WorkflowExecutionID, err)
+			}
+		}
+
+		if len(row.OverriddenEncoderConfig) > 0 {
+			m := &pb.Map{}
+			if err = proto.Unmarshal(row.OverriddenEncoderConfig, m); err != nil {
+				return nil, fmt.Errorf("failed to unmarshal overridden encoder config of request %s: %w", row.WorkflowExecutionID, err)
+			}
+			req.OverriddenEncoderConfig, err = values.FromMapValueProto(m)
+			if err != nil {
+				return nil, fmt.Errorf("failed to decode overridden encoder config of request %s: %w", row.WorkflowExecutionID, err)
+			}
+		}
+
+		reqs = append(reqs, req)
+	}
+	return reqs, nil
+}
@@ -0,0 +1,172 @@
+package requests_test
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/jonboulle/clockwork"
+	_ "github.com/marcboeker/go-duckdb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/capabilities/consensus/ocr3/requests"
+	"github.com/smartcontractkit/chainlink-common/pkg/logger"
+	"github.com/smartcontractkit/chainlink-common/pkg/services/servicetest"
+	"github.com/smartcontractkit/chainlink-common/pkg/sqlutil/pg"
+	"github.com/smartcontractkit/chainlink-common/pkg/utils/tests"
+	"github.com/smartcontractkit/chainlink-common/pkg/values"
+)
+
+func newDBBackend(t *testing.T) *requests.DBBackend {
+	// the in-memory txdb fallback creates its database file in the working directory
+	wd, err := os.Getwd()
+	require.NoError(t, err)
+	require.NoError(t, os.Chdir(t.TempDir()))
+	t.Cleanup(func() { assert.NoError(t, os.Chdir(wd)) })
+
+	b := requests.NewDBBackend(pg.NewTestDB(t, pg.TestURL(t)))
+	require.NoError(t, b.EnsureSchema(tests.Context(t)))
+	return b
+}
+
+func TestDBBackend(t *testing.T) {
+	ctx := tests.Context(t)
+	b := newDBBackend(t)
+
+	obs, err := values.NewList([]any{"hello", int64(1)})
+	require.NoError(t, err)
+	encoderConfig, err := values.NewMap(map[string]any{"abi": "(uint256 price) Reports"})
+	require.NoError(t, err)
+	expiresAt := time.Unix(1700000000, 0).UTC()
+	req := &requests.Request{
+		Observations:             obs,
+		OverriddenEncoderName:    "evm",
+		OverriddenEncoderConfig:  encoderConfig,
+		ExpiresAt:                expiresAt,
+		CallbackCh:               make(chan requests.Response),
+		WorkflowExecutionID:      "execution-1",
+		WorkflowID:               "workflow",
+		WorkflowOwner:            "owner",
+		WorkflowName:             "name",
+		WorkflowDonID:            1,
+		WorkflowDonConfigVersion: 2,
+		ReportID:                 "0001",
+		KeyID:                    "evm",
+	}
+	require.NoError(t, b.Add(ctx, req))
+	require.NoError(t, b.Add(ctx, &requests.Request{WorkflowExecutionID: "execution-0", ExpiresAt: expiresAt.Add(-time.Second)}))
+
+	loaded, err := b.Load(ctx)
+	require.NoError(t, err)
+	require.Len(t, loaded, 2)
+	assert.Equal(t, "execution-0", loaded[0].WorkflowExecutionID)
+
+	got := loaded[1]
+	assert.Nil(t, got.CallbackCh)
+	assert.True(t, expiresAt.Equal(got.ExpiresAt))
+	got.ExpiresAt = req.ExpiresAt
+	got.CallbackCh = req.CallbackCh
+	assert.Equal(t, req, got)
+
+	require.NoError(t, b.Remove(ctx, "execution-1"))
+	require.NoError(t, b.Remove(ctx, "execution-1"))
+	loaded, err = b.Load(ctx)
+	require.NoError(t, err)
+	require.Len(t, loaded, 1)
+	assert.Equal(t, "execution-0", loaded[0].WorkflowExecutionID)
+}
+
+func TestPersistentStore(t *testing.T) {
+	ctx := tests.Context(t)
+	b := newDBBackend(t)
+	n := time.Now()
+
+	s := requests.NewPersistentStore(b, logger.Test(t))
+	for i, id := range []string{"a", "b", "c"} {
+		err := s.Add(ctx, &requests.Request{WorkflowExecutionID: id, ExpiresAt: n.Add(time.Duration(i) * time.Second)})
+		require.NoError(t, err)
+	}
+	assert.Error(t, s.Add(ctx, &requests.Request{WorkflowExecutionID: "a"}))
+
+	// simulate a restart with a new store on the same backend
+	restarted := requests.NewPersistentStore(b, logger.Test(t))
+	loaded, err := restarted.Load(ctx)
+	require.NoError(t, err)
+	require.Len(t, loaded, 3)
+
+	got, err := restarted.FirstN(2)
+	require.NoError(t, err)
+	require.Len(t, got, 2)
+	assert.Equal(t, "a", got[0].WorkflowExecutionID)
+	assert.Equal(t, "b", got[1].WorkflowExecutionID)
+
+	got = restarted.GetByIDs([]string{"c", "missing"})
+	require.Len(t, got, 1)
+	assert.Equal(t, "c", got[0].WorkflowExecutionID)
+
+	// loading again does not duplicate requests
+	loaded, err = restarted.Load(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, loaded)
+}
+
+func Test_Handler_RestoresPersistedRequests(t *testing.T) {
+	ctx := tests.Context(t)
+	lggr := logger.Test(t)
+	b := newDBBackend(t)
+	clock := clockwork.NewFakeClockAt(time.Now())
+
+	s := requests.NewPersistentStore(b, lggr)
+	require.NoError(t, s.Add(ctx, &requests.Request{WorkflowExecutionID: "expiring", ExpiresAt: clock.Now().Add(time.Second)}))
+	require.NoError(t, s.Add(ctx, &requests.Request{WorkflowExecutionID: "resent", ExpiresAt: clock.Now().Add(time.Hour)}))
+
+	restarted := requests.NewPersistentStore(b, lggr)
+	h := requests.NewHandler(lggr, restarted, clock, time.Hour)
+	servicetest.Run(t, h)
+
+	got, err := restarted.FirstN(10)
+	require.NoError(t, err)
+	require.Len(t, got, 2)
+
+	// the requester of a restored request sends it again, which replaces the stored request, and receives the response
+	obs, err := values.NewList([]any{"resent"})
+	require.NoError(t, err)
+	expiresAt := clock.Now().Add(2 * time.Hour).UTC().Truncate(time.Microsecond)
+	responseCh := make(chan requests.Response, 1)
+	h.SendRequest(ctx, &requests.Request{
+		WorkflowExecutionID: "resent",
+		Observations:        obs,
+		CallbackCh:          responseCh,
+		ExpiresAt:           expiresAt,
+	})
+	require.Eventually(t, func() bool {
+		r := restarted.Get("resent")
+		return r != nil && r.ExpiresAt.Equal(expiresAt)
+	}, tests.WaitTimeout(t), 10*time.Millisecond)
+	assert.Equal(t, obs, restarted.Get("resent").Observations)
+
+	persisted, err := b.Load(ctx)
+	require.NoError(t, err)
+	require.Len(t, persisted, 2)
+	assert.Equal(t, "resent", persisted[1].WorkflowExecutionID)
+	assert.Equal(t, obs, persisted[1].Observations)
+	assert.True(t, expiresAt.Equal(persisted[1].ExpiresAt))
+
+	testVal, err := values.NewMap(map[string]any{"result": "testval"})
+	require.NoError(t, err)
+	h.SendResponse(ctx, requests.Response{WorkflowExecutionID: "resent", Value: testVal})
+	resp := <-responseCh
+	require.Equal(t, testVal, resp.Value)
+
+	// restored requests without a requester still expire
+	clock.Advance(2 * time.Second)
+	require.Eventually(t, func() bool {
+		got, err := restarted.FirstN(10)
+		return err == nil && len(got) == 0
+	}, tests.WaitTimeout(t), 10*time.Millisecond)
+
+	loaded, err := b.Load(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, loaded)
+}
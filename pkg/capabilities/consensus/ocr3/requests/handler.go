@@ -68,8 +68,20 @@ func (h *Handler) SendRequest(ctx context.Context, r *Request) {
 	}
 }
 
-func (h *Handler) Start(_ context.Context) error {
+func (h *Handler) Start(ctx context.Context) error {
 	return h.StartOnce("RequestHandler", func() error {
+		restored, err := h.store.Load(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to load persisted requests: %w", err)
+		}
+		// restored requests are tracked so that they still expire, but have no requester to respond to
+		for _, req := range restored {
+			h.pendingRequests[req.WorkflowExecutionID] = req
+		}
+		if len(restored) > 0 {
+			h.lggr.Infow("Restored persisted requests", "count", len(restored))
+		}
+
 		h.wg.Add(1)
 		go func() {
 			defer h.wg.Done()
@@ -116,12 +128,26 @@ func (h *Handler) worker(ctx context.Context) {
 				continue
 			}
 
-			if err := h.store.Add(req); err != nil {
+			if h.store.Get(req.WorkflowExecutionID) != nil {
+				// the request was restored after a restart, it keeps its place in the queue
+				// but takes the observations and expiry of the resent request, whose requester gets the response
+				h.lggr.Debugw("Request already in store", "workflowExecutionID", req.WorkflowExecutionID)
+				if err := h.store.Replace(ctx, req); err != nil {
+					h.lggr.Errorw("failed to replace request in store", "err", err)
+				}
+				continue
+			}
+
+			if err := h.store.Add(ctx, req); err != nil {
 				h.lggr.Errorw("failed to add request to store", "err", err)
 			}
 
 		case resp := <-h.responseCh:
-			req, wasPresent := h.store.evict(resp.WorkflowExecutionID)
+			req, wasPresent := h.store.evict(ctx, resp.WorkflowExecutionID)
+			if pending, ok := h.pendingRequests[resp.WorkflowExecutionID]; ok && wasPresent {
+				// prefer the pending request, as a restored request in the store has no callback channel
+				req = pending
+			}
 			if !wasPresent {
 				h.responseCache[resp.WorkflowExecutionID] = &responseCacheEntry{
 					response:  resp,
@@ -137,6 +163,12 @@ func (h *Handler) worker(ctx context.Context) {
 }
 
 func (h *Handler) sendResponse(ctx context.Context, req *Request, resp Response) {
+	if req.CallbackCh == nil {
+		h.lggr.Debugw("Dropping response for restored request without requester", "workflowExecutionID", req.WorkflowExecutionID)
+		delete(h.pendingRequests, req.WorkflowExecutionID)
+		return
+	}
+
 	select {
 	case <-ctx.Done():
 		return
@@ -155,7 +187,7 @@ func (h *Handler) expirePendingRequests(ctx context.Context) {
 				WorkflowExecutionID: req.WorkflowExecutionID,
 				Err:                 fmt.Errorf("timeout exceeded: could not process request before expiry %s", req.WorkflowExecutionID),
 			}
-			h.store.evict(req.WorkflowExecutionID)
+			h.store.evict(ctx, req.WorkflowExecutionID)
 			h.sendResponse(ctx, req, resp)
 		}
 	}
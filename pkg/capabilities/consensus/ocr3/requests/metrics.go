@@ -0,0 +1,17 @@
+package requests
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	promQueueDepth = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "ocr3_capability_request_queue_depth",
+			Help: "Number of pending consensus requests per workflow",
+		},
+		// the DON keeps the stores of the OCR3 capabilities of different DONs apart
+		[]string{"workflowDonID", "workflowID"},
+	)
+)
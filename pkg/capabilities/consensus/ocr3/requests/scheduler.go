@@ -0,0 +1,102 @@
+package requests
+
+import (
+	"errors"
+	"sort"
+)
+
+// Priority is the scheduling class of a workflow's requests.
+type Priority string
+
+const (
+	PriorityHigh   Priority = "high"
+	PriorityNormal Priority = "normal"
+	PriorityLow    Priority = "low"
+)
+
+// weight is the number of requests a workflow may place in a batch in each scheduling round
+func (p Priority) weight() int {
+	switch p {
+	case PriorityHigh:
+		return 4
+	case PriorityLow:
+		return 1
+	default:
+		return 2
+	}
+}
+
+// FairN returns up to `batchSize` requests, sharing the batch between workflows
+// so that a workflow with many pending requests cannot starve the others.
+//
+// The batch is filled in rounds. In each round, every workflow with pending requests
+// contributes up to as many requests as the weight of its priority, in FIFO order.
+// Within a round, workflows of a higher priority go first, and workflows of the same priority
+// are visited in the order of their oldest pending request, so a workflow that is left out
+// of a full batch moves up the order until it is scheduled.
+// A nil priorityOf treats every workflow as PriorityNormal.
+// The method deep-copies requests before returning them.
+func (s *Store) FairN(batchSize int, priorityOf func(workflowID string) Priority) ([]*Request, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if batchSize == 0 {
+		return nil, errors.New("batchsize cannot be 0")
+	}
+	if priorityOf == nil {
+		priorityOf = func(string) Priority { return PriorityNormal }
+	}
+
+	// requestIDs is in insertion order, so each queue is FIFO
+	// and workflowIDs is ordered by the age of the oldest request in each queue
+	queues := map[string][]*Request{}
+	workflowIDs := []string{}
+	for _, rid := range s.requestIDs {
+		r, ok := s.requests[rid]
+		if !ok {
+			continue
+		}
+		if _, ok := queues[r.WorkflowID]; !ok {
+			workflowIDs = append(workflowIDs, r.WorkflowID)
+		}
+		queues[r.WorkflowID] = append(queues[r.WorkflowID], r)
+	}
+
+	weights := make(map[string]int, len(workflowIDs))
+	for _, workflowID := range workflowIDs {
+		weights[workflowID] = priorityOf(workflowID).weight()
+	}
+	// within a round, higher priority workflows go first, ties keep the age order
+	sort.SliceStable(workflowIDs, func(i, j int) bool {
+		return weights[workflowIDs[i]] > weights[workflowIDs[j]]
+	})
+
+	got := []*Request{}
+	for len(got) < batchSize {
+		scheduled := false
+		for _, workflowID := range workflowIDs {
+			queue := queues[workflowID]
+			for n := 0; n < weights[workflowID] && len(queue) > 0 && len(got) < batchSize; n++ {
+				got = append(got, queue[0].Copy())
+				queue = queue[1:]
+				scheduled = true
+			}
+			queues[workflowID] = queue
+		}
+		if !scheduled {
+			break
+		}
+	}
+
+	return got, nil
+}
+
+// QueueDepths returns the number of pending requests of each workflow.
+func (s *Store) QueueDepths() map[string]int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	depths := make(map[string]int, len(s.depths))
+	for workflowID, depth := range s.depths {
+		depths[workflowID] = depth
+	}
+	return depths
+}
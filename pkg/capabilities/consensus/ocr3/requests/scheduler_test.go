@@ -0,0 +1,138 @@
+package requests
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/utils/tests"
+)
+
+func addRequests(t *testing.T, s *Store, workflowID string, n int) {
+	for i := 0; i < n; i++ {
+		err := s.Add(tests.Context(t), &Request{
+			WorkflowID:          workflowID,
+			WorkflowExecutionID: fmt.Sprintf("%s-%d", workflowID, i),
+			ExpiresAt:           time.Now().Add(time.Hour),
+		})
+		require.NoError(t, err)
+	}
+}
+
+func countByWorkflow(reqs []*Request) map[string]int {
+	counts := map[string]int{}
+	for _, r := range reqs {
+		counts[r.WorkflowID]++
+	}
+	return counts
+}
+
+func TestStore_FairN(t *testing.T) {
+	t.Run("zero batch size", func(t *testing.T) {
+		_, err := NewStore().FairN(0, nil)
+		assert.ErrorContains(t, err, "batchsize cannot be 0")
+	})
+
+	t.Run("low volume workflow is scheduled while a high volume workflow saturates the batch", func(t *testing.T) {
+		s := NewStore()
+		addRequests(t, s, "noisy", 100)
+		addRequests(t, s, "quiet", 1)
+
+		fifo, err := s.FirstN(10)
+		require.NoError(t, err)
+		assert.Equal(t, map[string]int{"noisy": 10}, countByWorkflow(fifo))
+
+		got, err := s.FairN(10, nil)
+		require.NoError(t, err)
+		require.Len(t, got, 10)
+		assert.Equal(t, map[string]int{"noisy": 9, "quiet": 1}, countByWorkflow(got))
+		// each workflow's requests are still in FIFO order
+		assert.Equal(t, "noisy-0", got[0].WorkflowExecutionID)
+		assert.Equal(t, "noisy-1", got[1].WorkflowExecutionID)
+	})
+
+	t.Run("workflows left out of a full batch are scheduled first next time", func(t *testing.T) {
+		s := NewStore()
+		for i := 0; i < 5; i++ {
+			addRequests(t, s, fmt.Sprintf("workflow-%d", i), 2)
+		}
+
+		got, err := s.FairN(4, nil)
+		require.NoError(t, err)
+		assert.Equal(t, map[string]int{"workflow-0": 2, "workflow-1": 2}, countByWorkflow(got))
+		for _, r := range got {
+			s.evict(tests.Context(t), r.WorkflowExecutionID)
+		}
+
+		got, err = s.FairN(4, nil)
+		require.NoError(t, err)
+		assert.Equal(t, map[string]int{"workflow-2": 2, "workflow-3": 2}, countByWorkflow(got))
+	})
+
+	t.Run("priorities weigh the share of the batch", func(t *testing.T) {
+		s := NewStore()
+		addRequests(t, s, "low", 20)
+		addRequests(t, s, "normal", 20)
+		addRequests(t, s, "high", 20)
+
+		priorities := map[string]Priority{"low": PriorityLow, "high": PriorityHigh}
+		got, err := s.FairN(14, func(workflowID string) Priority {
+			if p, ok := priorities[workflowID]; ok {
+				return p
+			}
+			return PriorityNormal
+		})
+		require.NoError(t, err)
+		assert.Equal(t, map[string]int{"high": 8, "normal": 4, "low": 2}, countByWorkflow(got))
+		assert.Equal(t, "high", got[0].WorkflowID)
+	})
+
+	t.Run("batch larger than queue", func(t *testing.T) {
+		s := NewStore()
+		addRequests(t, s, "a", 3)
+		addRequests(t, s, "b", 1)
+
+		got, err := s.FairN(100, nil)
+		require.NoError(t, err)
+		assert.Len(t, got, 4)
+	})
+}
+
+func TestStore_QueueDepths(t *testing.T) {
+	s := NewStore()
+	addRequests(t, s, "depth-a", 3)
+	addRequests(t, s, "depth-b", 1)
+	assert.Equal(t, map[string]int{"depth-a": 3, "depth-b": 1}, s.QueueDepths())
+	assert.InDelta(t, 3, testutil.ToFloat64(promQueueDepth.WithLabelValues("0", "depth-a")), 0)
+
+	s.evict(tests.Context(t), "depth-a-0")
+	s.evict(tests.Context(t), "depth-b-0")
+	assert.Equal(t, map[string]int{"depth-a": 2}, s.QueueDepths())
+	assert.InDelta(t, 2, testutil.ToFloat64(promQueueDepth.WithLabelValues("0", "depth-a")), 0)
+}
+
+func TestStore_QueueDepthsOfDifferentDONs(t *testing.T) {
+	ctx := tests.Context(t)
+	stores := map[uint32]*Store{1: NewStore(), 2: NewStore()}
+	for donID, s := range stores {
+		for i := 0; i < int(donID); i++ {
+			err := s.Add(ctx, &Request{
+				WorkflowID:          "shared",
+				WorkflowDonID:       donID,
+				WorkflowExecutionID: fmt.Sprintf("shared-%d-%d", donID, i),
+				ExpiresAt:           time.Now().Add(time.Hour),
+			})
+			require.NoError(t, err)
+		}
+	}
+	assert.InDelta(t, 1, testutil.ToFloat64(promQueueDepth.WithLabelValues("1", "shared")), 0)
+	assert.InDelta(t, 2, testutil.ToFloat64(promQueueDepth.WithLabelValues("2", "shared")), 0)
+
+	stores[1].evict(ctx, "shared-1-0")
+	assert.False(t, promQueueDepth.DeleteLabelValues("1", "shared"), "drained queues are removed from the gauge")
+	assert.InDelta(t, 2, testutil.ToFloat64(promQueueDepth.WithLabelValues("2", "shared")), 0)
+}
@@ -1,19 +1,43 @@
 package requests
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"strconv"
 	"sync"
+	"time"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/logger"
 )
 
+// defaultBackendTimeout bounds each call the Store makes to its Backend
+const defaultBackendTimeout = 5 * time.Second
+
+// Backend durably stores the requests held by a Store, so that pending requests survive a node restart.
+type Backend interface {
+	// Add stores req, replacing any stored request with the same ID
+	Add(ctx context.Context, req *Request) error
+	Remove(ctx context.Context, requestID string) error
+	// Load returns every stored request, in the order they should be queued
+	Load(ctx context.Context) ([]*Request, error)
+}
+
 // Store stores ongoing consensus requests in an
 // in-memory map.
 // Note: this object is intended to be thread-safe,
 // so any read requests should first deep-copy the returned
 // request object via request.Copy().
+// If a Backend is given, requests are also written through to it,
+// but are always read from memory.
 type Store struct {
 	requestIDs []string
 	requests   map[string]*Request
+	// depths is the number of requests per workflow
+	depths map[string]int
+
+	backend Backend
+	lggr    logger.Logger
 
 	mu sync.RWMutex
 }
@@ -22,7 +46,49 @@ func NewStore() *Store {
 	return &Store{
 		requestIDs: []string{},
 		requests:   map[string]*Request{},
+		depths:     map[string]int{},
+	}
+}
+
+// NewPersistentStore returns a Store that writes requests through to backend.
+// Persisted requests are restored by Load.
+// The Handler calls the backend inline on its single worker, so every call, bounded by defaultBackendTimeout,
+// delays the other requests and responses waiting on that worker.
+func NewPersistentStore(backend Backend, lggr logger.Logger) *Store {
+	s := NewStore()
+	s.backend = backend
+	s.lggr = logger.Named(lggr, "OCR3RequestStore")
+	return s
+}
+
+// Load restores the requests persisted in the backend, skipping those already in the store,
+// and returns copies of the restored requests.
+// Restored requests have no callback channel, as the original requester is gone.
+func (s *Store) Load(ctx context.Context) ([]*Request, error) {
+	if s.backend == nil {
+		return nil, nil
+	}
+
+	reqs, err := s.backend.Load(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	loaded := []*Request{}
+	for _, req := range reqs {
+		if _, ok := s.requests[req.WorkflowExecutionID]; ok {
+			continue
+		}
+		s.requestIDs = append(s.requestIDs, req.WorkflowExecutionID)
+		s.requests[req.WorkflowExecutionID] = req
+		s.incrementDepth(req)
+		loaded = append(loaded, req.Copy())
 	}
+
+	return loaded, nil
 }
 
 // GetByIDs is best-effort, doesn't return requests that are not in store
@@ -70,15 +136,61 @@ func (s *Store) FirstN(batchSize int) ([]*Request, error) {
 	return got, nil
 }
 
-func (s *Store) Add(req *Request) error {
+// Add queues req. If the Store has a Backend, req is persisted outside the lock
+// and removed again if that fails.
+func (s *Store) Add(ctx context.Context, req *Request) error {
 	s.mu.Lock()
-	defer s.mu.Unlock()
-
 	if _, ok := s.requests[req.WorkflowExecutionID]; ok {
+		s.mu.Unlock()
 		return fmt.Errorf("request with id %s already exists", req.WorkflowExecutionID)
 	}
 	s.requestIDs = append(s.requestIDs, req.WorkflowExecutionID)
 	s.requests[req.WorkflowExecutionID] = req
+	s.incrementDepth(req)
+	s.mu.Unlock()
+
+	if s.backend == nil {
+		return nil
+	}
+	ctx, cancel := context.WithTimeout(ctx, defaultBackendTimeout)
+	defer cancel()
+	if err := s.backend.Add(ctx, req); err != nil {
+		s.mu.Lock()
+		if s.requests[req.WorkflowExecutionID] == req {
+			s.remove(req.WorkflowExecutionID)
+		}
+		s.mu.Unlock()
+		return fmt.Errorf("failed to persist request with id %s: %w", req.WorkflowExecutionID, err)
+	}
+	return nil
+}
+
+// Replace swaps the queued request with the same ID for req, keeping its place in the queue.
+// If the Store has a Backend, the persisted request is replaced as well, and the previous request
+// is restored if that fails.
+func (s *Store) Replace(ctx context.Context, req *Request) error {
+	s.mu.Lock()
+	prev, ok := s.requests[req.WorkflowExecutionID]
+	if !ok {
+		s.mu.Unlock()
+		return fmt.Errorf("request with id %s does not exist", req.WorkflowExecutionID)
+	}
+	s.requests[req.WorkflowExecutionID] = req
+	s.mu.Unlock()
+
+	if s.backend == nil {
+		return nil
+	}
+	ctx, cancel := context.WithTimeout(ctx, defaultBackendTimeout)
+	defer cancel()
+	if err := s.backend.Add(ctx, req); err != nil {
+		s.mu.Lock()
+		if s.requests[req.WorkflowExecutionID] == req {
+			s.requests[req.WorkflowExecutionID] = prev
+		}
+		s.mu.Unlock()
+		return fmt.Errorf("failed to persist request with id %s: %w", req.WorkflowExecutionID, err)
+	}
 	return nil
 }
 
@@ -94,16 +206,32 @@ func (s *Store) Get(requestID string) *Request {
 	return nil
 }
 
-func (s *Store) evict(requestID string) (*Request, bool) {
+func (s *Store) evict(ctx context.Context, requestID string) (*Request, bool) {
 	s.mu.Lock()
-	defer s.mu.Unlock()
+	r, found := s.remove(requestID)
+	s.mu.Unlock()
 
+	if found && s.backend != nil {
+		ctx, cancel := context.WithTimeout(ctx, defaultBackendTimeout)
+		defer cancel()
+		if err := s.backend.Remove(ctx, requestID); err != nil {
+			// the request is gone from memory, so at worst it is restored and expired again after a restart
+			s.lggr.Errorw("failed to remove persisted request", "requestID", requestID, "err", err)
+		}
+	}
+
+	return r, found
+}
+
+// remove removes requestID from memory. s.mu must be held.
+func (s *Store) remove(requestID string) (*Request, bool) {
 	var found bool
 
 	r, ok := s.requests[requestID]
 	if ok {
 		found = true
 		delete(s.requests, requestID)
+		s.decrementDepth(r)
 	}
 
 	newRequestIDs := []string{}
@@ -116,5 +244,25 @@ func (s *Store) evict(requestID string) (*Request, bool) {
 	}
 
 	s.requestIDs = newRequestIDs
+
 	return r, found
 }
+
+func (s *Store) incrementDepth(req *Request) {
+	s.depths[req.WorkflowID]++
+	promQueueDepth.WithLabelValues(queueDepthLabels(req)...).Set(float64(s.depths[req.WorkflowID]))
+}
+
+func (s *Store) decrementDepth(req *Request) {
+	s.depths[req.WorkflowID]--
+	if s.depths[req.WorkflowID] <= 0 {
+		delete(s.depths, req.WorkflowID)
+		promQueueDepth.DeleteLabelValues(queueDepthLabels(req)...)
+		return
+	}
+	promQueueDepth.WithLabelValues(queueDepthLabels(req)...).Set(float64(s.depths[req.WorkflowID]))
+}
+
+func queueDepthLabels(req *Request) []string {
+	return []string{strconv.FormatUint(uint64(req.WorkflowDonID), 10), req.WorkflowID}
+}
@@ -2,6 +2,7 @@ package requests
 
 import (
 	"context"
+	"errors"
 	"testing"
 	"time"
 
@@ -9,6 +10,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/smartcontractkit/chainlink-common/pkg/logger"
 	"github.com/smartcontractkit/chainlink-common/pkg/utils/tests"
 	"github.com/smartcontractkit/chainlink-common/pkg/values"
 )
@@ -24,17 +26,17 @@ func TestOCR3Store(t *testing.T) {
 	}
 
 	t.Run("Add", func(t *testing.T) {
-		err := s.Add(req)
+		err := s.Add(tests.Context(t), req)
 		require.NoError(t, err)
 	})
 
 	t.Run("add duplicate", func(t *testing.T) {
-		err := s.Add(req)
+		err := s.Add(tests.Context(t), req)
 		require.Error(t, err)
 	})
 
 	t.Run("evict", func(t *testing.T) {
-		_, wasPresent := s.evict(rid)
+		_, wasPresent := s.evict(tests.Context(t), rid)
 		assert.True(t, wasPresent)
 		assert.Len(t, s.requests, 0)
 	})
@@ -52,7 +54,7 @@ func TestOCR3Store(t *testing.T) {
 
 	t.Run("firstN, batchSize larger than queue", func(t *testing.T) {
 		for i := 0; i < 10; i++ {
-			err := s.Add(&Request{WorkflowExecutionID: uuid.New().String(), ExpiresAt: n.Add(1 * time.Hour)})
+			err := s.Add(tests.Context(t), &Request{WorkflowExecutionID: uuid.New().String(), ExpiresAt: n.Add(1 * time.Hour)})
 			require.NoError(t, err)
 		}
 		items, err := s.FirstN(100)
@@ -62,7 +64,7 @@ func TestOCR3Store(t *testing.T) {
 
 	t.Run("getByIDs", func(t *testing.T) {
 		rid2 := uuid.New().String()
-		err := s.Add(req)
+		err := s.Add(tests.Context(t), req)
 		require.NoError(t, err)
 		reqs := s.GetByIDs([]string{rid, rid2})
 		require.Equal(t, 1, len(reqs))
@@ -76,7 +78,7 @@ func TestOCR3Store_ManagesStateConsistently(t *testing.T) {
 		WorkflowExecutionID: rid,
 	}
 
-	err := s.Add(req)
+	err := s.Add(tests.Context(t), req)
 	require.NoError(t, err)
 	assert.Len(t, s.requests, 1)
 	assert.Len(t, s.requestIDs, 1)
@@ -85,17 +87,32 @@ func TestOCR3Store_ManagesStateConsistently(t *testing.T) {
 	assert.Len(t, s.requests, 1)
 	assert.Len(t, s.requestIDs, 1)
 
-	_, ok := s.evict(rid)
+	_, ok := s.evict(tests.Context(t), rid)
 	assert.True(t, ok)
 	assert.Len(t, s.requests, 0)
 	assert.Len(t, s.requestIDs, 0)
 
-	err = s.Add(req)
+	err = s.Add(tests.Context(t), req)
 	require.NoError(t, err)
 	assert.Len(t, s.requests, 1)
 	assert.Len(t, s.requestIDs, 1)
 }
 
+type failingBackend struct{ Backend }
+
+func (failingBackend) Add(context.Context, *Request) error { return errors.New("backend unavailable") }
+
+func TestOCR3Store_RollsBackFailedAdd(t *testing.T) {
+	s := NewPersistentStore(failingBackend{}, logger.Test(t))
+	req := &Request{WorkflowExecutionID: uuid.New().String(), WorkflowID: "wid"}
+
+	require.ErrorContains(t, s.Add(tests.Context(t), req), "backend unavailable")
+	assert.Nil(t, s.Get(req.WorkflowExecutionID))
+	assert.Empty(t, s.requests)
+	assert.Empty(t, s.requestIDs)
+	assert.Empty(t, s.depths)
+}
+
 func TestOCR3Store_ReadRequestsCopy(t *testing.T) {
 	s := NewStore()
 	rid := uuid.New().String()
@@ -120,7 +137,7 @@ func TestOCR3Store_ReadRequestsCopy(t *testing.T) {
 		Observations: obs,
 	}
 
-	require.NoError(t, s.Add(req))
+	require.NoError(t, s.Add(tests.Context(t), req))
 
 	testCases := []struct {
 		name string
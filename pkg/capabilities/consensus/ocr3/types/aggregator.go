@@ -2,6 +2,7 @@ package types
 
 import (
 	"strings"
+	"time"
 
 	ocrcommon "github.com/smartcontractkit/libocr/commontypes"
 
@@ -39,6 +40,14 @@ type Aggregator interface {
 	Aggregate(lggr logger.Logger, previousOutcome *AggregationOutcome, observations map[ocrcommon.OracleID][]values.Value, f int) (*AggregationOutcome, error)
 }
 
+// TimestampedAggregator is an optional extension of Aggregator for aggregators that depend on time.
+// The reporting plugin calls AggregateAt instead of Aggregate, passing the timestamp agreed upon
+// in the Outcome() phase, so that time-based decisions are identical across nodes.
+type TimestampedAggregator interface {
+	Aggregator
+	AggregateAt(lggr logger.Logger, previousOutcome *AggregationOutcome, observations map[ocrcommon.OracleID][]values.Value, f int, timestamp time.Time) (*AggregationOutcome, error)
+}
+
 func AppendMetadata(outcome *AggregationOutcome, meta *Metadata) (*AggregationOutcome, error) {
 	meta.padWorkflowName()
 	metaWrapped, err := values.Wrap(meta)
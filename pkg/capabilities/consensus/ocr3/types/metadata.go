@@ -0,0 +1,112 @@
+package types
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/values"
+)
+
+// MetadataLen is the number of bytes Metadata is encoded into.
+const MetadataLen = 1 + 32 + 4 + 4 + 4 + 32 + 10 + 20 + 2 // 109
+
+// Encode encodes the metadata in the layout reports are prefixed with on-chain:
+// the version as 1 byte, the timestamp, DON ID and DON config version as 4 big-endian bytes each,
+// the hex fields decoded into the number of bytes documented on Metadata, and the workflow name padded to 10 bytes.
+func (m Metadata) Encode() ([]byte, error) {
+	if m.Version > math.MaxUint8 {
+		return nil, fmt.Errorf("version must be at most %d, got %d", math.MaxUint8, m.Version)
+	}
+
+	m.padWorkflowName()
+	if len(m.WorkflowName) != 10 {
+		return nil, fmt.Errorf("workflow name must be at most 10 bytes, got %q", m.WorkflowName)
+	}
+
+	encoded := make([]byte, 0, MetadataLen)
+	encoded = append(encoded, byte(m.Version))
+
+	var err error
+	if encoded, err = appendHex(encoded, "ExecutionID", m.ExecutionID, 32); err != nil {
+		return nil, err
+	}
+
+	encoded = binary.BigEndian.AppendUint32(encoded, m.Timestamp)
+	encoded = binary.BigEndian.AppendUint32(encoded, m.DONID)
+	encoded = binary.BigEndian.AppendUint32(encoded, m.DONConfigVersion)
+
+	if encoded, err = appendHex(encoded, "WorkflowID", m.WorkflowID, 32); err != nil {
+		return nil, err
+	}
+
+	encoded = append(encoded, m.WorkflowName...)
+
+	if encoded, err = appendHex(encoded, "WorkflowOwner", m.WorkflowOwner, 20); err != nil {
+		return nil, err
+	}
+
+	return appendHex(encoded, "ReportID", m.ReportID, 2)
+}
+
+// DecodeMetadata decodes Metadata encoded by Metadata.Encode from the start of a report, returning the rest of it.
+// Hex fields are decoded without a 0x prefix.
+func DecodeMetadata(report []byte) (Metadata, []byte, error) {
+	if len(report) < MetadataLen {
+		return Metadata{}, nil, fmt.Errorf("report must have at least %d bytes of metadata, got %d", MetadataLen, len(report))
+	}
+
+	next := func(n int) []byte {
+		field := report[:n]
+		report = report[n:]
+		return field
+	}
+
+	m := Metadata{
+		Version:          uint32(next(1)[0]),
+		ExecutionID:      hex.EncodeToString(next(32)),
+		Timestamp:        binary.BigEndian.Uint32(next(4)),
+		DONID:            binary.BigEndian.Uint32(next(4)),
+		DONConfigVersion: binary.BigEndian.Uint32(next(4)),
+		WorkflowID:       hex.EncodeToString(next(32)),
+		WorkflowName:     string(next(10)),
+		WorkflowOwner:    hex.EncodeToString(next(20)),
+		ReportID:         hex.EncodeToString(next(2)),
+	}
+	return m, report, nil
+}
+
+// SplitMetadata returns the Metadata added to the outcome by AppendMetadata and the other fields of an encoder's input.
+func SplitMetadata(input values.Map) (Metadata, *values.Map, error) {
+	wrapped, ok := input.Underlying[MetadataFieldName]
+	if !ok {
+		return Metadata{}, nil, fmt.Errorf("expected metadata field to be present: %s not found", MetadataFieldName)
+	}
+
+	var m Metadata
+	if err := wrapped.UnwrapTo(&m); err != nil {
+		return Metadata{}, nil, fmt.Errorf("failed to unwrap metadata: %w", err)
+	}
+
+	fields := make(map[string]values.Value, len(input.Underlying)-1)
+	for name, value := range input.Underlying {
+		if name != MetadataFieldName {
+			fields[name] = value
+		}
+	}
+	return m, &values.Map{Underlying: fields}, nil
+}
+
+func appendHex(into []byte, name, field string, n int) ([]byte, error) {
+	decoded, err := hex.DecodeString(strings.TrimPrefix(field, "0x"))
+	if err != nil {
+		return nil, fmt.Errorf("%s must be hex encoded: %w", name, err)
+	}
+
+	if len(decoded) != n {
+		return nil, fmt.Errorf("%s must be %d bytes, got %d", name, n, len(decoded))
+	}
+	return append(into, decoded...), nil
+}
@@ -0,0 +1,84 @@
+package types
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/values"
+)
+
+func testMetadata() Metadata {
+	return Metadata{
+		Version:          1,
+		ExecutionID:      strings.Repeat("11", 32),
+		Timestamp:        1700000000,
+		DONID:            7,
+		DONConfigVersion: 3,
+		WorkflowID:       "0x" + strings.Repeat("22", 32),
+		WorkflowName:     "name",
+		WorkflowOwner:    strings.Repeat("33", 20),
+		ReportID:         "0001",
+	}
+}
+
+func TestMetadata_Encode(t *testing.T) {
+	m := testMetadata()
+	encoded, err := m.Encode()
+	require.NoError(t, err)
+	require.Len(t, encoded, MetadataLen)
+
+	decoded, rest, err := DecodeMetadata(append(encoded, 0xff))
+	require.NoError(t, err)
+	assert.Equal(t, []byte{0xff}, rest)
+
+	m.WorkflowID = strings.TrimPrefix(m.WorkflowID, "0x")
+	m.WorkflowName = "name      "
+	assert.Equal(t, m, decoded)
+
+	_, _, err = DecodeMetadata(encoded[:MetadataLen-1])
+	assert.Error(t, err)
+}
+
+func TestMetadata_Encode_Errors(t *testing.T) {
+	for name, modify := range map[string]func(*Metadata){
+		"version too large":   func(m *Metadata) { m.Version = 256 },
+		"name too long":       func(m *Metadata) { m.WorkflowName = "12345678901" },
+		"invalid hex":         func(m *Metadata) { m.ExecutionID = "zz" },
+		"wrong owner length":  func(m *Metadata) { m.WorkflowOwner = "33" },
+		"wrong report length": func(m *Metadata) { m.ReportID = "000001" },
+	} {
+		t.Run(name, func(t *testing.T) {
+			m := testMetadata()
+			modify(&m)
+			_, err := m.Encode()
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestSplitMetadata(t *testing.T) {
+	wrapped, err := values.Wrap(testMetadata())
+	require.NoError(t, err)
+
+	input, err := values.NewMap(map[string]any{MetadataFieldName: wrapped, "price": int64(1)})
+	require.NoError(t, err)
+
+	m, fields, err := SplitMetadata(*input)
+	require.NoError(t, err)
+	assert.Equal(t, testMetadata(), m)
+	assert.Equal(t, []string{"price"}, keys(fields.Underlying))
+
+	_, _, err = SplitMetadata(*fields)
+	assert.Error(t, err)
+}
+
+func keys(m map[string]values.Value) []string {
+	var ks []string
+	for k := range m {
+		ks = append(ks, k)
+	}
+	return ks
+}
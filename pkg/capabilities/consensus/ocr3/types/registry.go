@@ -0,0 +1,172 @@
+package types
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"sync"
+
+	jsonvalidate "github.com/santhosh-tekuri/jsonschema/v5"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/logger"
+	"github.com/smartcontractkit/chainlink-common/pkg/values"
+)
+
+// BuiltinAggregationMethods are the aggregation methods built into the OCR3 capability.
+// They are reserved in every AggregatorRegistry.
+var BuiltinAggregationMethods = []string{"data_feeds", "identical", "reduce"}
+
+// CustomAggregator describes an aggregator that is not built into the OCR3 capability.
+type CustomAggregator struct {
+	// Name is the aggregation_method workflows use to select the aggregator.
+	Name string
+	// ConfigSchema is an optional JSON schema that the aggregation_config is validated against
+	// when a workflow registers with the capability.
+	ConfigSchema string
+	// Factory creates a new aggregator for a workflow from its aggregation_config.
+	Factory func(config values.Map, lggr logger.Logger) (Aggregator, error)
+}
+
+type registeredAggregator struct {
+	CustomAggregator
+	schema *jsonvalidate.Schema
+}
+
+// AggregatorRegistry holds the custom aggregators available to the OCR3 capability.
+// Aggregators are expected to be registered at node startup, before any workflow is registered.
+type AggregatorRegistry struct {
+	mu          sync.RWMutex
+	reserved    map[string]bool
+	aggregators map[string]registeredAggregator
+}
+
+// NewAggregatorRegistry creates an empty registry.
+// The BuiltinAggregationMethods and any other reserved names cannot be registered.
+func NewAggregatorRegistry(reserved ...string) *AggregatorRegistry {
+	r := &AggregatorRegistry{
+		reserved:    map[string]bool{},
+		aggregators: map[string]registeredAggregator{},
+	}
+	for _, name := range slices.Concat(BuiltinAggregationMethods, reserved) {
+		r.reserved[name] = true
+	}
+	return r
+}
+
+// Register adds a custom aggregator to the registry.
+func (r *AggregatorRegistry) Register(agg CustomAggregator) error {
+	if agg.Name == "" {
+		return errors.New("custom aggregator must have a name")
+	}
+	if agg.Factory == nil {
+		return fmt.Errorf("custom aggregator %s must have a factory", agg.Name)
+	}
+
+	registered := registeredAggregator{CustomAggregator: agg}
+	if agg.ConfigSchema != "" {
+		schema, err := jsonvalidate.CompileString(agg.Name, agg.ConfigSchema)
+		if err != nil {
+			return fmt.Errorf("invalid config schema for custom aggregator %s: %w", agg.Name, err)
+		}
+		registered.schema = schema
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.reserved[agg.Name] {
+		return fmt.Errorf("aggregator name %s is reserved", agg.Name)
+	}
+	if _, ok := r.aggregators[agg.Name]; ok {
+		return fmt.Errorf("aggregator %s is already registered", agg.Name)
+	}
+	r.aggregators[agg.Name] = registered
+	return nil
+}
+
+// Has returns true if a custom aggregator is registered under name.
+func (r *AggregatorRegistry) Has(name string) bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	_, ok := r.aggregators[name]
+	return ok
+}
+
+// Names returns the sorted names of all registered custom aggregators.
+func (r *AggregatorRegistry) Names() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	names := make([]string, 0, len(r.aggregators))
+	for name := range r.aggregators {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ValidateConfig validates config against the config schema of the named aggregator, if it has one.
+func (r *AggregatorRegistry) ValidateConfig(name string, config values.Map) error {
+	agg, err := r.get(name)
+	if err != nil {
+		return err
+	}
+	if agg.schema == nil {
+		return nil
+	}
+
+	unwrapped, err := config.Unwrap()
+	if err != nil {
+		return err
+	}
+	// round trip through JSON so the validator only sees JSON types
+	jsonValue, err := json.Marshal(unwrapped)
+	if err != nil {
+		return err
+	}
+	var jsonRaw any
+	if err = json.Unmarshal(jsonValue, &jsonRaw); err != nil {
+		return err
+	}
+
+	if err = agg.schema.Validate(jsonRaw); err != nil {
+		return fmt.Errorf("invalid config for aggregator %s: %w", name, err)
+	}
+	return nil
+}
+
+// NewAggregator validates config and creates a new instance of the named aggregator.
+func (r *AggregatorRegistry) NewAggregator(name string, config values.Map, lggr logger.Logger) (Aggregator, error) {
+	if err := r.ValidateConfig(name, config); err != nil {
+		return nil, err
+	}
+	agg, err := r.get(name)
+	if err != nil {
+		return nil, err
+	}
+	return agg.Factory(config, lggr)
+}
+
+// Factory returns an AggregatorFactory that creates registered custom aggregators,
+// deferring to fallback for every other name.
+func (r *AggregatorRegistry) Factory(fallback AggregatorFactory) AggregatorFactory {
+	return func(name string, config values.Map, lggr logger.Logger) (Aggregator, error) {
+		if r.Has(name) {
+			return r.NewAggregator(name, config, lggr)
+		}
+		if fallback == nil {
+			return nil, fmt.Errorf("unknown aggregator %s", name)
+		}
+		return fallback(name, config, lggr)
+	}
+}
+
+func (r *AggregatorRegistry) get(name string) (registeredAggregator, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	agg, ok := r.aggregators[name]
+	if !ok {
+		return registeredAggregator{}, fmt.Errorf("no custom aggregator registered for %s", name)
+	}
+	return agg, nil
+}
@@ -0,0 +1,93 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	ocrcommon "github.com/smartcontractkit/libocr/commontypes"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/logger"
+	"github.com/smartcontractkit/chainlink-common/pkg/values"
+)
+
+type nopAggregator struct {
+	config values.Map
+}
+
+func (a *nopAggregator) Aggregate(_ logger.Logger, _ *AggregationOutcome, _ map[ocrcommon.OracleID][]values.Value, _ int) (*AggregationOutcome, error) {
+	return &AggregationOutcome{}, nil
+}
+
+func newNopAggregator(config values.Map, _ logger.Logger) (Aggregator, error) {
+	return &nopAggregator{config: config}, nil
+}
+
+const thresholdSchema = `{
+	"type": "object",
+	"properties": {
+		"threshold": {"type": "integer", "minimum": 1}
+	},
+	"required": ["threshold"]
+}`
+
+func TestAggregatorRegistry_Register(t *testing.T) {
+	r := NewAggregatorRegistry("reserved")
+
+	require.NoError(t, r.Register(CustomAggregator{Name: "custom", ConfigSchema: thresholdSchema, Factory: newNopAggregator}))
+	require.NoError(t, r.Register(CustomAggregator{Name: "another", Factory: newNopAggregator}))
+	assert.True(t, r.Has("custom"))
+	assert.False(t, r.Has("reduce"))
+	assert.Equal(t, []string{"another", "custom"}, r.Names())
+
+	assert.ErrorContains(t, r.Register(CustomAggregator{Name: "custom", Factory: newNopAggregator}), "already registered")
+	assert.ErrorContains(t, r.Register(CustomAggregator{Name: "reserved", Factory: newNopAggregator}), "reserved")
+	for _, name := range BuiltinAggregationMethods {
+		assert.ErrorContains(t, NewAggregatorRegistry().Register(CustomAggregator{Name: name, Factory: newNopAggregator}), "reserved", name)
+	}
+	assert.Error(t, r.Register(CustomAggregator{Factory: newNopAggregator}))
+	assert.Error(t, r.Register(CustomAggregator{Name: "no_factory"}))
+	assert.ErrorContains(t, r.Register(CustomAggregator{Name: "bad_schema", ConfigSchema: "{", Factory: newNopAggregator}), "invalid config schema")
+}
+
+func TestAggregatorRegistry_NewAggregator(t *testing.T) {
+	r := NewAggregatorRegistry()
+	require.NoError(t, r.Register(CustomAggregator{Name: "custom", ConfigSchema: thresholdSchema, Factory: newNopAggregator}))
+
+	valid, err := values.NewMap(map[string]any{"threshold": 2})
+	require.NoError(t, err)
+	agg, err := r.NewAggregator("custom", *valid, logger.Test(t))
+	require.NoError(t, err)
+	assert.Equal(t, *valid, agg.(*nopAggregator).config)
+
+	invalid, err := values.NewMap(map[string]any{"threshold": 0})
+	require.NoError(t, err)
+	_, err = r.NewAggregator("custom", *invalid, logger.Test(t))
+	assert.ErrorContains(t, err, "invalid config for aggregator custom")
+
+	_, err = r.NewAggregator("unknown", *valid, logger.Test(t))
+	assert.ErrorContains(t, err, "no custom aggregator registered")
+}
+
+func TestAggregatorRegistry_Factory(t *testing.T) {
+	r := NewAggregatorRegistry()
+	require.NoError(t, r.Register(CustomAggregator{Name: "custom", Factory: newNopAggregator}))
+
+	var fallbackCalledWith string
+	factory := r.Factory(func(name string, _ values.Map, _ logger.Logger) (Aggregator, error) {
+		fallbackCalledWith = name
+		return &nopAggregator{}, nil
+	})
+
+	_, err := factory("custom", *values.EmptyMap(), logger.Test(t))
+	require.NoError(t, err)
+	assert.Empty(t, fallbackCalledWith)
+
+	_, err = factory("reduce", *values.EmptyMap(), logger.Test(t))
+	require.NoError(t, err)
+	assert.Equal(t, "reduce", fallbackCalledWith)
+
+	_, err = r.Factory(nil)("reduce", *values.EmptyMap(), logger.Test(t))
+	assert.ErrorContains(t, err, "unknown aggregator")
+}
@@ -0,0 +1,377 @@
+package cron
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/jonboulle/clockwork"
+	"github.com/robfig/cron/v3"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/capabilities"
+	"github.com/smartcontractkit/chainlink-common/pkg/logger"
+	"github.com/smartcontractkit/chainlink-common/pkg/services"
+	"github.com/smartcontractkit/chainlink-common/pkg/values"
+)
+
+const (
+	ID = "cron-trigger@1.0.0"
+
+	defaultSendChannelBufferSize = 1000
+	// defaultMaxCatchUpTicks bounds the missed ticks MissedTicksCatchUpAll replays at once, the remaining ones
+	// are replayed right after, so that the lock isn't held for the whole backlog.
+	defaultMaxCatchUpTicks = defaultSendChannelBufferSize
+)
+
+var info = capabilities.MustNewCapabilityInfo(
+	ID,
+	capabilities.CapabilityTypeTrigger,
+	"A trigger that uses a cron schedule to run periodically at fixed times, dates, or intervals.",
+)
+
+// parser parses standard cron schedules with an optional leading seconds field, as well as descriptors such as
+// @hourly or @every 5m. Schedules are in UTC unless prefixed with a time zone, such as CRON_TZ=America/New_York.
+var parser = cron.NewParser(cron.SecondOptional | cron.Minute | cron.Hour | cron.Dom | cron.Month | cron.Dow | cron.Descriptor)
+
+// MissedTickPolicy is what a Service does with ticks it missed, because it was not started yet, was blocked,
+// or the clock jumped forward. A tick is missed when the tick after it is already due by the time it's processed.
+type MissedTickPolicy string
+
+const (
+	// MissedTicksSkip drops missed ticks, so the next event is sent at the next scheduled time.
+	MissedTicksSkip MissedTickPolicy = "skip"
+	// MissedTicksCatchUpOnce sends a single event for the latest missed tick.
+	MissedTicksCatchUpOnce MissedTickPolicy = "catch up once"
+	// MissedTicksCatchUpAll sends an event for every missed tick, in order, in batches of at most
+	// defaultMaxCatchUpTicks.
+	MissedTicksCatchUpAll MissedTickPolicy = "catch up all"
+)
+
+// LastFiredStore persists the latest scheduled time each trigger fired at, so that the ticks missed while the
+// node was down are handled according to the MissedTickPolicy once the trigger is registered again.
+type LastFiredStore interface {
+	// LastFired returns false if the trigger never fired.
+	LastFired(ctx context.Context, triggerID string) (time.Time, bool, error)
+	SetLastFired(ctx context.Context, triggerID string, scheduled time.Time) error
+}
+
+// Service is an in-process cron trigger capability.
+// It sends a TriggerResponse with a Payload on the channel of each registered trigger at the times of its schedule.
+type Service struct {
+	services.StateMachine
+	capabilities.CapabilityInfo
+	capabilities.Validator[Config, any, Payload]
+
+	lggr   logger.Logger
+	clock  clockwork.Clock
+	policy MissedTickPolicy
+	store  LastFiredStore
+	// maxCatchUpTicks is the number of missed ticks MissedTicksCatchUpAll replays at once
+	maxCatchUpTicks int
+
+	triggers map[string]*trigger
+	mu       sync.Mutex
+
+	wakeCh chan struct{}
+	stopCh services.StopChan
+	wg     sync.WaitGroup
+}
+
+var _ capabilities.TriggerCapability = (*Service)(nil)
+var _ services.Service = (*Service)(nil)
+
+type trigger struct {
+	workflowID string
+	schedule   cron.Schedule
+	next       time.Time
+	ch         chan capabilities.TriggerResponse
+}
+
+// NewService creates a cron trigger service that uses clock for its schedules and policy for the ticks it misses.
+func NewService(lggr logger.Logger, clock clockwork.Clock, policy MissedTickPolicy) (*Service, error) {
+	switch policy {
+	case MissedTicksSkip, MissedTicksCatchUpOnce, MissedTicksCatchUpAll:
+	default:
+		return nil, fmt.Errorf("unknown missed tick policy %q", policy)
+	}
+
+	return &Service{
+		CapabilityInfo:  info,
+		Validator:       capabilities.NewValidator[Config, any, Payload](capabilities.ValidatorArgs{Info: info}),
+		lggr:            logger.Named(lggr, "CronTriggerService"),
+		clock:           clock,
+		policy:          policy,
+		maxCatchUpTicks: defaultMaxCatchUpTicks,
+		triggers:        map[string]*trigger{},
+		wakeCh:          make(chan struct{}, 1),
+		stopCh:          make(services.StopChan),
+	}, nil
+}
+
+// NewPersistentService creates a cron trigger service like NewService, which keeps the time each trigger last
+// fired in store. Registered triggers resume their schedule from that time, rather than from now.
+func NewPersistentService(lggr logger.Logger, clock clockwork.Clock, policy MissedTickPolicy, store LastFiredStore) (*Service, error) {
+	s, err := NewService(lggr, clock, policy)
+	if err != nil {
+		return nil, err
+	}
+	s.store = store
+	return s, nil
+}
+
+// ParseSchedule parses a cron schedule with optional seconds and time zone, see Config.Schedule.
+func ParseSchedule(schedule string) (cron.Schedule, error) {
+	parsed, err := parser.Parse(schedule)
+	if err != nil {
+		return nil, fmt.Errorf("invalid schedule %q: %w", schedule, err)
+	}
+	return parsed, nil
+}
+
+func (s *Service) RegisterTrigger(ctx context.Context, req capabilities.TriggerRegistrationRequest) (<-chan capabilities.TriggerResponse, error) {
+	config, err := s.ValidateConfig(req.Config)
+	if err != nil {
+		return nil, err
+	}
+
+	schedule, err := ParseSchedule(config.Schedule)
+	if err != nil {
+		return nil, err
+	}
+
+	// schedules without a time zone are in the time zone of the time they're computed from
+	from := s.clock.Now().UTC()
+	if s.store != nil {
+		lastFired, ok, err := s.store.LastFired(ctx, req.TriggerID)
+		if err != nil {
+			s.lggr.Errorw("failed to load last fired time, ticks missed while down are skipped", "triggerID", req.TriggerID, "err", err)
+		} else if ok && lastFired.Before(from) {
+			from = lastFired.UTC()
+		}
+	}
+
+	next := schedule.Next(from)
+	if next.IsZero() {
+		return nil, fmt.Errorf("schedule %q never fires", config.Schedule)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.triggers[req.TriggerID]; ok {
+		return nil, fmt.Errorf("triggerId %s already registered", req.TriggerID)
+	}
+
+	ch := make(chan capabilities.TriggerResponse, defaultSendChannelBufferSize)
+	s.triggers[req.TriggerID] = &trigger{
+		workflowID: req.Metadata.WorkflowID,
+		schedule:   schedule,
+		next:       next,
+		ch:         ch,
+	}
+	s.wake()
+	return ch, nil
+}
+
+func (s *Service) UnregisterTrigger(ctx context.Context, req capabilities.TriggerRegistrationRequest) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	t, ok := s.triggers[req.TriggerID]
+	if !ok {
+		return fmt.Errorf("triggerId %s not registered", req.TriggerID)
+	}
+	close(t.ch)
+	delete(s.triggers, req.TriggerID)
+	s.wake()
+	return nil
+}
+
+// wake makes the loop recompute when the next tick is due. The caller must hold mu.
+func (s *Service) wake() {
+	select {
+	case s.wakeCh <- struct{}{}:
+	default:
+	}
+}
+
+func (s *Service) loop() {
+	defer s.wg.Done()
+	for {
+		var timer clockwork.Timer
+		var tick <-chan time.Time
+		if next, ok := s.nextTick(); ok {
+			timer = s.clock.NewTimer(next.Sub(s.clock.Now()))
+			tick = timer.Chan()
+		}
+
+		select {
+		case <-s.stopCh:
+			stopTimer(timer)
+			return
+		case <-s.wakeCh:
+			stopTimer(timer)
+		case <-tick:
+			s.persist(s.process(s.clock.Now().UTC()))
+		}
+	}
+}
+
+func stopTimer(timer clockwork.Timer) {
+	if timer != nil {
+		timer.Stop()
+	}
+}
+
+// nextTick returns the earliest time a tick is due for any trigger.
+func (s *Service) nextTick() (time.Time, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var next time.Time
+	for _, t := range s.triggers {
+		if t.next.IsZero() {
+			// the schedule has no more ticks
+			continue
+		}
+		if next.IsZero() || t.next.Before(next) {
+			next = t.next
+		}
+	}
+	return next, !next.IsZero()
+}
+
+// process sends events for the ticks that are due at now, according to the missed tick policy, and returns
+// the latest tick processed by trigger ID. now must be in UTC, like the times schedules are computed from.
+func (s *Service) process(now time.Time) map[string]time.Time {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	fired := map[string]time.Time{}
+	for triggerID, t := range s.triggers {
+		if t.next.IsZero() || t.next.After(now) {
+			continue
+		}
+
+		next := t.schedule.Next(t.next)
+		if next.IsZero() || next.After(now) {
+			s.send(triggerID, t, t.next, now)
+			fired[triggerID] = t.next
+			t.next = next
+			continue
+		}
+
+		switch s.policy {
+		case MissedTicksSkip, MissedTicksCatchUpOnce:
+			latest := latestTick(t.schedule, next, now)
+			s.lggr.Warnw("Missed cron ticks", "triggerID", triggerID, "workflowID", t.workflowID, "first", t.next, "latest", latest, "policy", s.policy)
+			if s.policy == MissedTicksCatchUpOnce {
+				s.send(triggerID, t, latest, now)
+			}
+			fired[triggerID] = latest
+			t.next = t.schedule.Next(now)
+		case MissedTicksCatchUpAll:
+			var due []time.Time
+			for ; !t.next.IsZero() && !t.next.After(now) && len(due) < s.maxCatchUpTicks; t.next = t.schedule.Next(t.next) {
+				due = append(due, t.next)
+			}
+			if !t.next.IsZero() && !t.next.After(now) {
+				s.lggr.Warnw("Missed more cron ticks than are replayed at once, replaying the rest next", "triggerID", triggerID, "workflowID", t.workflowID, "replayed", len(due), "next", t.next)
+			} else {
+				s.lggr.Warnw("Missed cron ticks", "triggerID", triggerID, "workflowID", t.workflowID, "missed", len(due), "policy", s.policy)
+			}
+			for _, scheduled := range due {
+				s.send(triggerID, t, scheduled, now)
+			}
+			fired[triggerID] = due[len(due)-1]
+		}
+	}
+	return fired
+}
+
+// latestTick returns the latest tick of schedule which isn't after now, given tick, one which isn't either.
+// It searches back from now in growing steps, rather than walking every tick since tick.
+func latestTick(schedule cron.Schedule, tick, now time.Time) time.Time {
+	for step := time.Second; now.Add(-step).After(tick); step *= 2 {
+		if found := schedule.Next(now.Add(-step)); !found.After(now) {
+			tick = found
+			break
+		}
+	}
+	for next := schedule.Next(tick); !next.IsZero() && !next.After(now); next = schedule.Next(next) {
+		tick = next
+	}
+	return tick
+}
+
+// persist stores the latest tick processed by trigger ID, outside of mu.
+func (s *Service) persist(fired map[string]time.Time) {
+	if s.store == nil {
+		return
+	}
+	ctx, cancel := s.stopCh.NewCtx()
+	defer cancel()
+	for triggerID, scheduled := range fired {
+		if err := s.store.SetLastFired(ctx, triggerID, scheduled); err != nil {
+			s.lggr.Errorw("failed to store last fired time", "triggerID", triggerID, "err", err)
+		}
+	}
+}
+
+func (s *Service) send(triggerID string, t *trigger, scheduled, actual time.Time) {
+	eventID := fmt.Sprintf("cron_%s_%d", triggerID, scheduled.UnixNano())
+	response, err := wrapPayload(Payload{
+		ActualExecutionTime:    actual.UTC().Format(time.RFC3339Nano),
+		ScheduledExecutionTime: scheduled.UTC().Format(time.RFC3339Nano),
+	}, eventID)
+	if err != nil {
+		s.lggr.Errorw("error wrapping payload", "eventID", eventID, "err", err)
+		return
+	}
+
+	select {
+	case t.ch <- response:
+	default:
+		s.lggr.Errorw("subscriber channel full, dropping event", "eventID", eventID, "workflowID", t.workflowID)
+	}
+}
+
+func wrapPayload(payload Payload, eventID string) (capabilities.TriggerResponse, error) {
+	outputs, err := values.WrapMap(payload)
+	if err != nil {
+		return capabilities.TriggerResponse{}, err
+	}
+
+	return capabilities.TriggerResponse{
+		Event: capabilities.TriggerEvent{
+			TriggerType: ID,
+			ID:          eventID,
+			Outputs:     outputs,
+		},
+	}, nil
+}
+
+func (s *Service) Start(ctx context.Context) error {
+	return s.StartOnce("CronTriggerService", func() error {
+		s.wg.Add(1)
+		go s.loop()
+		return nil
+	})
+}
+
+func (s *Service) Close() error {
+	return s.StopOnce("CronTriggerService", func() error {
+		close(s.stopCh)
+		s.wg.Wait()
+		return nil
+	})
+}
+
+func (s *Service) HealthReport() map[string]error {
+	return map[string]error{s.Name(): s.Healthy()}
+}
+
+func (s *Service) Name() string {
+	return s.lggr.Name()
+}
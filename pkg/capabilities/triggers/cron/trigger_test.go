@@ -0,0 +1,268 @@
+package cron
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/jonboulle/clockwork"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/capabilities"
+	"github.com/smartcontractkit/chainlink-common/pkg/logger"
+	"github.com/smartcontractkit/chainlink-common/pkg/services/servicetest"
+	"github.com/smartcontractkit/chainlink-common/pkg/utils/tests"
+	"github.com/smartcontractkit/chainlink-common/pkg/values"
+)
+
+var start = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+func newTestService(t *testing.T, policy MissedTickPolicy) (*Service, clockwork.FakeClock) {
+	clock := clockwork.NewFakeClockAt(start)
+	s, err := NewService(logger.Test(t), clock, policy)
+	require.NoError(t, err)
+	require.NoError(t, s.Start(tests.Context(t)))
+	t.Cleanup(func() { assert.NoError(t, s.Close()) })
+	return s, clock
+}
+
+func registerTrigger(ctx context.Context, t *testing.T, s *Service, triggerID, schedule string) (<-chan capabilities.TriggerResponse, capabilities.TriggerRegistrationRequest) {
+	config, err := values.NewMap(map[string]any{"schedule": schedule})
+	require.NoError(t, err)
+
+	req := capabilities.TriggerRegistrationRequest{
+		TriggerID: triggerID,
+		Metadata:  capabilities.RequestMetadata{WorkflowID: "workflow-id-1"},
+		Config:    config,
+	}
+	ch, err := s.RegisterTrigger(ctx, req)
+	require.NoError(t, err)
+	return ch, req
+}
+
+// advance moves the clock forward once the service is waiting for the next tick, and waits for it to be processed.
+func advance(t *testing.T, clock clockwork.FakeClock, d time.Duration) {
+	clock.BlockUntil(1)
+	clock.Advance(d)
+	clock.BlockUntil(1)
+}
+
+func receivePayload(t *testing.T, ch <-chan capabilities.TriggerResponse) Payload {
+	select {
+	case response := <-ch:
+		require.NoError(t, response.Err)
+		assert.Equal(t, ID, response.Event.TriggerType)
+
+		payload := Payload{}
+		require.NoError(t, response.Event.Outputs.UnwrapTo(&payload))
+		return payload
+	case <-tests.Context(t).Done():
+		require.FailNow(t, "timed out waiting for trigger event")
+		return Payload{}
+	}
+}
+
+func TestService(t *testing.T) {
+	t.Parallel()
+
+	t.Run("sends events on schedule with seconds", func(t *testing.T) {
+		s, clock := newTestService(t, MissedTicksSkip)
+		ch, _ := registerTrigger(tests.Context(t), t, s, "trigger-1", "*/5 * * * * *")
+
+		for _, expected := range []string{"2024-01-01T00:00:05Z", "2024-01-01T00:00:10Z"} {
+			advance(t, clock, 5*time.Second)
+			payload := receivePayload(t, ch)
+			assert.Equal(t, expected, payload.ScheduledExecutionTime)
+			assert.Equal(t, expected, payload.ActualExecutionTime)
+		}
+	})
+
+	t.Run("sends events on schedule in time zone", func(t *testing.T) {
+		s, clock := newTestService(t, MissedTicksSkip)
+		ch, _ := registerTrigger(tests.Context(t), t, s, "trigger-1", "CRON_TZ=Asia/Tokyo 30 9 * * *")
+
+		advance(t, clock, 30*time.Minute)
+		assert.Equal(t, "2024-01-01T00:30:00Z", receivePayload(t, ch).ScheduledExecutionTime)
+	})
+
+	t.Run("sends events on schedule in UTC when the clock is in another time zone", func(t *testing.T) {
+		clock := clockwork.NewFakeClockAt(start.Add(-30 * time.Minute).In(time.FixedZone("UTC+5", 5*60*60)))
+		s, err := NewService(logger.Test(t), clock, MissedTicksSkip)
+		require.NoError(t, err)
+		servicetest.Run(t, s)
+		ch, _ := registerTrigger(tests.Context(t), t, s, "trigger-1", "0 0 * * *")
+
+		advance(t, clock, 30*time.Minute)
+		payload := receivePayload(t, ch)
+		assert.Equal(t, "2024-01-01T00:00:00Z", payload.ScheduledExecutionTime)
+		assert.Equal(t, "2024-01-01T00:00:00Z", payload.ActualExecutionTime)
+	})
+
+	t.Run("sends events to every registered trigger", func(t *testing.T) {
+		s, clock := newTestService(t, MissedTicksSkip)
+		ch1, _ := registerTrigger(tests.Context(t), t, s, "trigger-1", "* * * * *")
+		ch2, _ := registerTrigger(tests.Context(t), t, s, "trigger-2", "*/2 * * * *")
+
+		advance(t, clock, time.Minute)
+		assert.Equal(t, "2024-01-01T00:01:00Z", receivePayload(t, ch1).ScheduledExecutionTime)
+		assert.Empty(t, ch2)
+
+		advance(t, clock, time.Minute)
+		assert.Equal(t, "2024-01-01T00:02:00Z", receivePayload(t, ch1).ScheduledExecutionTime)
+		assert.Equal(t, "2024-01-01T00:02:00Z", receivePayload(t, ch2).ScheduledExecutionTime)
+	})
+
+	t.Run("UnregisterTrigger closes the channel", func(t *testing.T) {
+		s, _ := newTestService(t, MissedTicksSkip)
+		ctx := tests.Context(t)
+		ch, req := registerTrigger(ctx, t, s, "trigger-1", "* * * * *")
+
+		require.NoError(t, s.UnregisterTrigger(ctx, req))
+		_, ok := <-ch
+		assert.False(t, ok)
+		assert.Error(t, s.UnregisterTrigger(ctx, req))
+	})
+
+	t.Run("RegisterTrigger returns an error for duplicate triggers", func(t *testing.T) {
+		s, _ := newTestService(t, MissedTicksSkip)
+		ctx := tests.Context(t)
+		_, req := registerTrigger(ctx, t, s, "trigger-1", "* * * * *")
+
+		_, err := s.RegisterTrigger(ctx, req)
+		assert.Error(t, err)
+	})
+
+	t.Run("RegisterTrigger returns an error for invalid schedules", func(t *testing.T) {
+		s, _ := newTestService(t, MissedTicksSkip)
+		for _, schedule := range []string{"", "* * *", "61 * * * *", "CRON_TZ=Nowhere/Special * * * * *", "0 0 30 2 *"} {
+			config, err := values.NewMap(map[string]any{"schedule": schedule})
+			require.NoError(t, err)
+
+			_, err = s.RegisterTrigger(tests.Context(t), capabilities.TriggerRegistrationRequest{TriggerID: "trigger-1", Config: config})
+			assert.Error(t, err, schedule)
+		}
+	})
+
+	t.Run("NewService returns an error for unknown missed tick policies", func(t *testing.T) {
+		_, err := NewService(logger.Test(t), clockwork.NewFakeClock(), "sometimes")
+		assert.Error(t, err)
+	})
+}
+
+func TestService_MissedTicks(t *testing.T) {
+	t.Parallel()
+
+	for _, test := range []struct {
+		policy   MissedTickPolicy
+		expected []string
+	}{
+		{policy: MissedTicksSkip},
+		{policy: MissedTicksCatchUpOnce, expected: []string{"2024-01-01T00:03:00Z"}},
+		{policy: MissedTicksCatchUpAll, expected: []string{"2024-01-01T00:01:00Z", "2024-01-01T00:02:00Z", "2024-01-01T00:03:00Z"}},
+	} {
+		t.Run(string(test.policy), func(t *testing.T) {
+			s, clock := newTestService(t, test.policy)
+			ch, _ := registerTrigger(tests.Context(t), t, s, "trigger-1", "* * * * *")
+
+			advance(t, clock, 3*time.Minute)
+			require.Len(t, ch, len(test.expected))
+			for _, expected := range test.expected {
+				payload := receivePayload(t, ch)
+				assert.Equal(t, expected, payload.ScheduledExecutionTime)
+				assert.Equal(t, "2024-01-01T00:03:00Z", payload.ActualExecutionTime)
+			}
+
+			// ticks after the missed ones are sent on time
+			advance(t, clock, time.Minute)
+			assert.Equal(t, "2024-01-01T00:04:00Z", receivePayload(t, ch).ScheduledExecutionTime)
+		})
+	}
+}
+
+func TestService_MissedTicksOverLongOutages(t *testing.T) {
+	t.Parallel()
+
+	t.Run("skip and catch up once don't walk every missed tick", func(t *testing.T) {
+		for _, policy := range []MissedTickPolicy{MissedTicksSkip, MissedTicksCatchUpOnce} {
+			s, clock := newTestService(t, policy)
+			ch, _ := registerTrigger(tests.Context(t), t, s, "trigger-1", "* * * * * *")
+
+			// a year of ticks every second
+			advance(t, clock, 365*24*time.Hour)
+			if policy == MissedTicksCatchUpOnce {
+				assert.Equal(t, "2024-12-31T00:00:00Z", receivePayload(t, ch).ScheduledExecutionTime)
+			}
+			assert.Empty(t, ch)
+
+			advance(t, clock, time.Second)
+			assert.Equal(t, "2024-12-31T00:00:01Z", receivePayload(t, ch).ScheduledExecutionTime)
+		}
+	})
+
+	t.Run("catch up all replays missed ticks in batches", func(t *testing.T) {
+		s, clock := newTestService(t, MissedTicksCatchUpAll)
+		s.maxCatchUpTicks = 2
+		ch, _ := registerTrigger(tests.Context(t), t, s, "trigger-1", "* * * * *")
+
+		advance(t, clock, 5*time.Minute)
+		for _, expected := range []string{"2024-01-01T00:01:00Z", "2024-01-01T00:02:00Z", "2024-01-01T00:03:00Z", "2024-01-01T00:04:00Z", "2024-01-01T00:05:00Z"} {
+			assert.Equal(t, expected, receivePayload(t, ch).ScheduledExecutionTime)
+		}
+	})
+}
+
+type memoryLastFiredStore struct {
+	mu sync.Mutex
+	m  map[string]time.Time
+}
+
+func (s *memoryLastFiredStore) LastFired(_ context.Context, triggerID string) (time.Time, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	t, ok := s.m[triggerID]
+	return t, ok, nil
+}
+
+func (s *memoryLastFiredStore) SetLastFired(_ context.Context, triggerID string, scheduled time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.m[triggerID] = scheduled
+	return nil
+}
+
+func TestService_CatchUpAfterRestart(t *testing.T) {
+	t.Parallel()
+	store := &memoryLastFiredStore{m: map[string]time.Time{}}
+	clock := clockwork.NewFakeClockAt(start)
+
+	s, err := NewPersistentService(logger.Test(t), clock, MissedTicksCatchUpAll, store)
+	require.NoError(t, err)
+	require.NoError(t, s.Start(tests.Context(t)))
+	ch, _ := registerTrigger(tests.Context(t), t, s, "trigger-1", "* * * * *")
+
+	advance(t, clock, time.Minute)
+	assert.Equal(t, "2024-01-01T00:01:00Z", receivePayload(t, ch).ScheduledExecutionTime)
+	require.NoError(t, s.Close())
+
+	// the node is down for three minutes
+	clock.Advance(3 * time.Minute)
+
+	restarted, err := NewPersistentService(logger.Test(t), clock, MissedTicksCatchUpAll, store)
+	require.NoError(t, err)
+	require.NoError(t, restarted.Start(tests.Context(t)))
+	t.Cleanup(func() { assert.NoError(t, restarted.Close()) })
+	ch, _ = registerTrigger(tests.Context(t), t, restarted, "trigger-1", "* * * * *")
+
+	for _, expected := range []string{"2024-01-01T00:02:00Z", "2024-01-01T00:03:00Z", "2024-01-01T00:04:00Z"} {
+		payload := receivePayload(t, ch)
+		assert.Equal(t, expected, payload.ScheduledExecutionTime)
+		assert.Equal(t, "2024-01-01T00:04:00Z", payload.ActualExecutionTime)
+	}
+
+	// ticks after the missed ones are sent on time
+	advance(t, clock, time.Minute)
+	assert.Equal(t, "2024-01-01T00:05:00Z", receivePayload(t, ch).ScheduledExecutionTime)
+	assert.Equal(t, start.Add(5*time.Minute), store.m["trigger-1"])
+}
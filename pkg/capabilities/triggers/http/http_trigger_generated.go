@@ -0,0 +1,102 @@
+// Code generated by github.com/smartcontractkit/chainlink-common/pkg/capabilities/cli, DO NOT EDIT.
+
+package http
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+type Config struct {
+	// AllowedKeys corresponds to the JSON schema field "allowedKeys".
+	AllowedKeys []string `json:"allowedKeys" yaml:"allowedKeys" mapstructure:"allowedKeys"`
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (j *Config) UnmarshalJSON(b []byte) error {
+	var raw map[string]interface{}
+	if err := json.Unmarshal(b, &raw); err != nil {
+		return err
+	}
+	if _, ok := raw["allowedKeys"]; raw != nil && !ok {
+		return fmt.Errorf("field allowedKeys in Config: required")
+	}
+	type Plain Config
+	var plain Plain
+	if err := json.Unmarshal(b, &plain); err != nil {
+		return err
+	}
+	if plain.AllowedKeys != nil && len(plain.AllowedKeys) < 1 {
+		return fmt.Errorf("field %s length: must be >= %d", "allowedKeys", 1)
+	}
+	*j = Config(plain)
+	return nil
+}
+
+type Payload struct {
+	// JSON object the request was sent with
+	Body PayloadBody `json:"Body" yaml:"Body" mapstructure:"Body"`
+
+	// Hex encoded ed25519 public key the request was signed with
+	PublicKey string `json:"PublicKey" yaml:"PublicKey" mapstructure:"PublicKey"`
+
+	// Time the request was signed at (RFC3339Nano formatted)
+	Timestamp string `json:"Timestamp" yaml:"Timestamp" mapstructure:"Timestamp"`
+}
+
+// JSON object the request was sent with
+type PayloadBody map[string]interface{}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (j *Payload) UnmarshalJSON(b []byte) error {
+	var raw map[string]interface{}
+	if err := json.Unmarshal(b, &raw); err != nil {
+		return err
+	}
+	if _, ok := raw["Body"]; raw != nil && !ok {
+		return fmt.Errorf("field Body in Payload: required")
+	}
+	if _, ok := raw["PublicKey"]; raw != nil && !ok {
+		return fmt.Errorf("field PublicKey in Payload: required")
+	}
+	if _, ok := raw["Timestamp"]; raw != nil && !ok {
+		return fmt.Errorf("field Timestamp in Payload: required")
+	}
+	type Plain Payload
+	var plain Plain
+	if err := json.Unmarshal(b, &plain); err != nil {
+		return err
+	}
+	*j = Payload(plain)
+	return nil
+}
+
+// A trigger that starts a workflow from a signed HTTP request.
+type Trigger struct {
+	// Config corresponds to the JSON schema field "config".
+	Config Config `json:"config" yaml:"config" mapstructure:"config"`
+
+	// Outputs corresponds to the JSON schema field "outputs".
+	Outputs Payload `json:"outputs" yaml:"outputs" mapstructure:"outputs"`
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (j *Trigger) UnmarshalJSON(b []byte) error {
+	var raw map[string]interface{}
+	if err := json.Unmarshal(b, &raw); err != nil {
+		return err
+	}
+	if _, ok := raw["config"]; raw != nil && !ok {
+		return fmt.Errorf("field config in Trigger: required")
+	}
+	if _, ok := raw["outputs"]; raw != nil && !ok {
+		return fmt.Errorf("field outputs in Trigger: required")
+	}
+	type Plain Trigger
+	var plain Plain
+	if err := json.Unmarshal(b, &plain); err != nil {
+		return err
+	}
+	*j = Trigger(plain)
+	return nil
+}
@@ -0,0 +1,17 @@
+// Code generated by github.com/smartcontractkit/chainlink-common/pkg/capabilities/cli, DO NOT EDIT.
+
+// Code generated by github.com/smartcontractkit/chainlink-common/pkg/capabilities/cli, DO NOT EDIT.
+
+package httptest
+
+import (
+	"github.com/smartcontractkit/chainlink-common/pkg/capabilities/triggers/http"
+	"github.com/smartcontractkit/chainlink-common/pkg/workflows/sdk/testutils"
+)
+
+// Trigger registers a new capability mock with the runner
+func Trigger(runner *testutils.Runner, fn func() (http.Payload, error)) *testutils.TriggerMock[http.Payload] {
+	mock := testutils.MockTrigger[http.Payload]("http-trigger@1.0.0", fn)
+	runner.MockCapability("http-trigger@1.0.0", nil, mock)
+	return mock
+}
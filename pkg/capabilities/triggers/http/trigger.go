@@ -0,0 +1,377 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/jonboulle/clockwork"
+	"github.com/shopspring/decimal"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/capabilities"
+	"github.com/smartcontractkit/chainlink-common/pkg/logger"
+	"github.com/smartcontractkit/chainlink-common/pkg/services"
+	"github.com/smartcontractkit/chainlink-common/pkg/values"
+)
+
+const (
+	ID = "http-trigger@1.0.0"
+
+	// HeaderPublicKey is the hex encoded ed25519 public key a request is signed with.
+	HeaderPublicKey = "X-Trigger-Public-Key"
+	// HeaderTimestamp is the unix time in seconds a request is signed at.
+	HeaderTimestamp = "X-Trigger-Timestamp"
+	// HeaderSignature is the hex encoded ed25519 signature of the message returned by SignedMessage.
+	HeaderSignature = "X-Trigger-Signature"
+
+	// MaxRequestAge is how far the timestamp of a request can be from the current time.
+	// Signatures are remembered for as long, so requests cannot be replayed.
+	MaxRequestAge = 5 * time.Minute
+
+	maxBodySize                  = 1 << 20
+	defaultSendChannelBufferSize = 1000
+)
+
+var info = capabilities.MustNewCapabilityInfo(
+	ID,
+	capabilities.CapabilityTypeTrigger,
+	"A trigger that starts a workflow from a signed HTTP request.",
+)
+
+// Service is an HTTP trigger capability.
+// Workflows are triggered by POST requests to /workflows/{workflowID}, with a JSON object body signed by one of the
+// keys allowed by the trigger's config, see SignRequest.
+// The body is sent as the Payload of a TriggerResponse on the channel of each trigger of the workflow allowing the key.
+type Service struct {
+	services.StateMachine
+	capabilities.CapabilityInfo
+	capabilities.Validator[Config, any, Payload]
+
+	lggr  logger.Logger
+	clock clockwork.Clock
+	mux   *http.ServeMux
+
+	addr     string
+	srvr     *http.Server
+	srvrDone chan struct{}
+
+	subscribers map[string]*subscriber
+	// seen are the signatures of accepted requests, by the time they were signed at.
+	seen map[string]time.Time
+	mu   sync.Mutex
+}
+
+var _ capabilities.TriggerCapability = (*Service)(nil)
+var _ services.Service = (*Service)(nil)
+var _ http.Handler = (*Service)(nil)
+
+type subscriber struct {
+	workflowID  string
+	allowedKeys map[string]bool
+	ch          chan capabilities.TriggerResponse
+}
+
+// NewService creates an HTTP trigger service that listens on addr once started.
+// The service is also an http.Handler, so it can be served by another server instead of being started.
+func NewService(lggr logger.Logger, clock clockwork.Clock, addr string) *Service {
+	s := &Service{
+		CapabilityInfo: info,
+		Validator:      capabilities.NewValidator[Config, any, Payload](capabilities.ValidatorArgs{Info: info}),
+		lggr:           logger.Named(lggr, "HTTPTriggerService"),
+		clock:          clock,
+		mux:            http.NewServeMux(),
+		addr:           addr,
+		srvrDone:       make(chan struct{}),
+		subscribers:    map[string]*subscriber{},
+		seen:           map[string]time.Time{},
+	}
+	s.mux.HandleFunc("POST /workflows/{workflowID}", s.handleTrigger)
+	s.srvr = &http.Server{Handler: s.mux, ReadHeaderTimeout: 5 * time.Second}
+	return s
+}
+
+// SignedMessage returns the message signed for a request to trigger workflowID with body at timestamp.
+// It covers the method and path of the request, so a signed request cannot trigger another workflow.
+func SignedMessage(workflowID string, timestamp int64, body []byte) []byte {
+	return append([]byte(http.MethodPost+" /workflows/"+workflowID+"\n"+strconv.FormatInt(timestamp, 10)+"."), body...)
+}
+
+// SignRequest sets the headers of a request to trigger workflowID with body, signed with key at now.
+func SignRequest(r *http.Request, key ed25519.PrivateKey, workflowID string, body []byte, now time.Time) {
+	timestamp := now.Unix()
+	r.Header.Set(HeaderPublicKey, hex.EncodeToString(key.Public().(ed25519.PublicKey)))
+	r.Header.Set(HeaderTimestamp, strconv.FormatInt(timestamp, 10))
+	r.Header.Set(HeaderSignature, hex.EncodeToString(ed25519.Sign(key, SignedMessage(workflowID, timestamp, body))))
+}
+
+func (s *Service) RegisterTrigger(ctx context.Context, req capabilities.TriggerRegistrationRequest) (<-chan capabilities.TriggerResponse, error) {
+	config, err := s.ValidateConfig(req.Config)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(config.AllowedKeys) == 0 {
+		return nil, errors.New("at least one allowed key is required")
+	}
+
+	allowedKeys := make(map[string]bool, len(config.AllowedKeys))
+	for _, key := range config.AllowedKeys {
+		if b, err := hex.DecodeString(key); err != nil || len(b) != ed25519.PublicKeySize || key != strings.ToLower(key) {
+			return nil, fmt.Errorf("allowed key %q is not a lowercase hex encoded ed25519 public key", key)
+		}
+		allowedKeys[key] = true
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.subscribers[req.TriggerID]; ok {
+		return nil, fmt.Errorf("triggerId %s already registered", req.TriggerID)
+	}
+
+	ch := make(chan capabilities.TriggerResponse, defaultSendChannelBufferSize)
+	s.subscribers[req.TriggerID] = &subscriber{
+		workflowID:  req.Metadata.WorkflowID,
+		allowedKeys: allowedKeys,
+		ch:          ch,
+	}
+	return ch, nil
+}
+
+func (s *Service) UnregisterTrigger(ctx context.Context, req capabilities.TriggerRegistrationRequest) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	sub, ok := s.subscribers[req.TriggerID]
+	if !ok {
+		return fmt.Errorf("triggerId %s not registered", req.TriggerID)
+	}
+	close(sub.ch)
+	delete(s.subscribers, req.TriggerID)
+	return nil
+}
+
+func (s *Service) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mux.ServeHTTP(w, r)
+}
+
+func (s *Service) handleTrigger(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxBodySize))
+	if err != nil {
+		http.Error(w, fmt.Sprintf("failed to read body: %s", err), http.StatusRequestEntityTooLarge)
+		return
+	}
+
+	workflowID := r.PathValue("workflowID")
+	publicKey, signature, timestamp, err := s.verify(r.Header, workflowID, body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+
+	decoded, err := decodeBody(body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	outputs, err := values.WrapMap(Payload{
+		Body:      decoded,
+		PublicKey: publicKey,
+		Timestamp: timestamp.UTC().Format(time.RFC3339Nano),
+	})
+	if err != nil {
+		http.Error(w, fmt.Sprintf("failed to wrap body: %s", err), http.StatusBadRequest)
+		return
+	}
+
+	status, err := s.send(workflowID, publicKey, signature, timestamp, outputs)
+	if err != nil {
+		http.Error(w, err.Error(), status)
+		return
+	}
+	w.WriteHeader(status)
+}
+
+// verify checks the signature of a request to trigger workflowID was made recently by the public key in its headers.
+func (s *Service) verify(header http.Header, workflowID string, body []byte) (publicKey, signature string, timestamp time.Time, err error) {
+	publicKey = strings.ToLower(header.Get(HeaderPublicKey))
+	key, err := hex.DecodeString(publicKey)
+	if err != nil || len(key) != ed25519.PublicKeySize {
+		return "", "", time.Time{}, errors.New("invalid public key")
+	}
+
+	unix, err := strconv.ParseInt(header.Get(HeaderTimestamp), 10, 64)
+	if err != nil {
+		return "", "", time.Time{}, errors.New("invalid timestamp")
+	}
+
+	timestamp = time.Unix(unix, 0)
+	if age := s.clock.Since(timestamp); age > MaxRequestAge || age < -MaxRequestAge {
+		return "", "", time.Time{}, fmt.Errorf("timestamp must be within %s of the current time", MaxRequestAge)
+	}
+
+	signature = strings.ToLower(header.Get(HeaderSignature))
+	sig, err := hex.DecodeString(signature)
+	if err != nil || !ed25519.Verify(key, SignedMessage(workflowID, unix, body), sig) {
+		return "", "", time.Time{}, errors.New("invalid signature")
+	}
+
+	return publicKey, signature, timestamp, nil
+}
+
+// send sends outputs to the triggers of the workflow allowing the public key, unless the signature was already seen.
+func (s *Service) send(workflowID, publicKey, signature string, timestamp time.Time, outputs *values.Map) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var subscribers []*subscriber
+	registered := false
+	for _, sub := range s.subscribers {
+		if sub.workflowID == workflowID {
+			registered = true
+			if sub.allowedKeys[publicKey] {
+				subscribers = append(subscribers, sub)
+			}
+		}
+	}
+
+	if !registered {
+		return http.StatusNotFound, fmt.Errorf("no triggers registered for workflow %s", workflowID)
+	}
+
+	if len(subscribers) == 0 {
+		return http.StatusForbidden, fmt.Errorf("public key %s is not allowed to trigger workflow %s", publicKey, workflowID)
+	}
+
+	for sig, signedAt := range s.seen {
+		if s.clock.Since(signedAt) > MaxRequestAge {
+			delete(s.seen, sig)
+		}
+	}
+
+	if _, ok := s.seen[signature]; ok {
+		return http.StatusConflict, errors.New("request was already received")
+	}
+	s.seen[signature] = timestamp
+
+	hash := sha256.Sum256([]byte(signature))
+	response := capabilities.TriggerResponse{
+		Event: capabilities.TriggerEvent{
+			TriggerType: ID,
+			ID:          "http_" + hex.EncodeToString(hash[:]),
+			Outputs:     outputs,
+		},
+	}
+
+	for _, sub := range subscribers {
+		select {
+		case sub.ch <- response:
+		default:
+			s.lggr.Errorw("subscriber channel full, dropping event", "eventID", response.Event.ID, "workflowID", workflowID)
+		}
+	}
+	return http.StatusAccepted, nil
+}
+
+// decodeBody decodes a JSON object, with whole numbers as int64 or *big.Int and other numbers as decimal.Decimal,
+// so they can be wrapped into values without losing precision.
+func decodeBody(body []byte) (PayloadBody, error) {
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+
+	decoded := map[string]any{}
+	if err := decoder.Decode(&decoded); err != nil {
+		return nil, fmt.Errorf("body must be a JSON object: %w", err)
+	}
+
+	if decoder.More() {
+		return nil, errors.New("body must be a single JSON object")
+	}
+
+	for key, value := range decoded {
+		converted, err := convertNumbers(value)
+		if err != nil {
+			return nil, err
+		}
+		decoded[key] = converted
+	}
+	return decoded, nil
+}
+
+func convertNumbers(value any) (any, error) {
+	switch v := value.(type) {
+	case json.Number:
+		if i, err := v.Int64(); err == nil {
+			return i, nil
+		}
+		if i, ok := new(big.Int).SetString(v.String(), 10); ok {
+			return i, nil
+		}
+		return decimal.NewFromString(v.String())
+	case map[string]any:
+		for key, elem := range v {
+			converted, err := convertNumbers(elem)
+			if err != nil {
+				return nil, err
+			}
+			v[key] = converted
+		}
+	case []any:
+		for i, elem := range v {
+			converted, err := convertNumbers(elem)
+			if err != nil {
+				return nil, err
+			}
+			v[i] = converted
+		}
+	}
+	return value, nil
+}
+
+func (s *Service) Start(ctx context.Context) error {
+	return s.StartOnce("HTTPTriggerService", func() error {
+		l, err := net.Listen("tcp", s.addr)
+		if err != nil {
+			return err
+		}
+
+		s.lggr.Infow("Listening for triggers", "addr", l.Addr().String())
+		go func() {
+			defer close(s.srvrDone)
+			if err := s.srvr.Serve(l); !errors.Is(err, http.ErrServerClosed) {
+				s.lggr.Errorw("HTTP server failed", "err", err)
+			}
+		}()
+		return nil
+	})
+}
+
+func (s *Service) Close() error {
+	return s.StopOnce("HTTPTriggerService", func() error {
+		err := s.srvr.Shutdown(context.Background())
+		<-s.srvrDone
+		return err
+	})
+}
+
+func (s *Service) HealthReport() map[string]error {
+	return map[string]error{s.Name(): s.Healthy()}
+}
+
+func (s *Service) Name() string {
+	return s.lggr.Name()
+}
@@ -0,0 +1,106 @@
+// Code generated by github.com/smartcontractkit/chainlink-common/pkg/capabilities/cli, DO NOT EDIT.
+
+package http
+
+import (
+	"github.com/smartcontractkit/chainlink-common/pkg/capabilities"
+	"github.com/smartcontractkit/chainlink-common/pkg/workflows/sdk"
+)
+
+func (cfg Config) New(w *sdk.WorkflowSpecFactory) PayloadCap {
+	ref := "trigger"
+	def := sdk.StepDefinition{
+		ID: "http-trigger@1.0.0", Ref: ref,
+		Inputs: sdk.StepInputs{},
+		Config: map[string]any{
+			"allowedKeys": cfg.AllowedKeys,
+		},
+		CapabilityType: capabilities.CapabilityTypeTrigger,
+	}
+
+	step := sdk.Step[Payload]{Definition: def}
+	raw := step.AddTo(w)
+	return PayloadWrapper(raw)
+}
+
+// PayloadWrapper allows access to field from an sdk.CapDefinition[Payload]
+func PayloadWrapper(raw sdk.CapDefinition[Payload]) PayloadCap {
+	wrapped, ok := raw.(PayloadCap)
+	if ok {
+		return wrapped
+	}
+	return &payloadCap{CapDefinition: raw}
+}
+
+type PayloadCap interface {
+	sdk.CapDefinition[Payload]
+	Body() PayloadBodyCap
+	PublicKey() sdk.CapDefinition[string]
+	Timestamp() sdk.CapDefinition[string]
+	private()
+}
+
+type payloadCap struct {
+	sdk.CapDefinition[Payload]
+}
+
+func (*payloadCap) private() {}
+func (c *payloadCap) Body() PayloadBodyCap {
+	return PayloadBodyWrapper(sdk.AccessField[Payload, PayloadBody](c.CapDefinition, "Body"))
+}
+func (c *payloadCap) PublicKey() sdk.CapDefinition[string] {
+	return sdk.AccessField[Payload, string](c.CapDefinition, "PublicKey")
+}
+func (c *payloadCap) Timestamp() sdk.CapDefinition[string] {
+	return sdk.AccessField[Payload, string](c.CapDefinition, "Timestamp")
+}
+
+func ConstantPayload(value Payload) PayloadCap {
+	return &payloadCap{CapDefinition: sdk.ConstantDefinition(value)}
+}
+
+func NewPayloadFromFields(
+	body PayloadBodyCap,
+	publicKey sdk.CapDefinition[string],
+	timestamp sdk.CapDefinition[string]) PayloadCap {
+	return &simplePayload{
+		CapDefinition: sdk.ComponentCapDefinition[Payload]{
+			"Body":      body.Ref(),
+			"PublicKey": publicKey.Ref(),
+			"Timestamp": timestamp.Ref(),
+		},
+		body:      body,
+		publicKey: publicKey,
+		timestamp: timestamp,
+	}
+}
+
+type simplePayload struct {
+	sdk.CapDefinition[Payload]
+	body      PayloadBodyCap
+	publicKey sdk.CapDefinition[string]
+	timestamp sdk.CapDefinition[string]
+}
+
+func (c *simplePayload) Body() PayloadBodyCap {
+	return c.body
+}
+func (c *simplePayload) PublicKey() sdk.CapDefinition[string] {
+	return c.publicKey
+}
+func (c *simplePayload) Timestamp() sdk.CapDefinition[string] {
+	return c.timestamp
+}
+
+func (c *simplePayload) private() {}
+
+// PayloadBodyWrapper allows access to field from an sdk.CapDefinition[PayloadBody]
+func PayloadBodyWrapper(raw sdk.CapDefinition[PayloadBody]) PayloadBodyCap {
+	wrapped, ok := raw.(PayloadBodyCap)
+	if ok {
+		return wrapped
+	}
+	return PayloadBodyCap(raw)
+}
+
+type PayloadBodyCap sdk.CapDefinition[PayloadBody]
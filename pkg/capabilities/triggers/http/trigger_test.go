@@ -0,0 +1,227 @@
+package http
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/hex"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"path"
+	"testing"
+	"time"
+
+	"github.com/jonboulle/clockwork"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/capabilities"
+	"github.com/smartcontractkit/chainlink-common/pkg/logger"
+	"github.com/smartcontractkit/chainlink-common/pkg/utils/tests"
+	"github.com/smartcontractkit/chainlink-common/pkg/values"
+)
+
+const workflowID = "workflow-id-1"
+
+func newKey(t *testing.T) ed25519.PrivateKey {
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	return key
+}
+
+func publicKey(key ed25519.PrivateKey) string {
+	return hex.EncodeToString(key.Public().(ed25519.PublicKey))
+}
+
+func newTestServer(t *testing.T) (*Service, *httptest.Server, clockwork.FakeClock) {
+	clock := clockwork.NewFakeClock()
+	s := NewService(logger.Test(t), clock, "")
+	server := httptest.NewServer(s)
+	t.Cleanup(server.Close)
+	return s, server, clock
+}
+
+func registerTrigger(t *testing.T, s *Service, triggerID string, keys ...ed25519.PrivateKey) (<-chan capabilities.TriggerResponse, capabilities.TriggerRegistrationRequest) {
+	return registerWorkflowTrigger(t, s, workflowID, triggerID, keys...)
+}
+
+func registerWorkflowTrigger(t *testing.T, s *Service, workflowID, triggerID string, keys ...ed25519.PrivateKey) (<-chan capabilities.TriggerResponse, capabilities.TriggerRegistrationRequest) {
+	allowedKeys := make([]string, len(keys))
+	for i, key := range keys {
+		allowedKeys[i] = publicKey(key)
+	}
+
+	config, err := values.NewMap(map[string]any{"allowedKeys": allowedKeys})
+	require.NoError(t, err)
+
+	req := capabilities.TriggerRegistrationRequest{
+		TriggerID: triggerID,
+		Metadata:  capabilities.RequestMetadata{WorkflowID: workflowID},
+		Config:    config,
+	}
+	ch, err := s.RegisterTrigger(tests.Context(t), req)
+	require.NoError(t, err)
+	return ch, req
+}
+
+func post(t *testing.T, server *httptest.Server, workflowID string, body string, sign func(r *http.Request, body []byte)) int {
+	r, err := http.NewRequestWithContext(tests.Context(t), http.MethodPost, server.URL+"/workflows/"+workflowID, bytes.NewBufferString(body))
+	require.NoError(t, err)
+	sign(r, []byte(body))
+
+	resp, err := server.Client().Do(r)
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+	return resp.StatusCode
+}
+
+func signWith(key ed25519.PrivateKey, now time.Time) func(r *http.Request, body []byte) {
+	return func(r *http.Request, body []byte) {
+		SignRequest(r, key, path.Base(r.URL.Path), body, now)
+	}
+}
+
+func TestService(t *testing.T) {
+	t.Parallel()
+
+	t.Run("sends the body of signed requests to the workflow's triggers", func(t *testing.T) {
+		s, server, clock := newTestServer(t)
+		key := newKey(t)
+		ch1, _ := registerTrigger(t, s, "trigger-1", key)
+		ch2, _ := registerTrigger(t, s, "trigger-2", newKey(t), key)
+
+		body := `{"name": "feed", "answer": 123, "big": 123456789012345678901234567890, "price": 1.25, "tags": ["a", {"n": 2}]}`
+		require.Equal(t, http.StatusAccepted, post(t, server, workflowID, body, signWith(key, clock.Now())))
+
+		for _, ch := range []<-chan capabilities.TriggerResponse{ch1, ch2} {
+			response := <-ch
+			require.NoError(t, response.Err)
+			assert.Equal(t, ID, response.Event.TriggerType)
+			assert.NotEmpty(t, response.Event.ID)
+
+			payload := Payload{}
+			require.NoError(t, response.Event.Outputs.UnwrapTo(&payload))
+			assert.Equal(t, publicKey(key), payload.PublicKey)
+			assert.Equal(t, time.Unix(clock.Now().Unix(), 0).UTC().Format(time.RFC3339Nano), payload.Timestamp)
+
+			big, _ := new(big.Int).SetString("123456789012345678901234567890", 10)
+			assert.Equal(t, PayloadBody{
+				"name":   "feed",
+				"answer": int64(123),
+				"big":    big,
+				"price":  decimal.RequireFromString("1.25"),
+				"tags":   []any{"a", map[string]any{"n": int64(2)}},
+			}, payload.Body)
+		}
+	})
+
+	t.Run("rejects requests that are not signed by an allowed key", func(t *testing.T) {
+		s, server, clock := newTestServer(t)
+		key := newKey(t)
+		ch, _ := registerTrigger(t, s, "trigger-1", key)
+
+		for name, test := range map[string]struct {
+			sign     func(r *http.Request, body []byte)
+			expected int
+		}{
+			"unsigned":          {sign: func(*http.Request, []byte) {}, expected: http.StatusUnauthorized},
+			"key not allowed":   {sign: signWith(newKey(t), clock.Now()), expected: http.StatusForbidden},
+			"expired timestamp": {sign: signWith(key, clock.Now().Add(-MaxRequestAge-time.Second)), expected: http.StatusUnauthorized},
+			"future timestamp":  {sign: signWith(key, clock.Now().Add(MaxRequestAge+time.Second)), expected: http.StatusUnauthorized},
+			"wrong body": {
+				sign:     func(r *http.Request, _ []byte) { SignRequest(r, key, workflowID, []byte(`{"a": 2}`), clock.Now()) },
+				expected: http.StatusUnauthorized,
+			},
+			"other workflow": {
+				sign:     func(r *http.Request, body []byte) { SignRequest(r, key, "other-workflow", body, clock.Now()) },
+				expected: http.StatusUnauthorized,
+			},
+			"wrong public key": {
+				sign: func(r *http.Request, body []byte) {
+					SignRequest(r, key, workflowID, body, clock.Now())
+					r.Header.Set(HeaderPublicKey, publicKey(newKey(t)))
+				},
+				expected: http.StatusUnauthorized,
+			},
+		} {
+			t.Run(name, func(t *testing.T) {
+				assert.Equal(t, test.expected, post(t, server, workflowID, `{"a": 1}`, test.sign))
+			})
+		}
+		assert.Empty(t, ch)
+	})
+
+	t.Run("rejects replayed requests", func(t *testing.T) {
+		s, server, clock := newTestServer(t)
+		key := newKey(t)
+		ch, _ := registerTrigger(t, s, "trigger-1", key)
+
+		sign := signWith(key, clock.Now())
+		require.Equal(t, http.StatusAccepted, post(t, server, workflowID, `{"a": 1}`, sign))
+		assert.Equal(t, http.StatusConflict, post(t, server, workflowID, `{"a": 1}`, sign))
+		assert.Len(t, ch, 1)
+
+		// a replay is rejected as expired once the signature is forgotten
+		clock.Advance(MaxRequestAge + time.Second)
+		assert.Equal(t, http.StatusUnauthorized, post(t, server, workflowID, `{"a": 1}`, sign))
+	})
+
+	t.Run("rejects requests signed for another workflow", func(t *testing.T) {
+		s, server, clock := newTestServer(t)
+		key := newKey(t)
+		chA, _ := registerWorkflowTrigger(t, s, "workflow-a", "trigger-a", key)
+		chB, _ := registerWorkflowTrigger(t, s, "workflow-b", "trigger-b", key)
+
+		sign := func(r *http.Request, body []byte) { SignRequest(r, key, "workflow-a", body, clock.Now()) }
+		require.Equal(t, http.StatusAccepted, post(t, server, "workflow-a", `{"a": 1}`, sign))
+		assert.Equal(t, http.StatusUnauthorized, post(t, server, "workflow-b", `{"a": 1}`, sign))
+		assert.Len(t, chA, 1)
+		assert.Empty(t, chB)
+	})
+
+	t.Run("rejects bodies that are not JSON objects", func(t *testing.T) {
+		s, server, clock := newTestServer(t)
+		key := newKey(t)
+		registerTrigger(t, s, "trigger-1", key)
+
+		for _, body := range []string{"", "[1]", `{"a": 1} {}`, "{"} {
+			assert.Equal(t, http.StatusBadRequest, post(t, server, workflowID, body, signWith(key, clock.Now())), body)
+		}
+	})
+
+	t.Run("returns not found for workflows without triggers", func(t *testing.T) {
+		s, server, clock := newTestServer(t)
+		key := newKey(t)
+		_, req := registerTrigger(t, s, "trigger-1", key)
+
+		assert.Equal(t, http.StatusNotFound, post(t, server, "other-workflow", `{}`, signWith(key, clock.Now())))
+
+		require.NoError(t, s.UnregisterTrigger(tests.Context(t), req))
+		assert.Equal(t, http.StatusNotFound, post(t, server, workflowID, `{}`, signWith(key, clock.Now())))
+	})
+
+	t.Run("RegisterTrigger validates the config", func(t *testing.T) {
+		s, _, _ := newTestServer(t)
+		for _, keys := range []any{[]string{}, []string{"not a key"}, "abc"} {
+			config, err := values.NewMap(map[string]any{"allowedKeys": keys})
+			require.NoError(t, err)
+
+			_, err = s.RegisterTrigger(tests.Context(t), capabilities.TriggerRegistrationRequest{TriggerID: "trigger-1", Config: config})
+			assert.Error(t, err)
+		}
+
+		key := newKey(t)
+		_, req := registerTrigger(t, s, "trigger-1", key)
+		_, err := s.RegisterTrigger(tests.Context(t), req)
+		assert.Error(t, err)
+	})
+
+	t.Run("Start listens on the address", func(t *testing.T) {
+		s := NewService(logger.Test(t), clockwork.NewRealClock(), "127.0.0.1:0")
+		require.NoError(t, s.Start(tests.Context(t)))
+		require.NoError(t, s.Ready())
+		require.NoError(t, s.Close())
+	})
+}
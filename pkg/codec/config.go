@@ -25,6 +25,7 @@ import (
 // - address to string -> [AddressBytesToStringModifierConfig]
 // - field wrapper -> [WrapperModifierConfig]
 // - precodec -> [PrecodecModifierConfig]
+// - scale -> [ScaleModifierConfig]
 type ModifiersConfig []ModifierConfig
 
 func (m *ModifiersConfig) UnmarshalJSON(data []byte) error {
@@ -61,6 +62,8 @@ func (m *ModifiersConfig) UnmarshalJSON(data []byte) error {
 			(*m)[i] = &WrapperModifierConfig{}
 		case ModifierPreCodec:
 			(*m)[i] = &PreCodecModifierConfig{}
+		case ModifierScale:
+			(*m)[i] = &ScaleModifierConfig{}
 		default:
 			return fmt.Errorf("%w: unknown modifier type: %s", types.ErrInvalidConfig, mType)
 		}
@@ -96,6 +99,7 @@ const (
 	ModifierExtractProperty ModifierType = "extract property"
 	ModifierAddressToString ModifierType = "address to string"
 	ModifierWrapper         ModifierType = "wrapper"
+	ModifierScale           ModifierType = "scale"
 )
 
 type ModifierConfig interface {
@@ -140,7 +144,9 @@ func (d *DropModifierConfig) ToModifier(_ ...mapstructure.DecodeHookFunc) (Modif
 		fields[upperFirstCharacter(f)] = fmt.Sprintf("dropFieldPrivateName%d", i)
 	}
 
-	return NewRenamer(fields), nil
+	r := NewRenamer(fields).(*renamer)
+	r.drops = true
+	return r, nil
 }
 
 func (d *DropModifierConfig) MarshalJSON() ([]byte, error) {
@@ -393,6 +399,42 @@ func (r *WrapperModifierConfig) MarshalJSON() ([]byte, error) {
 	})
 }
 
+// ScaleModifierConfig converts integer fields on-chain to *decimal.Decimal fields off-chain.
+// Each field is multiplied by ten to the power of its exponent off-chain,
+// and divided by it on-chain, where it's rounded to a whole number using its rounding mode.
+//
+//	Example:
+//
+//	Based on this input struct:
+//		type example struct {
+//			Answer *big.Int
+//		}
+//
+//	And the scale config defined as:
+//		{"Answer": {"Exponent": -8, "Rounding": "half even"}}
+//
+//	Result:
+//		type example struct {
+//			Answer *decimal.Decimal
+//		}
+//
+//	Where an on-chain answer of 123456789 is 1.23456789 off-chain.
+type ScaleModifierConfig struct {
+	Fields map[string]*DecimalScale
+}
+
+func (s *ScaleModifierConfig) ToModifier(_ ...mapstructure.DecodeHookFunc) (Modifier, error) {
+	mapKeyToUpperFirst(s.Fields)
+	return NewScaleModifier(s.Fields), nil
+}
+
+func (s *ScaleModifierConfig) MarshalJSON() ([]byte, error) {
+	return json.Marshal(&modifierMarshaller[ScaleModifierConfig]{
+		Type: ModifierScale,
+		T:    s,
+	})
+}
+
 type typer struct {
 	Type string
 }
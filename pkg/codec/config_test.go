@@ -237,6 +237,9 @@ func TestModifiersConfig(t *testing.T) {
 			&codec.WrapperModifierConfig{
 				Fields: map[string]string{"A": "Z"},
 			},
+			&codec.ScaleModifierConfig{
+				Fields: map[string]*codec.DecimalScale{"T": {Exponent: -8, Rounding: codec.RoundingHalfEven}},
+			},
 		}
 
 		b, err := json.Marshal(&configs)
@@ -0,0 +1,182 @@
+package abi
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/codec/encodings"
+	"github.com/smartcontractkit/chainlink-common/pkg/types"
+)
+
+// NewArray creates a codec for T[k], which is encoded like a tuple of its elements.
+// It is dynamic if its element type is.
+func NewArray(numElements int, underlying encodings.TypeCodec) (encodings.TypeCodec, error) {
+	if numElements < 0 {
+		return nil, fmt.Errorf("%w: number of elements cannot be negative", types.ErrInvalidConfig)
+	}
+
+	e, err := newElement(underlying)
+	if err != nil {
+		return nil, err
+	}
+
+	return &array{numElements: numElements, elm: e}, nil
+}
+
+type array struct {
+	numElements int
+	elm         element
+}
+
+var _ TypeCodec = &array{}
+
+func (a *array) Encode(value any, into []byte) ([]byte, error) {
+	rValue := reflect.ValueOf(value)
+	if kind := rValue.Kind(); kind != reflect.Array && kind != reflect.Slice {
+		return nil, fmt.Errorf("%w: expected array or slice but got %s", types.ErrNotASlice, kind)
+	}
+
+	if rValue.Len() != a.numElements {
+		return nil, fmt.Errorf("%w: expected %v elements, got %v", types.ErrSliceWrongLen, a.numElements, rValue.Len())
+	}
+
+	return encodeElements(a.elm, rValue, into)
+}
+
+func (a *array) Decode(encoded []byte) (any, []byte, error) {
+	return decodeElements(a.elm, encoded, reflect.New(a.GetType()).Elem())
+}
+
+func (a *array) GetType() reflect.Type {
+	return reflect.ArrayOf(a.numElements, a.elm.codec.GetType())
+}
+
+func (a *array) Size(_ int) (int, error) {
+	return a.FixedSize()
+}
+
+func (a *array) FixedSize() (int, error) {
+	if a.elm.dynamic {
+		return 0, fmt.Errorf("%w: arrays of dynamic types do not have a fixed size", types.ErrInvalidType)
+	}
+
+	fs, err := a.elm.codec.FixedSize()
+	if err != nil {
+		return 0, err
+	}
+	return fs * a.numElements, nil
+}
+
+func (a *array) Dynamic() bool {
+	return a.elm.dynamic
+}
+
+// NewSlice creates a codec for T[], which is encoded as its number of elements followed by its elements encoded
+// like a tuple.
+func NewSlice(underlying encodings.TypeCodec) (encodings.TypeCodec, error) {
+	e, err := newElement(underlying)
+	if err != nil {
+		return nil, err
+	}
+
+	return &slice{elm: e}, nil
+}
+
+type slice struct {
+	elm element
+}
+
+var _ TypeCodec = &slice{}
+
+func (s *slice) Encode(value any, into []byte) ([]byte, error) {
+	rValue := reflect.ValueOf(value)
+	if kind := rValue.Kind(); kind != reflect.Array && kind != reflect.Slice {
+		return nil, fmt.Errorf("%w: expected array or slice but got %s", types.ErrNotASlice, kind)
+	}
+
+	return encodeElements(s.elm, rValue, appendLength(into, rValue.Len()))
+}
+
+func (s *slice) Decode(encoded []byte) (any, []byte, error) {
+	numElements, remaining, err := decodeLength(encoded)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// check the length against the size of the heads of the elements,
+	// so that a corrupted length can't allocate more than the encoding's size
+	headSize, err := s.elm.headSize()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if headSize > 0 && numElements > len(remaining)/headSize {
+		return nil, nil, fmt.Errorf("%w: not enough bytes to decode %d elements", types.ErrInvalidEncoding, numElements)
+	}
+
+	return decodeElements(s.elm, remaining, reflect.MakeSlice(s.GetType(), numElements, numElements))
+}
+
+func (s *slice) GetType() reflect.Type {
+	return reflect.SliceOf(s.elm.codec.GetType())
+}
+
+func (s *slice) Size(numItems int) (int, error) {
+	if s.elm.dynamic {
+		return 0, fmt.Errorf("%w: slices of dynamic types are not sized with number of reports", types.ErrInvalidType)
+	}
+
+	elemSize, err := s.elm.codec.FixedSize()
+	if err != nil {
+		return 0, err
+	}
+
+	return wordSize + elemSize*numItems, nil
+}
+
+func (s *slice) FixedSize() (int, error) {
+	return 0, fmt.Errorf("%w: slices are not fixed size", types.ErrInvalidType)
+}
+
+func (s *slice) Dynamic() bool {
+	return true
+}
+
+// encodeElements encodes the elements of an array or slice, in sequence if they're static, or as a tuple otherwise.
+func encodeElements(e element, rValue reflect.Value, into []byte) ([]byte, error) {
+	if !e.dynamic {
+		return encodings.EncodeEach(rValue, into, e.codec)
+	}
+
+	elements := make([]element, rValue.Len())
+	values := make([]any, rValue.Len())
+	for i := range elements {
+		elements[i] = e
+		values[i] = rValue.Index(i).Interface()
+	}
+
+	return encodeTuple(elements, values, into)
+}
+
+// decodeElements decodes the elements encoded by encodeElements into an array or slice of the right length.
+func decodeElements(e element, encoded []byte, into reflect.Value) (any, []byte, error) {
+	if !e.dynamic {
+		return encodings.DecodeEach(encoded, into, into.Len(), e.codec)
+	}
+
+	elements := make([]element, into.Len())
+	for i := range elements {
+		elements[i] = e
+	}
+
+	values, remaining, err := decodeTuple(elements, encoded)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	for i, value := range values {
+		into.Index(i).Set(reflect.ValueOf(value))
+	}
+
+	return into.Interface(), remaining, nil
+}
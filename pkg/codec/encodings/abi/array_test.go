@@ -0,0 +1,107 @@
+package abi_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/codec/encodings/abi"
+	"github.com/smartcontractkit/chainlink-common/pkg/codec/encodings/testutils"
+	"github.com/smartcontractkit/chainlink-common/pkg/types"
+)
+
+func TestArray(t *testing.T) {
+	t.Parallel()
+	static, err := abi.NewArray(3, builder.Uint16())
+	require.NoError(t, err)
+	str, err := builder.String(10)
+	require.NoError(t, err)
+	dynamic, err := abi.NewArray(2, str)
+	require.NoError(t, err)
+
+	t.Run("NewArray returns an error if the codec isn't an ABI codec", func(t *testing.T) {
+		_, err := abi.NewArray(1, &testutils.TestTypeCodec{Value: 1})
+		assert.True(t, errors.Is(err, types.ErrInvalidConfig))
+	})
+
+	t.Run("Static arrays are encoded in place", func(t *testing.T) {
+		encoded, err := static.Encode([3]uint16{1, 2, 3}, nil)
+		require.NoError(t, err)
+		assert.Equal(t, words(t, "1", "2", "3"), encoded)
+
+		decoded, remaining, err := static.Decode(encoded)
+		require.NoError(t, err)
+		assert.Equal(t, [3]uint16{1, 2, 3}, decoded)
+		assert.Empty(t, remaining)
+
+		size, err := static.FixedSize()
+		require.NoError(t, err)
+		assert.Equal(t, 96, size)
+	})
+
+	t.Run("Arrays of dynamic types are encoded like tuples", func(t *testing.T) {
+		encoded, err := dynamic.Encode([]string{"a", "b"}, nil)
+		require.NoError(t, err)
+		assert.Equal(t, words(t,
+			"40",
+			"80",
+			"1",
+			"6100000000000000000000000000000000000000000000000000000000000000",
+			"1",
+			"6200000000000000000000000000000000000000000000000000000000000000",
+		), encoded)
+
+		decoded, _, err := dynamic.Decode(encoded)
+		require.NoError(t, err)
+		assert.Equal(t, [2]string{"a", "b"}, decoded)
+
+		_, err = dynamic.FixedSize()
+		assert.True(t, errors.Is(err, types.ErrInvalidType))
+	})
+
+	t.Run("Encode returns an error if the number of elements is wrong", func(t *testing.T) {
+		_, err := static.Encode([]uint16{1, 2}, nil)
+		assert.True(t, errors.Is(err, types.ErrSliceWrongLen))
+	})
+
+	t.Run("GetType returns an array", func(t *testing.T) {
+		assert.Equal(t, reflect.TypeOf([3]uint16{}), static.GetType())
+	})
+}
+
+func TestSlice(t *testing.T) {
+	t.Parallel()
+	slice, err := abi.NewSlice(builder.Int32())
+	require.NoError(t, err)
+
+	t.Run("Empty slices are only their length", func(t *testing.T) {
+		encoded, err := slice.Encode([]int32{}, nil)
+		require.NoError(t, err)
+		assert.Equal(t, words(t, "0"), encoded)
+
+		decoded, _, err := slice.Decode(encoded)
+		require.NoError(t, err)
+		assert.Equal(t, []int32{}, decoded)
+	})
+
+	t.Run("Size is the length and numItems elements", func(t *testing.T) {
+		size, err := slice.Size(4)
+		require.NoError(t, err)
+		assert.Equal(t, 5*32, size)
+
+		_, err = slice.FixedSize()
+		assert.True(t, errors.Is(err, types.ErrInvalidType))
+	})
+
+	t.Run("Decode returns an error if the length is larger than the encoding", func(t *testing.T) {
+		_, _, err := slice.Decode(words(t, "ffffffff", "1"))
+		assert.True(t, errors.Is(err, types.ErrInvalidEncoding))
+	})
+
+	t.Run("GetType returns a slice", func(t *testing.T) {
+		assert.Equal(t, reflect.TypeOf([]int32{}), slice.GetType())
+	})
+}
@@ -0,0 +1,124 @@
+// Package abi encodes values in the layout of the Solidity contract ABI, where every value is padded to 32-byte
+// words and dynamic types are encoded in the tail of their enclosing tuple, at an offset stored in its head.
+// See https://docs.soliditylang.org/en/latest/abi-spec.html#formal-specification-of-the-encoding
+package abi
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+
+	"github.com/smartcontractkit/libocr/commontypes"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/codec/encodings"
+	"github.com/smartcontractkit/chainlink-common/pkg/types"
+)
+
+// TypeCodec is a TypeCodec for a type of the ABI.
+// The tuple, array and slice codecs of this package can only be composed of TypeCodecs, since they need to know
+// which of their elements are dynamic.
+type TypeCodec interface {
+	encodings.TypeCodec
+
+	// Dynamic returns true if the type is dynamic in the ABI, such as bytes, string and T[],
+	// or a tuple or array containing a dynamic type.
+	Dynamic() bool
+}
+
+// Builder returns an encodings.Builder for ABI types.
+// The ABI has no floating point types, so floats are encoded as the uint32 or uint64 of their IEEE 754 bits.
+func Builder() encodings.Builder {
+	return builder{}
+}
+
+type builder struct{}
+
+func (builder) Bool() encodings.TypeCodec {
+	return boolCodec{}
+}
+
+func (builder) Int8() encodings.TypeCodec {
+	return newInt[int8](8)
+}
+
+func (builder) Int16() encodings.TypeCodec {
+	return newInt[int16](16)
+}
+
+func (builder) Int32() encodings.TypeCodec {
+	return newInt[int32](32)
+}
+
+func (builder) Int64() encodings.TypeCodec {
+	return newInt[int64](64)
+}
+
+func (builder) Uint8() encodings.TypeCodec {
+	return newInt[uint8](8)
+}
+
+func (builder) Uint16() encodings.TypeCodec {
+	return newInt[uint16](16)
+}
+
+func (builder) Uint32() encodings.TypeCodec {
+	return newInt[uint32](32)
+}
+
+func (builder) Uint64() encodings.TypeCodec {
+	return newInt[uint64](64)
+}
+
+func (builder) String(maxLen uint) (encodings.TypeCodec, error) {
+	return &dynamicBytes{maxLength: maxLen, tpe: reflect.TypeOf("")}, nil
+}
+
+func (builder) Float32() encodings.TypeCodec {
+	return &floatCodec[float32, uint32]{
+		bits:     newInt[uint32](32),
+		toBits:   math.Float32bits,
+		fromBits: math.Float32frombits,
+	}
+}
+
+func (builder) Float64() encodings.TypeCodec {
+	return &floatCodec[float64, uint64]{
+		bits:     newInt[uint64](64),
+		toBits:   math.Float64bits,
+		fromBits: math.Float64frombits,
+	}
+}
+
+func (builder) OracleID() encodings.TypeCodec {
+	return newInt[commontypes.OracleID](8)
+}
+
+// Int returns a codec encoding an int as an ABI int of the given number of bytes.
+func (builder) Int(bytes uint) (encodings.TypeCodec, error) {
+	if err := validateIntBytes(bytes); err != nil {
+		return nil, err
+	}
+	return newInt[int](int(bytes) * 8), nil
+}
+
+// Uint returns a codec encoding a uint as an ABI uint of the given number of bytes.
+func (builder) Uint(bytes uint) (encodings.TypeCodec, error) {
+	if err := validateIntBytes(bytes); err != nil {
+		return nil, err
+	}
+	return newInt[uint](int(bytes) * 8), nil
+}
+
+func (builder) BigInt(bytes uint, signed bool) (encodings.TypeCodec, error) {
+	if err := validateIntBytes(bytes); err != nil {
+		return nil, err
+	}
+	return &bigInt{bits: int(bytes) * 8, signed: signed}, nil
+}
+
+func validateIntBytes(bytes uint) error {
+	if bytes == 0 || bytes > wordSize {
+		return fmt.Errorf("%w: numBytes is %v, but must be between 1 and %v", types.ErrInvalidConfig, bytes, wordSize)
+	}
+	return nil
+}
@@ -0,0 +1,163 @@
+package abi
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/codec/encodings"
+	"github.com/smartcontractkit/chainlink-common/pkg/types"
+)
+
+// FixedBytes returns a codec for bytesN, encoding a [N]byte padded with zeros on the right to a word.
+func FixedBytes(n uint) (encodings.TypeCodec, error) {
+	if n == 0 || n > wordSize {
+		return nil, fmt.Errorf("%w: bytesN must have between 1 and %v bytes, got %v", types.ErrInvalidConfig, wordSize, n)
+	}
+
+	return &fixedBytes{tpe: reflect.ArrayOf(int(n), reflect.TypeOf(byte(0)))}, nil
+}
+
+// Address returns a codec for address, encoding a [20]byte padded with zeros on the left to a word, like a uint160.
+func Address() encodings.TypeCodec {
+	return &fixedBytes{tpe: reflect.TypeOf([20]byte{}), padLeft: true}
+}
+
+type fixedBytes struct {
+	tpe     reflect.Type
+	padLeft bool
+}
+
+var _ TypeCodec = &fixedBytes{}
+
+func (f *fixedBytes) Encode(value any, into []byte) ([]byte, error) {
+	rValue := reflect.ValueOf(value)
+	if kind := rValue.Kind(); (kind != reflect.Array && kind != reflect.Slice) || rValue.Type().Elem().Kind() != reflect.Uint8 {
+		return nil, fmt.Errorf("%w: expected %v, got %T", types.ErrInvalidType, f.tpe, value)
+	}
+
+	if rValue.Len() != f.tpe.Len() {
+		return nil, fmt.Errorf("%w: expected %v bytes, got %v", types.ErrSliceWrongLen, f.tpe.Len(), rValue.Len())
+	}
+
+	word := make([]byte, wordSize)
+	reflect.Copy(reflect.ValueOf(word[f.start():]), rValue)
+	return append(into, word...), nil
+}
+
+func (f *fixedBytes) Decode(encoded []byte) (any, []byte, error) {
+	if len(encoded) < wordSize {
+		return nil, nil, fmt.Errorf("%w: not enough bytes to decode bytes%d", types.ErrInvalidEncoding, f.tpe.Len())
+	}
+
+	n, start := f.tpe.Len(), f.start()
+	if !isZero(encoded[:start]) || !isZero(encoded[start+n:wordSize]) {
+		return nil, nil, fmt.Errorf("%w: %v is not padded with zeros", types.ErrInvalidEncoding, f.tpe)
+	}
+
+	rArray := reflect.New(f.tpe).Elem()
+	reflect.Copy(rArray, reflect.ValueOf(encoded[start:start+n]))
+	return rArray.Interface(), encoded[wordSize:], nil
+}
+
+// start returns the index of the bytes in their word.
+func (f *fixedBytes) start() int {
+	if f.padLeft {
+		return wordSize - f.tpe.Len()
+	}
+	return 0
+}
+
+func (f *fixedBytes) GetType() reflect.Type {
+	return f.tpe
+}
+
+func (f *fixedBytes) Size(_ int) (int, error) {
+	return wordSize, nil
+}
+
+func (f *fixedBytes) FixedSize() (int, error) {
+	return wordSize, nil
+}
+
+func (f *fixedBytes) Dynamic() bool {
+	return false
+}
+
+// Bytes returns a codec for bytes, encoding a []byte of at most maxLen bytes as its length followed by
+// the bytes padded with zeros on the right to a multiple of a word.
+func Bytes(maxLen uint) encodings.TypeCodec {
+	return &dynamicBytes{maxLength: maxLen, tpe: reflect.TypeOf([]byte{})}
+}
+
+// dynamicBytes encodes bytes and string, which only differ in their Go type.
+type dynamicBytes struct {
+	maxLength uint
+	tpe       reflect.Type
+}
+
+var _ TypeCodec = &dynamicBytes{}
+
+func (d *dynamicBytes) Encode(value any, into []byte) ([]byte, error) {
+	if reflect.TypeOf(value) != d.tpe {
+		return nil, fmt.Errorf("%w: expected %v, got %T", types.ErrInvalidType, d.tpe, value)
+	}
+
+	var raw []byte
+	if str, ok := value.(string); ok {
+		raw = []byte(str)
+	} else {
+		raw = value.([]byte)
+	}
+
+	if uint(len(raw)) > d.maxLength {
+		return nil, fmt.Errorf("%w: %v longer than max length %d", types.ErrInvalidType, d.tpe, d.maxLength)
+	}
+
+	into = appendLength(into, len(raw))
+	into = append(into, raw...)
+	return append(into, make([]byte, paddedLen(len(raw))-len(raw))...), nil
+}
+
+func (d *dynamicBytes) Decode(encoded []byte) (any, []byte, error) {
+	n, remaining, err := decodeLength(encoded)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if uint(n) > d.maxLength {
+		return nil, nil, fmt.Errorf("%w: %v longer than max length %d", types.ErrInvalidEncoding, d.tpe, d.maxLength)
+	}
+
+	padded := paddedLen(n)
+	if len(remaining) < padded {
+		return nil, nil, fmt.Errorf("%w: not enough bytes to decode %v of length %d", types.ErrInvalidEncoding, d.tpe, n)
+	}
+
+	if !isZero(remaining[n:padded]) {
+		return nil, nil, fmt.Errorf("%w: %v is not padded with zeros", types.ErrInvalidEncoding, d.tpe)
+	}
+
+	raw := make([]byte, n)
+	copy(raw, remaining[:n])
+	if d.tpe.Kind() == reflect.String {
+		return string(raw), remaining[padded:], nil
+	}
+
+	return raw, remaining[padded:], nil
+}
+
+func (d *dynamicBytes) GetType() reflect.Type {
+	return d.tpe
+}
+
+func (d *dynamicBytes) Size(_ int) (int, error) {
+	return 0, fmt.Errorf("%w: %v is not sized with number of reports", types.ErrInvalidType, d.tpe)
+}
+
+func (d *dynamicBytes) FixedSize() (int, error) {
+	return 0, fmt.Errorf("%w: %v does not have a fixed size", types.ErrInvalidType, d.tpe)
+}
+
+func (d *dynamicBytes) Dynamic() bool {
+	return true
+}
@@ -0,0 +1,138 @@
+package abi_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/codec/encodings/abi"
+	"github.com/smartcontractkit/chainlink-common/pkg/types"
+)
+
+func TestFixedBytes(t *testing.T) {
+	t.Parallel()
+	bytes10, err := abi.FixedBytes(10)
+	require.NoError(t, err)
+
+	t.Run("FixedBytes returns an error if the number of bytes is invalid", func(t *testing.T) {
+		_, err := abi.FixedBytes(0)
+		assert.True(t, errors.Is(err, types.ErrInvalidConfig))
+		_, err = abi.FixedBytes(33)
+		assert.True(t, errors.Is(err, types.ErrInvalidConfig))
+	})
+
+	t.Run("Encode pads bytes on the right", func(t *testing.T) {
+		encoded, err := bytes10.Encode([10]byte([]byte("1234567890")), nil)
+		require.NoError(t, err)
+		assert.Equal(t, words(t, "3132333435363738393000000000000000000000000000000000000000000000"), encoded)
+
+		decoded, remaining, err := bytes10.Decode(encoded)
+		require.NoError(t, err)
+		assert.Equal(t, [10]byte([]byte("1234567890")), decoded)
+		assert.Empty(t, remaining)
+	})
+
+	t.Run("Encode accepts slices of the right length", func(t *testing.T) {
+		encoded, err := bytes10.Encode([]byte("1234567890"), nil)
+		require.NoError(t, err)
+		assert.Len(t, encoded, 32)
+
+		_, err = bytes10.Encode([]byte("123"), nil)
+		assert.True(t, errors.Is(err, types.ErrSliceWrongLen))
+	})
+
+	t.Run("Decode returns an error if the padding is dirty", func(t *testing.T) {
+		_, _, err := bytes10.Decode(words(t, "3132333435363738393031000000000000000000000000000000000000000000"))
+		assert.True(t, errors.Is(err, types.ErrInvalidEncoding))
+	})
+
+	t.Run("GetType returns an array of bytes", func(t *testing.T) {
+		assert.Equal(t, reflect.TypeOf([10]byte{}), bytes10.GetType())
+	})
+}
+
+func TestAddress(t *testing.T) {
+	t.Parallel()
+	address := abi.Address()
+	raw := [20]byte([]byte("12345678901234567890"))
+
+	t.Run("Encode pads bytes on the left", func(t *testing.T) {
+		encoded, err := address.Encode(raw, nil)
+		require.NoError(t, err)
+		assert.Equal(t, words(t, "0000000000000000000000003132333435363738393031323334353637383930"), encoded)
+
+		decoded, remaining, err := address.Decode(encoded)
+		require.NoError(t, err)
+		assert.Equal(t, raw, decoded)
+		assert.Empty(t, remaining)
+	})
+
+	t.Run("Decode returns an error if the padding is dirty", func(t *testing.T) {
+		_, _, err := address.Decode(words(t, "0000000000000000000000013132333435363738393031323334353637383930"))
+		assert.True(t, errors.Is(err, types.ErrInvalidEncoding))
+	})
+}
+
+func TestDynamicBytes(t *testing.T) {
+	t.Parallel()
+	bytesCodec := abi.Bytes(64)
+	stringCodec, err := builder.String(64)
+	require.NoError(t, err)
+
+	t.Run("Encode writes the length and the padded bytes", func(t *testing.T) {
+		expected := words(t, "d", "48656c6c6f2c20776f726c642100000000000000000000000000000000000000")
+
+		encoded, err := bytesCodec.Encode([]byte("Hello, world!"), nil)
+		require.NoError(t, err)
+		assert.Equal(t, expected, encoded)
+
+		encoded, err = stringCodec.Encode("Hello, world!", nil)
+		require.NoError(t, err)
+		assert.Equal(t, expected, encoded)
+
+		decoded, remaining, err := stringCodec.Decode(append(encoded, 1, 2, 3))
+		require.NoError(t, err)
+		assert.Equal(t, "Hello, world!", decoded)
+		assert.Equal(t, []byte{1, 2, 3}, remaining)
+	})
+
+	t.Run("Empty values are only their length", func(t *testing.T) {
+		encoded, err := bytesCodec.Encode([]byte{}, nil)
+		require.NoError(t, err)
+		assert.Equal(t, words(t, "0"), encoded)
+
+		decoded, _, err := bytesCodec.Decode(encoded)
+		require.NoError(t, err)
+		assert.Equal(t, []byte{}, decoded)
+	})
+
+	t.Run("Encode returns an error if the value is too long", func(t *testing.T) {
+		_, err := stringCodec.Encode(string(make([]byte, 65)), nil)
+		assert.True(t, errors.Is(err, types.ErrInvalidType))
+	})
+
+	t.Run("Encode returns an error if the type is wrong", func(t *testing.T) {
+		_, err := stringCodec.Encode([]byte("foo"), nil)
+		assert.True(t, errors.Is(err, types.ErrInvalidType))
+	})
+
+	t.Run("Decode returns an error if the value is too long", func(t *testing.T) {
+		_, _, err := bytesCodec.Decode(append(words(t, "41"), make([]byte, 96)...))
+		assert.True(t, errors.Is(err, types.ErrInvalidEncoding))
+	})
+
+	t.Run("Decode returns an error when there are not enough bytes", func(t *testing.T) {
+		_, _, err := bytesCodec.Decode(append(words(t, "21"), make([]byte, 32)...))
+		assert.True(t, errors.Is(err, types.ErrInvalidEncoding))
+	})
+
+	t.Run("Size and FixedSize return an error", func(t *testing.T) {
+		_, err := stringCodec.Size(1)
+		assert.True(t, errors.Is(err, types.ErrInvalidType))
+		_, err = stringCodec.FixedSize()
+		assert.True(t, errors.Is(err, types.ErrInvalidType))
+	})
+}
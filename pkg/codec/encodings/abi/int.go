@@ -0,0 +1,273 @@
+package abi
+
+import (
+	"fmt"
+	"math/big"
+	"reflect"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/types"
+)
+
+// bigInt encodes a *big.Int as an ABI intN or uintN in a word, in two's complement for negative values.
+type bigInt struct {
+	bits   int
+	signed bool
+}
+
+var _ TypeCodec = &bigInt{}
+
+func (i *bigInt) Encode(value any, into []byte) ([]byte, error) {
+	bi, ok := value.(*big.Int)
+	if !ok {
+		return nil, fmt.Errorf("%w: expected big.Int, got %T", types.ErrInvalidType, value)
+	}
+
+	return i.encode(bi, into)
+}
+
+func (i *bigInt) encode(bi *big.Int, into []byte) ([]byte, error) {
+	if !i.fits(bi) {
+		return nil, fmt.Errorf("%w: %v doesn't fit into an %s", types.ErrInvalidType, bi, i)
+	}
+
+	word := make([]byte, wordSize)
+	if bi.Sign() < 0 {
+		new(big.Int).Add(bi, twoTo256).FillBytes(word)
+	} else {
+		bi.FillBytes(word)
+	}
+
+	return append(into, word...), nil
+}
+
+func (i *bigInt) Decode(encoded []byte) (any, []byte, error) {
+	bi, remaining, err := i.decode(encoded)
+	if err != nil {
+		return nil, nil, err
+	}
+	return bi, remaining, nil
+}
+
+// decode decodes a word, which must be the padded encoding of a value in range,
+// as values with dirty padding are rejected by Solidity too.
+func (i *bigInt) decode(encoded []byte) (*big.Int, []byte, error) {
+	if len(encoded) < wordSize {
+		return nil, nil, fmt.Errorf("%w: not enough bytes to decode %s", types.ErrInvalidEncoding, i)
+	}
+
+	bi := new(big.Int).SetBytes(encoded[:wordSize])
+	if i.signed && encoded[0]&0x80 != 0 {
+		bi.Sub(bi, twoTo256)
+	}
+
+	if !i.fits(bi) {
+		return nil, nil, fmt.Errorf("%w: %v doesn't fit into an %s", types.ErrInvalidEncoding, bi, i)
+	}
+
+	return bi, encoded[wordSize:], nil
+}
+
+func (i *bigInt) fits(bi *big.Int) bool {
+	if !i.signed {
+		return bi.Sign() >= 0 && bi.BitLen() <= i.bits
+	}
+
+	if bi.Sign() >= 0 {
+		return bi.BitLen() < i.bits
+	}
+
+	// -2^(bits-1) is the smallest value that fits
+	minValue := new(big.Int).Neg(new(big.Int).Lsh(big.NewInt(1), uint(i.bits-1)))
+	return bi.Cmp(minValue) >= 0
+}
+
+func (i *bigInt) String() string {
+	if i.signed {
+		return fmt.Sprintf("int%d", i.bits)
+	}
+	return fmt.Sprintf("uint%d", i.bits)
+}
+
+func (i *bigInt) GetType() reflect.Type {
+	return reflect.TypeOf((*big.Int)(nil))
+}
+
+func (i *bigInt) Size(_ int) (int, error) {
+	return wordSize, nil
+}
+
+func (i *bigInt) FixedSize() (int, error) {
+	return wordSize, nil
+}
+
+func (i *bigInt) Dynamic() bool {
+	return false
+}
+
+type integer interface {
+	~int8 | ~int16 | ~int32 | ~int64 | ~int | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uint
+}
+
+// intCodec encodes a Go integer as an ABI integer of the given number of bits,
+// which is signed if the Go integer is.
+type intCodec[T integer] struct {
+	word bigInt
+}
+
+var _ TypeCodec = &intCodec[int]{}
+
+func newInt[T integer](bits int) *intCodec[T] {
+	var zero T
+	return &intCodec[T]{word: bigInt{bits: bits, signed: zero-1 < zero}}
+}
+
+func (i *intCodec[T]) Encode(value any, into []byte) ([]byte, error) {
+	v, ok := value.(T)
+	if !ok {
+		return nil, fmt.Errorf("%w: %T is not an %v", types.ErrInvalidType, value, i.GetType())
+	}
+
+	return i.word.encode(i.toBig(v), into)
+}
+
+func (i *intCodec[T]) Decode(encoded []byte) (any, []byte, error) {
+	bi, remaining, err := i.word.decode(encoded)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	v, ok := i.fromBig(bi)
+	if !ok {
+		return nil, nil, fmt.Errorf("%w: %v doesn't fit into an %v", types.ErrInvalidEncoding, bi, i.GetType())
+	}
+
+	return v, remaining, nil
+}
+
+func (i *intCodec[T]) toBig(v T) *big.Int {
+	if i.word.signed {
+		return big.NewInt(int64(v))
+	}
+	return new(big.Int).SetUint64(uint64(v))
+}
+
+func (i *intCodec[T]) fromBig(bi *big.Int) (T, bool) {
+	var v T
+	switch {
+	case bi.IsInt64():
+		v = T(bi.Int64())
+	case bi.IsUint64():
+		v = T(bi.Uint64())
+	default:
+		return v, false
+	}
+
+	return v, i.toBig(v).Cmp(bi) == 0
+}
+
+func (i *intCodec[T]) GetType() reflect.Type {
+	return reflect.TypeOf(T(0))
+}
+
+func (i *intCodec[T]) Size(_ int) (int, error) {
+	return wordSize, nil
+}
+
+func (i *intCodec[T]) FixedSize() (int, error) {
+	return wordSize, nil
+}
+
+func (i *intCodec[T]) Dynamic() bool {
+	return false
+}
+
+// floatCodec encodes a float as the unsigned integer of its IEEE 754 bits.
+type floatCodec[F float32 | float64, U uint32 | uint64] struct {
+	bits     *intCodec[U]
+	toBits   func(F) U
+	fromBits func(U) F
+}
+
+var _ TypeCodec = &floatCodec[float32, uint32]{}
+
+func (f *floatCodec[F, U]) Encode(value any, into []byte) ([]byte, error) {
+	v, ok := value.(F)
+	if !ok {
+		return nil, fmt.Errorf("%w: %T is not a %v", types.ErrInvalidType, value, f.GetType())
+	}
+
+	return f.bits.Encode(f.toBits(v), into)
+}
+
+func (f *floatCodec[F, U]) Decode(encoded []byte) (any, []byte, error) {
+	bits, remaining, err := f.bits.Decode(encoded)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return f.fromBits(bits.(U)), remaining, nil
+}
+
+func (f *floatCodec[F, U]) GetType() reflect.Type {
+	return reflect.TypeOf(F(0))
+}
+
+func (f *floatCodec[F, U]) Size(_ int) (int, error) {
+	return wordSize, nil
+}
+
+func (f *floatCodec[F, U]) FixedSize() (int, error) {
+	return wordSize, nil
+}
+
+func (f *floatCodec[F, U]) Dynamic() bool {
+	return false
+}
+
+// boolCodec encodes a bool as a uint8 holding 0 or 1.
+type boolCodec struct{}
+
+var _ TypeCodec = boolCodec{}
+
+func (boolCodec) Encode(value any, into []byte) ([]byte, error) {
+	b, ok := value.(bool)
+	if !ok {
+		return nil, fmt.Errorf("%w: expected bool, got %T", types.ErrInvalidType, value)
+	}
+
+	word := make([]byte, wordSize)
+	if b {
+		word[wordSize-1] = 1
+	}
+
+	return append(into, word...), nil
+}
+
+func (boolCodec) Decode(encoded []byte) (any, []byte, error) {
+	if len(encoded) < wordSize {
+		return nil, nil, fmt.Errorf("%w: not enough bytes to decode bool", types.ErrInvalidEncoding)
+	}
+
+	word := encoded[:wordSize]
+	if !isZero(word[:wordSize-1]) || word[wordSize-1] > 1 {
+		return nil, nil, fmt.Errorf("%w: %x is not a bool", types.ErrInvalidEncoding, word)
+	}
+
+	return word[wordSize-1] == 1, encoded[wordSize:], nil
+}
+
+func (boolCodec) GetType() reflect.Type {
+	return reflect.TypeOf(true)
+}
+
+func (boolCodec) Size(_ int) (int, error) {
+	return wordSize, nil
+}
+
+func (boolCodec) FixedSize() (int, error) {
+	return wordSize, nil
+}
+
+func (boolCodec) Dynamic() bool {
+	return false
+}
@@ -0,0 +1,225 @@
+package abi_test
+
+import (
+	"encoding/hex"
+	"errors"
+	"math"
+	"math/big"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/smartcontractkit/libocr/commontypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/codec/encodings"
+	"github.com/smartcontractkit/chainlink-common/pkg/codec/encodings/abi"
+	"github.com/smartcontractkit/chainlink-common/pkg/types"
+)
+
+var builder = abi.Builder()
+
+// words decodes hex words, which are left padded with zeros to a full word.
+func words(t *testing.T, hexWords ...string) []byte {
+	var encoded []byte
+	for _, w := range hexWords {
+		b, err := hex.DecodeString(strings.Repeat("0", 64-len(w)) + w)
+		require.NoError(t, err)
+		encoded = append(encoded, b...)
+	}
+	return encoded
+}
+
+func TestInts(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		codec   encodings.TypeCodec
+		value   any
+		encoded string
+	}{
+		{"int8 positive", builder.Int8(), int8(69), "45"},
+		{"int8 negative", builder.Int8(), int8(-1), strings.Repeat("ff", 32)},
+		{"int16 min", builder.Int16(), int16(math.MinInt16), strings.Repeat("ff", 30) + "8000"},
+		{"int32", builder.Int32(), int32(-2), strings.Repeat("ff", 31) + "fe"},
+		{"int64 max", builder.Int64(), int64(math.MaxInt64), "7fffffffffffffff"},
+		{"uint8", builder.Uint8(), uint8(255), "ff"},
+		{"uint16", builder.Uint16(), uint16(0x456), "456"},
+		{"uint32", builder.Uint32(), uint32(69), "45"},
+		{"uint64 max", builder.Uint64(), uint64(math.MaxUint64), "ffffffffffffffff"},
+		{"OracleID", builder.OracleID(), commontypes.OracleID(4), "4"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			expected := words(t, test.encoded)
+
+			encoded, err := test.codec.Encode(test.value, nil)
+			require.NoError(t, err)
+			assert.Equal(t, expected, encoded)
+
+			decoded, remaining, err := test.codec.Decode(append(encoded, 1, 2, 3))
+			require.NoError(t, err)
+			assert.Equal(t, test.value, decoded)
+			assert.Equal(t, []byte{1, 2, 3}, remaining)
+
+			assert.Equal(t, reflect.TypeOf(test.value), test.codec.GetType())
+			size, err := test.codec.Size(100)
+			require.NoError(t, err)
+			assert.Equal(t, 32, size)
+			size, err = test.codec.FixedSize()
+			require.NoError(t, err)
+			assert.Equal(t, 32, size)
+		})
+	}
+
+	t.Run("Encode returns an error if the type is wrong", func(t *testing.T) {
+		_, err := builder.Int32().Encode(int64(1), nil)
+		assert.True(t, errors.Is(err, types.ErrInvalidType))
+	})
+
+	t.Run("Decode returns an error if the value doesn't fit", func(t *testing.T) {
+		_, _, err := builder.Uint8().Decode(words(t, "100"))
+		assert.True(t, errors.Is(err, types.ErrInvalidEncoding))
+
+		// a positive value with the sign bit of an int8 isn't sign-extended
+		_, _, err = builder.Int8().Decode(words(t, "80"))
+		assert.True(t, errors.Is(err, types.ErrInvalidEncoding))
+
+		_, _, err = builder.Uint64().Decode(words(t, strings.Repeat("ff", 32)))
+		assert.True(t, errors.Is(err, types.ErrInvalidEncoding))
+	})
+
+	t.Run("Decode returns an error when there are not enough bytes", func(t *testing.T) {
+		_, _, err := builder.Int64().Decode(make([]byte, 31))
+		assert.True(t, errors.Is(err, types.ErrInvalidEncoding))
+	})
+}
+
+func TestInt(t *testing.T) {
+	t.Parallel()
+	int24, err := builder.Int(3)
+	require.NoError(t, err)
+	uint256, err := builder.Uint(32)
+	require.NoError(t, err)
+
+	t.Run("Int and Uint return an error if the number of bytes is invalid", func(t *testing.T) {
+		_, err := builder.Int(0)
+		assert.True(t, errors.Is(err, types.ErrInvalidConfig))
+		_, err = builder.Uint(33)
+		assert.True(t, errors.Is(err, types.ErrInvalidConfig))
+	})
+
+	t.Run("Encode and Decode work together", func(t *testing.T) {
+		encoded, err := int24.Encode(-8388608, nil)
+		require.NoError(t, err)
+		assert.Equal(t, words(t, strings.Repeat("ff", 29)+"800000"), encoded)
+
+		decoded, _, err := int24.Decode(encoded)
+		require.NoError(t, err)
+		assert.Equal(t, -8388608, decoded)
+
+		encoded, err = uint256.Encode(uint(math.MaxUint64), nil)
+		require.NoError(t, err)
+		decoded, _, err = uint256.Decode(encoded)
+		require.NoError(t, err)
+		assert.Equal(t, uint(math.MaxUint64), decoded)
+	})
+
+	t.Run("Encode returns an error if the value doesn't fit", func(t *testing.T) {
+		_, err := int24.Encode(8388608, nil)
+		assert.True(t, errors.Is(err, types.ErrInvalidType))
+	})
+
+	t.Run("Decode returns an error if the value doesn't fit in a Go int", func(t *testing.T) {
+		_, _, err := uint256.Decode(words(t, "10000000000000000"))
+		assert.True(t, errors.Is(err, types.ErrInvalidEncoding))
+	})
+}
+
+func TestBigInt(t *testing.T) {
+	t.Parallel()
+	int256, err := builder.BigInt(32, true)
+	require.NoError(t, err)
+	uint160, err := builder.BigInt(20, false)
+	require.NoError(t, err)
+
+	t.Run("BigInt returns an error if the number of bytes is invalid", func(t *testing.T) {
+		_, err := builder.BigInt(33, true)
+		assert.True(t, errors.Is(err, types.ErrInvalidConfig))
+	})
+
+	t.Run("Encode and Decode work together", func(t *testing.T) {
+		minInt256 := new(big.Int).Neg(new(big.Int).Lsh(big.NewInt(1), 255))
+		encoded, err := int256.Encode(minInt256, nil)
+		require.NoError(t, err)
+		assert.Equal(t, words(t, "80"+strings.Repeat("00", 31)), encoded)
+
+		decoded, remaining, err := int256.Decode(encoded)
+		require.NoError(t, err)
+		assert.Equal(t, 0, minInt256.Cmp(decoded.(*big.Int)))
+		assert.Empty(t, remaining)
+
+		maxUint160 := new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 160), big.NewInt(1))
+		encoded, err = uint160.Encode(maxUint160, nil)
+		require.NoError(t, err)
+		assert.Equal(t, words(t, strings.Repeat("ff", 20)), encoded)
+	})
+
+	t.Run("Encode returns an error if the value doesn't fit", func(t *testing.T) {
+		_, err := uint160.Encode(new(big.Int).Lsh(big.NewInt(1), 160), nil)
+		assert.True(t, errors.Is(err, types.ErrInvalidType))
+		_, err = uint160.Encode(big.NewInt(-1), nil)
+		assert.True(t, errors.Is(err, types.ErrInvalidType))
+	})
+
+	t.Run("Decode returns an error if the padding is dirty", func(t *testing.T) {
+		_, _, err := uint160.Decode(words(t, "01"+strings.Repeat("00", 20)))
+		assert.True(t, errors.Is(err, types.ErrInvalidEncoding))
+	})
+
+	t.Run("GetType returns *big.Int", func(t *testing.T) {
+		assert.Equal(t, reflect.TypeOf(&big.Int{}), int256.GetType())
+	})
+}
+
+func TestBool(t *testing.T) {
+	t.Parallel()
+	codec := builder.Bool()
+
+	for _, b := range []bool{true, false} {
+		encoded, err := codec.Encode(b, nil)
+		require.NoError(t, err)
+
+		decoded, remaining, err := codec.Decode(encoded)
+		require.NoError(t, err)
+		assert.Equal(t, b, decoded)
+		assert.Empty(t, remaining)
+	}
+
+	encoded, err := codec.Encode(true, nil)
+	require.NoError(t, err)
+	assert.Equal(t, words(t, "1"), encoded)
+
+	_, _, err = codec.Decode(words(t, "2"))
+	assert.True(t, errors.Is(err, types.ErrInvalidEncoding))
+}
+
+func TestFloats(t *testing.T) {
+	t.Parallel()
+
+	encoded, err := builder.Float32().Encode(float32(1.5), nil)
+	require.NoError(t, err)
+	assert.Equal(t, words(t, "3fc00000"), encoded)
+	decoded, _, err := builder.Float32().Decode(encoded)
+	require.NoError(t, err)
+	assert.Equal(t, float32(1.5), decoded)
+
+	encoded, err = builder.Float64().Encode(-2.25, nil)
+	require.NoError(t, err)
+	assert.Equal(t, words(t, "c002000000000000"), encoded)
+	decoded, _, err = builder.Float64().Decode(encoded)
+	require.NoError(t, err)
+	assert.Equal(t, -2.25, decoded)
+}
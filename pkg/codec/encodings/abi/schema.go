@@ -0,0 +1,46 @@
+package abi
+
+import (
+	"reflect"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/codec/encodings"
+)
+
+var (
+	_ encodings.SchemaDescriber = &tuple{}
+	_ encodings.SchemaDescriber = &array{}
+	_ encodings.SchemaDescriber = &slice{}
+)
+
+func (t *tuple) DescribeSchema() *encodings.TypeSchema {
+	schema := describeType(t)
+	schema.Kind = reflect.Struct.String()
+	for i, e := range t.elements {
+		schema.Fields = append(schema.Fields, &encodings.FieldSchema{
+			Name: t.tpe.Elem().Field(i).Name,
+			Type: encodings.DescribeTypeCodec(e.codec),
+		})
+	}
+	return schema
+}
+
+func (a *array) DescribeSchema() *encodings.TypeSchema {
+	schema := describeType(a)
+	schema.Len = a.numElements
+	schema.Elem = encodings.DescribeTypeCodec(a.elm.codec)
+	return schema
+}
+
+func (s *slice) DescribeSchema() *encodings.TypeSchema {
+	schema := describeType(s)
+	schema.Elem = encodings.DescribeTypeCodec(s.elm.codec)
+	return schema
+}
+
+func describeType(c encodings.TypeCodec) *encodings.TypeSchema {
+	schema := &encodings.TypeSchema{GoType: c.GetType().String(), Kind: c.GetType().Kind().String()}
+	if size, err := c.FixedSize(); err == nil {
+		schema.Size = &size
+	}
+	return schema
+}
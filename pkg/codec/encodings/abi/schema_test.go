@@ -0,0 +1,38 @@
+package abi_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/codec/encodings"
+	"github.com/smartcontractkit/chainlink-common/pkg/codec/encodings/abi"
+)
+
+func TestDescribeSchema(t *testing.T) {
+	t.Parallel()
+	array := mustCodec(abi.NewArray(2, builder.Uint8()))
+	slice := mustCodec(abi.NewSlice(builder.Int32()))
+	tuple := newTuple(t, []encodings.NamedTypeCodec{
+		{Name: "Pair", Codec: array},
+		{Name: "Values", Codec: slice},
+	})
+
+	schema := encodings.DescribeTypeCodec(tuple)
+	assert.Equal(t, reflect.Struct.String(), schema.Kind)
+	assert.Nil(t, schema.Size)
+	require.Len(t, schema.Fields, 2)
+
+	pair := schema.Fields[0]
+	assert.Equal(t, "Pair", pair.Name)
+	assert.Equal(t, 2, pair.Type.Len)
+	assert.Equal(t, 64, *pair.Type.Size)
+	assert.Equal(t, 32, *pair.Type.Elem.Size)
+
+	values := schema.Fields[1]
+	assert.Equal(t, "Values", values.Name)
+	assert.Equal(t, reflect.Slice.String(), values.Type.Kind)
+	assert.Equal(t, "int32", values.Type.Elem.GoType)
+}
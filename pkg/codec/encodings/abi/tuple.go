@@ -0,0 +1,240 @@
+package abi
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/codec/encodings"
+	"github.com/smartcontractkit/chainlink-common/pkg/types"
+)
+
+// element is a member of a tuple, or the element of an array or slice.
+type element struct {
+	codec   encodings.TypeCodec
+	dynamic bool
+}
+
+func newElement(tc encodings.TypeCodec) (element, error) {
+	if tc == nil {
+		return element{}, fmt.Errorf("%w: field type cannot be nil", types.ErrInvalidConfig)
+	}
+
+	abiCodec, ok := tc.(TypeCodec)
+	if !ok {
+		return element{}, fmt.Errorf("%w: %T is not an ABI type codec", types.ErrInvalidConfig, tc)
+	}
+
+	return element{codec: tc, dynamic: abiCodec.Dynamic()}, nil
+}
+
+// headSize returns the size of the element in the head of a tuple, where dynamic elements only have their offset.
+func (e element) headSize() (int, error) {
+	if e.dynamic {
+		return wordSize, nil
+	}
+	return e.codec.FixedSize()
+}
+
+// encodeTuple appends the encoding of values as a tuple: the static values and the offsets of the dynamic values
+// in the head, followed by the dynamic values in the tail. Offsets are relative to the start of the tuple.
+func encodeTuple(elements []element, values []any, into []byte) ([]byte, error) {
+	headSize, err := tupleHeadSize(elements)
+	if err != nil {
+		return nil, err
+	}
+
+	head := make([]byte, 0, headSize)
+	var tail []byte
+	for i, e := range elements {
+		if e.dynamic {
+			head = appendLength(head, headSize+len(tail))
+			tail, err = e.codec.Encode(values[i], tail)
+		} else {
+			head, err = e.codec.Encode(values[i], head)
+		}
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	into = append(into, head...)
+	return append(into, tail...), nil
+}
+
+// decodeTuple decodes a tuple encoded by encodeTuple. The tuple ends after its head and the tails of its dynamic
+// values, which is where the remaining bytes start.
+func decodeTuple(elements []element, encoded []byte) ([]any, []byte, error) {
+	headSize, err := tupleHeadSize(elements)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if len(encoded) < headSize {
+		return nil, nil, fmt.Errorf("%w: not enough bytes to decode tuple", types.ErrInvalidEncoding)
+	}
+
+	values := make([]any, len(elements))
+	head := encoded
+	end := headSize
+	for i, e := range elements {
+		if !e.dynamic {
+			if values[i], head, err = e.codec.Decode(head); err != nil {
+				return nil, nil, err
+			}
+			continue
+		}
+
+		var offset int
+		if offset, head, err = decodeLength(head); err != nil {
+			return nil, nil, err
+		}
+
+		if offset < headSize || offset > len(encoded) {
+			return nil, nil, fmt.Errorf("%w: offset %d is out of the tuple", types.ErrInvalidEncoding, offset)
+		}
+
+		var remaining []byte
+		if values[i], remaining, err = e.codec.Decode(encoded[offset:]); err != nil {
+			return nil, nil, err
+		}
+
+		end = max(end, len(encoded)-len(remaining))
+	}
+
+	return values, encoded[end:], nil
+}
+
+func tupleHeadSize(elements []element) (int, error) {
+	size := 0
+	for _, e := range elements {
+		elementSize, err := e.headSize()
+		if err != nil {
+			return 0, err
+		}
+		size += elementSize
+	}
+	return size, nil
+}
+
+// NewTuple creates a codec for a tuple of the fields with the given names and codecs, which must be ABI type codecs.
+// Like encodings.NewStructCodec, it encodes a pointer to a struct, and codecs with non-pointer types in fields are
+// wrapped with encodings.NotNilPointer to verify fields are not defaulted.
+// An encoded tuple is the encoding of the fields as arguments of a function, so a report encoded as a tuple can be
+// decoded in Solidity with abi.decode(report, (field types...)).
+func NewTuple(fields []encodings.NamedTypeCodec) (c encodings.TopLevelCodec, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("%w: %v", types.ErrInvalidConfig, r)
+		}
+	}()
+
+	sfs := make([]reflect.StructField, len(fields))
+	elements := make([]element, len(fields))
+	dynamic := false
+	for i, field := range fields {
+		e, err := newElement(field.Codec)
+		if err != nil {
+			return nil, err
+		}
+
+		ft := e.codec.GetType()
+		if ft.Kind() != reflect.Pointer {
+			e.codec = &encodings.NotNilPointer{Elm: e.codec}
+			ft = reflect.PointerTo(ft)
+		}
+
+		sfs[i] = reflect.StructField{
+			Name: field.Name,
+			Type: ft,
+		}
+		elements[i] = e
+		dynamic = dynamic || e.dynamic
+	}
+
+	return &tuple{
+		elements: elements,
+		dynamic:  dynamic,
+		tpe:      reflect.PointerTo(reflect.StructOf(sfs)),
+	}, nil
+}
+
+type tuple struct {
+	elements []element
+	dynamic  bool
+	tpe      reflect.Type
+}
+
+var _ TypeCodec = &tuple{}
+
+func (t *tuple) Encode(value any, into []byte) ([]byte, error) {
+	rVal := reflect.ValueOf(value)
+	if rVal.Type() != t.tpe {
+		return nil, fmt.Errorf("%w: expected %v, got %T", types.ErrInvalidType, t.tpe, value)
+	}
+
+	rVal = reflect.Indirect(rVal)
+	values := make([]any, len(t.elements))
+	for i := range t.elements {
+		values[i] = rVal.Field(i).Interface()
+	}
+
+	return encodeTuple(t.elements, values, into)
+}
+
+func (t *tuple) Decode(encoded []byte) (any, []byte, error) {
+	values, remaining, err := decodeTuple(t.elements, encoded)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	rVal := reflect.New(t.tpe.Elem())
+	iVal := reflect.Indirect(rVal)
+	for i, value := range values {
+		iVal.Field(i).Set(reflect.ValueOf(value))
+	}
+
+	return rVal.Interface(), remaining, nil
+}
+
+func (t *tuple) GetType() reflect.Type {
+	return t.tpe
+}
+
+func (t *tuple) Size(_ int) (int, error) {
+	return t.FixedSize()
+}
+
+func (t *tuple) FixedSize() (int, error) {
+	if t.dynamic {
+		return 0, fmt.Errorf("%w: tuples with dynamic fields do not have a fixed size", types.ErrInvalidType)
+	}
+	return tupleHeadSize(t.elements)
+}
+
+// SizeAtTopLevel returns the size of the tuple with numItems in each of its fields,
+// where dynamic fields take the size of their offset and of their value.
+func (t *tuple) SizeAtTopLevel(numItems int) (int, error) {
+	size := 0
+	for _, e := range t.elements {
+		if !e.dynamic {
+			fieldSize, err := e.codec.FixedSize()
+			if err != nil {
+				return 0, err
+			}
+			size += fieldSize
+			continue
+		}
+
+		fieldSize, err := e.codec.Size(numItems)
+		if err != nil {
+			return 0, err
+		}
+		size += wordSize + fieldSize
+	}
+	return size, nil
+}
+
+func (t *tuple) Dynamic() bool {
+	return t.dynamic
+}
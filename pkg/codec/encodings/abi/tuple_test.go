@@ -0,0 +1,276 @@
+package abi_test
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/codec/encodings"
+	"github.com/smartcontractkit/chainlink-common/pkg/codec/encodings/abi"
+	"github.com/smartcontractkit/chainlink-common/pkg/codec/encodings/binary"
+	"github.com/smartcontractkit/chainlink-common/pkg/types"
+	"github.com/smartcontractkit/chainlink-common/pkg/utils/tests"
+)
+
+// The vectors are the examples of https://docs.soliditylang.org/en/latest/abi-spec.html#examples,
+// without the function selectors.
+func TestTuple_SpecVectors(t *testing.T) {
+	t.Parallel()
+	uint256, err := builder.Uint(32)
+	require.NoError(t, err)
+	bytes3, err := abi.FixedBytes(3)
+	require.NoError(t, err)
+	bytes10, err := abi.FixedBytes(10)
+	require.NoError(t, err)
+	bytes := abi.Bytes(1024)
+	str, err := builder.String(1024)
+	require.NoError(t, err)
+
+	t.Run("baz(uint32,bool)", func(t *testing.T) {
+		type baz struct {
+			X uint32
+			Y bool
+		}
+
+		tuple := newTuple(t, []encodings.NamedTypeCodec{
+			{Name: "X", Codec: builder.Uint32()},
+			{Name: "Y", Codec: builder.Bool()},
+		})
+
+		assertRoundTrip(t, tuple, baz{X: 69, Y: true}, words(t, "45", "1"))
+
+		size, err := tuple.FixedSize()
+		require.NoError(t, err)
+		assert.Equal(t, 64, size)
+	})
+
+	t.Run("bar(bytes3[2])", func(t *testing.T) {
+		type bar struct {
+			X [2][3]byte
+		}
+
+		tuple := newTuple(t, []encodings.NamedTypeCodec{
+			{Name: "X", Codec: mustCodec(abi.NewArray(2, bytes3))},
+		})
+
+		assertRoundTrip(t, tuple, bar{X: [2][3]byte{[3]byte([]byte("abc")), [3]byte([]byte("def"))}}, words(t,
+			"6162630000000000000000000000000000000000000000000000000000000000",
+			"6465660000000000000000000000000000000000000000000000000000000000",
+		))
+	})
+
+	t.Run("sam(bytes,bool,uint256[])", func(t *testing.T) {
+		type sam struct {
+			X []byte
+			Y bool
+			Z []uint
+		}
+
+		tuple := newTuple(t, []encodings.NamedTypeCodec{
+			{Name: "X", Codec: bytes},
+			{Name: "Y", Codec: builder.Bool()},
+			{Name: "Z", Codec: mustCodec(abi.NewSlice(uint256))},
+		})
+
+		assertRoundTrip(t, tuple, sam{X: []byte("dave"), Y: true, Z: []uint{1, 2, 3}}, words(t,
+			"60",
+			"1",
+			"a0",
+			"4",
+			"6461766500000000000000000000000000000000000000000000000000000000",
+			"3",
+			"1",
+			"2",
+			"3",
+		))
+	})
+
+	t.Run("f(uint256,uint32[],bytes10,bytes)", func(t *testing.T) {
+		type f struct {
+			A *big.Int
+			B []uint32
+			C [10]byte
+			D []byte
+		}
+
+		tuple := newTuple(t, []encodings.NamedTypeCodec{
+			{Name: "A", Codec: mustCodec(builder.BigInt(32, false))},
+			{Name: "B", Codec: mustCodec(abi.NewSlice(builder.Uint32()))},
+			{Name: "C", Codec: bytes10},
+			{Name: "D", Codec: bytes},
+		})
+
+		assertRoundTrip(t, tuple, f{
+			A: big.NewInt(0x123),
+			B: []uint32{0x456, 0x789},
+			C: [10]byte([]byte("1234567890")),
+			D: []byte("Hello, world!"),
+		}, words(t,
+			"123",
+			"80",
+			"3132333435363738393000000000000000000000000000000000000000000000",
+			"e0",
+			"2",
+			"456",
+			"789",
+			"d",
+			"48656c6c6f2c20776f726c642100000000000000000000000000000000000000",
+		))
+	})
+
+	t.Run("g(uint256[][],string[])", func(t *testing.T) {
+		type g struct {
+			X [][]uint
+			Y []string
+		}
+
+		tuple := newTuple(t, []encodings.NamedTypeCodec{
+			{Name: "X", Codec: mustCodec(abi.NewSlice(mustCodec(abi.NewSlice(uint256))))},
+			{Name: "Y", Codec: mustCodec(abi.NewSlice(str))},
+		})
+
+		assertRoundTrip(t, tuple, g{X: [][]uint{{1, 2}, {3}}, Y: []string{"one", "two", "three"}}, words(t,
+			"40",
+			"140",
+			"2",
+			"40",
+			"a0",
+			"2",
+			"1",
+			"2",
+			"1",
+			"3",
+			"3",
+			"60",
+			"a0",
+			"e0",
+			"3",
+			"6f6e650000000000000000000000000000000000000000000000000000000000",
+			"3",
+			"74776f0000000000000000000000000000000000000000000000000000000000",
+			"5",
+			"7468726565000000000000000000000000000000000000000000000000000000",
+		))
+	})
+}
+
+func TestTuple(t *testing.T) {
+	t.Parallel()
+
+	t.Run("NewTuple returns an error if a codec isn't an ABI codec", func(t *testing.T) {
+		_, err := abi.NewTuple([]encodings.NamedTypeCodec{
+			{Name: "Foo", Codec: binary.BigEndian().Uint32()},
+		})
+		assert.True(t, errors.Is(err, types.ErrInvalidConfig))
+	})
+
+	t.Run("NewTuple returns an error if names are repeated", func(t *testing.T) {
+		_, err := abi.NewTuple([]encodings.NamedTypeCodec{
+			{Name: "Foo", Codec: builder.Bool()},
+			{Name: "Foo", Codec: builder.Bool()},
+		})
+		assert.True(t, errors.Is(err, types.ErrInvalidConfig))
+	})
+
+	nested := newTuple(t, []encodings.NamedTypeCodec{
+		{Name: "A", Codec: builder.Uint64()},
+		{Name: "B", Codec: abi.Bytes(64)},
+	})
+	tuple := newTuple(t, []encodings.NamedTypeCodec{
+		{Name: "Nested", Codec: nested},
+		{Name: "Values", Codec: mustCodec(abi.NewSlice(builder.Int64()))},
+		{Name: "Static", Codec: newTuple(t, []encodings.NamedTypeCodec{{Name: "C", Codec: builder.Bool()}})},
+	})
+
+	type report struct {
+		Nested struct {
+			A uint64
+			B []byte
+		}
+		Values []int64
+		Static struct{ C bool }
+	}
+
+	t.Run("Nested dynamic tuples are encoded in the tail", func(t *testing.T) {
+		r := report{Values: []int64{-1}, Static: struct{ C bool }{C: true}}
+		r.Nested.A = 7
+		r.Nested.B = []byte{0xaa}
+
+		assertRoundTrip(t, tuple, r, words(t,
+			"60",
+			"e0",
+			"1",
+			"7",
+			"40",
+			"1",
+			"aa00000000000000000000000000000000000000000000000000000000000000",
+			"1",
+			"ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff",
+		))
+	})
+
+	t.Run("Size is only fixed for static tuples", func(t *testing.T) {
+		_, err := tuple.FixedSize()
+		assert.True(t, errors.Is(err, types.ErrInvalidType))
+		_, err = tuple.Size(1)
+		assert.True(t, errors.Is(err, types.ErrInvalidType))
+	})
+
+	t.Run("SizeAtTopLevel sizes the dynamic fields with the number of items", func(t *testing.T) {
+		static := newTuple(t, []encodings.NamedTypeCodec{
+			{Name: "A", Codec: builder.Uint64()},
+			{Name: "B", Codec: mustCodec(abi.NewArray(2, builder.Bool()))},
+			{Name: "C", Codec: mustCodec(abi.NewSlice(builder.Int64()))},
+		})
+
+		size, err := static.SizeAtTopLevel(5)
+		require.NoError(t, err)
+		assert.Equal(t, 32+2*32+(32+32+5*32), size)
+
+		_, err = tuple.SizeAtTopLevel(5)
+		assert.True(t, errors.Is(err, types.ErrInvalidType))
+	})
+
+	t.Run("Decode returns an error if an offset is out of the tuple", func(t *testing.T) {
+		_, _, err := tuple.Decode(words(t, "1000", "e0", "1"))
+		assert.True(t, errors.Is(err, types.ErrInvalidEncoding))
+		_, _, err = tuple.Decode(words(t, "20", "60", "1"))
+		assert.True(t, errors.Is(err, types.ErrInvalidEncoding))
+	})
+
+	t.Run("Decode returns an error when there are not enough bytes", func(t *testing.T) {
+		_, _, err := tuple.Decode(words(t, "60", "e0"))
+		assert.True(t, errors.Is(err, types.ErrInvalidEncoding))
+	})
+}
+
+func newTuple(t *testing.T, fields []encodings.NamedTypeCodec) encodings.TopLevelCodec {
+	tuple, err := abi.NewTuple(fields)
+	require.NoError(t, err)
+	return tuple
+}
+
+func mustCodec(codec encodings.TypeCodec, err error) encodings.TypeCodec {
+	if err != nil {
+		panic(err)
+	}
+	return codec
+}
+
+// assertRoundTrip checks that the value encodes to the expected bytes with a CodecFromTypeCodec
+// and that the bytes decode back to the value.
+func assertRoundTrip[T any](t *testing.T, tuple encodings.TypeCodec, value T, expected []byte) {
+	ctx := tests.Context(t)
+	c := encodings.CodecFromTypeCodec{"report": tuple}
+
+	encoded, err := c.Encode(ctx, value, "report")
+	require.NoError(t, err)
+	assert.Equal(t, expected, encoded)
+
+	var decoded T
+	require.NoError(t, c.Decode(ctx, encoded, &decoded, "report"))
+	assert.Equal(t, value, decoded)
+}
@@ -0,0 +1,53 @@
+package abi
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+	"math/big"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/types"
+)
+
+const wordSize = 32
+
+var twoTo256 = new(big.Int).Lsh(big.NewInt(1), 8*wordSize)
+
+// paddedLen returns n rounded up to a multiple of the word size.
+func paddedLen(n int) int {
+	return (n + wordSize - 1) / wordSize * wordSize
+}
+
+// appendLength appends a length or an offset as a uint256.
+func appendLength(into []byte, n int) []byte {
+	word := make([]byte, wordSize)
+	binary.BigEndian.PutUint64(word[wordSize-8:], uint64(n))
+	return append(into, word...)
+}
+
+// decodeLength decodes a length or an offset, which must fit in an int.
+func decodeLength(encoded []byte) (int, []byte, error) {
+	if len(encoded) < wordSize {
+		return 0, nil, fmt.Errorf("%w: not enough bytes to decode length", types.ErrInvalidEncoding)
+	}
+
+	if !isZero(encoded[:wordSize-8]) {
+		return 0, nil, fmt.Errorf("%w: length or offset is too large", types.ErrInvalidEncoding)
+	}
+
+	n := binary.BigEndian.Uint64(encoded[wordSize-8 : wordSize])
+	if n > math.MaxInt32 {
+		return 0, nil, fmt.Errorf("%w: length or offset %v is too large", types.ErrInvalidEncoding, n)
+	}
+
+	return int(n), encoded[wordSize:], nil
+}
+
+func isZero(b []byte) bool {
+	for _, v := range b {
+		if v != 0 {
+			return false
+		}
+	}
+	return true
+}
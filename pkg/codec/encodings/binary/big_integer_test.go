@@ -12,6 +12,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/smartcontractkit/chainlink-common/pkg/codec/encodings"
+	"github.com/smartcontractkit/chainlink-common/pkg/codec/encodings/binary"
 	"github.com/smartcontractkit/chainlink-common/pkg/types"
 )
 
@@ -128,4 +129,23 @@ func TestBigInteger(t *testing.T) {
 		_, _, err := signed.Decode([]byte{1, 2})
 		require.True(t, errors.Is(err, types.ErrInvalidEncoding))
 	})
+
+	t.Run("Little endian Decode does not modify the encoded bytes", func(t *testing.T) {
+		for _, signed := range []bool{true, false} {
+			codec, err := binary.LittleEndian().BigInt(4, signed)
+			require.NoError(t, err)
+
+			encoded, err := codec.Encode(big.NewInt(0x01020304), nil)
+			require.NoError(t, err)
+			original := append([]byte{}, encoded...)
+
+			first, _, err := codec.Decode(encoded)
+			require.NoError(t, err)
+			assert.Equal(t, original, encoded)
+
+			second, _, err := codec.Decode(encoded)
+			require.NoError(t, err)
+			assert.Equal(t, first, second)
+		}
+	})
 }
@@ -115,12 +115,15 @@ func (littleBigInt) serializeUnsigned(size int, i *big.Int) ([]byte, error) {
 }
 
 func (littleBigInt) deserializeSigned(size int, b []byte) *big.Int {
+	// b is part of the encoded bytes, which must not be modified
+	b = slices.Clone(b)
 	slices.Reverse(b)
 	bi, _ := bigbigendian.DeserializeSigned(size, b)
 	return bi
 }
 
 func (littleBigInt) deserializeUnsigned(_ int, b []byte) *big.Int {
+	b = slices.Clone(b)
 	slices.Reverse(b)
 	return new(big.Int).SetBytes(b)
 }
@@ -0,0 +1,22 @@
+package binary_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/codec/encodings"
+	"github.com/smartcontractkit/chainlink-common/pkg/codec/encodings/binary"
+	"github.com/smartcontractkit/chainlink-common/pkg/codec/encodings/testutils"
+)
+
+func TestBuilder(t *testing.T) {
+	t.Parallel()
+	for name, builder := range map[string]func() encodings.Builder{"BigEndian": binary.BigEndian, "LittleEndian": binary.LittleEndian} {
+		t.Run(name, func(t *testing.T) {
+			size, err := builder().Int(4)
+			require.NoError(t, err)
+			testutils.RunBuilderTests(t, builder(), size)
+		})
+	}
+}
@@ -32,11 +32,11 @@ func (f *Float64) GetType() reflect.Type {
 }
 
 func (f *Float64) Size(_ int) (int, error) {
-	return 4, nil
+	return 8, nil
 }
 
 func (f *Float64) FixedSize() (int, error) {
-	return 4, nil
+	return 8, nil
 }
 
 var _ encodings.TypeCodec = &Float64{}
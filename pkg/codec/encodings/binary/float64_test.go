@@ -72,12 +72,12 @@ func TestFloat64(t *testing.T) {
 	t.Run("Size returns the correct size", func(t *testing.T) {
 		size, err := f.Size(100)
 		require.NoError(t, err)
-		assert.Equal(t, 4, size)
+		assert.Equal(t, 8, size)
 	})
 
 	t.Run("FixedSize returns the correct size", func(t *testing.T) {
 		size, err := f.FixedSize()
 		require.NoError(t, err)
-		assert.Equal(t, 4, size)
+		assert.Equal(t, 8, size)
 	})
 }
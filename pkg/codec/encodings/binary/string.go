@@ -16,7 +16,13 @@ func NewString(maxLength uint, encoder encodings.Builder) (encodings.TypeCodec,
 		return nil, err
 	}
 
-	codec, err := encodings.NewSlice(encoder.Uint8(), sizeEncoder)
+	return NewLengthPrefixedString(maxLength, sizeEncoder)
+}
+
+// NewLengthPrefixedString creates a codec for strings encoded as their length, encoded with the provided int codec,
+// followed by their bytes.
+func NewLengthPrefixedString(maxLength uint, length encodings.TypeCodec) (encodings.TypeCodec, error) {
+	codec, err := encodings.NewSlice(&Uint8{}, length)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,152 @@
+// Package borsh encodes values following the Borsh specification used by Solana programs, see https://borsh.io.
+// Integers and floats are little endian, strings and slices are prefixed by their u32 length,
+// and options and enums are prefixed by a u8 tag.
+package borsh
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+	"reflect"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/codec/encodings"
+	encbinary "github.com/smartcontractkit/chainlink-common/pkg/codec/encodings/binary"
+	"github.com/smartcontractkit/chainlink-common/pkg/types"
+)
+
+// Builder returns an encodings.Builder for Borsh types.
+// u128 and i128 are built with BigInt(16, signed).
+func Builder() encodings.Builder {
+	return builder{Builder: encbinary.LittleEndian()}
+}
+
+// builder builds fixed-size types like little endian binary, which Borsh only restricts.
+type builder struct {
+	encodings.Builder
+}
+
+func (builder) Bool() encodings.TypeCodec {
+	return Bool{}
+}
+
+func (builder) String(maxLen uint) (encodings.TypeCodec, error) {
+	return encbinary.NewLengthPrefixedString(maxLen, Length{})
+}
+
+func (b builder) Float32() encodings.TypeCodec {
+	return notNaN{TypeCodec: b.Builder.Float32()}
+}
+
+func (b builder) Float64() encodings.TypeCodec {
+	return notNaN{TypeCodec: b.Builder.Float64()}
+}
+
+// NewSlice creates a codec for Vec<T>, encoded as its u32 length followed by its elements.
+func NewSlice(underlying encodings.TypeCodec) (encodings.TypeCodec, error) {
+	return encodings.NewSlice(underlying, Length{})
+}
+
+// Bool encodes a bool as a u8, which must be 0 or 1.
+type Bool struct{}
+
+var _ encodings.TypeCodec = Bool{}
+
+func (Bool) Encode(value any, into []byte) ([]byte, error) {
+	return encbinary.Bool{}.Encode(value, into)
+}
+
+func (Bool) Decode(encoded []byte) (any, []byte, error) {
+	if len(encoded) < 1 {
+		return nil, nil, fmt.Errorf("%w: not enough bytes to decode type", types.ErrInvalidEncoding)
+	}
+
+	if encoded[0] > 1 {
+		return nil, nil, fmt.Errorf("%w: %d is not a bool", types.ErrInvalidEncoding, encoded[0])
+	}
+
+	return encoded[0] == 1, encoded[1:], nil
+}
+
+func (Bool) GetType() reflect.Type {
+	return reflect.TypeOf(true)
+}
+
+func (Bool) Size(_ int) (int, error) {
+	return 1, nil
+}
+
+func (Bool) FixedSize() (int, error) {
+	return 1, nil
+}
+
+// Length encodes the length of strings and slices as a u32, decoding it to an int.
+type Length struct{}
+
+var _ encodings.TypeCodec = Length{}
+
+func (Length) Encode(value any, into []byte) ([]byte, error) {
+	length, ok := value.(int)
+	if !ok {
+		return nil, fmt.Errorf("%w: %T is not an int", types.ErrInvalidType, value)
+	}
+
+	if length < 0 || uint64(length) > math.MaxUint32 {
+		return nil, fmt.Errorf("%w: length %d doesn't fit into a u32", types.ErrInvalidType, length)
+	}
+
+	return binary.LittleEndian.AppendUint32(into, uint32(length)), nil
+}
+
+func (Length) Decode(encoded []byte) (any, []byte, error) {
+	return encodings.SafeDecode[int](encoded, 4, func(b []byte) int {
+		return int(binary.LittleEndian.Uint32(b))
+	})
+}
+
+func (Length) GetType() reflect.Type {
+	return reflect.TypeOf(0)
+}
+
+func (Length) Size(_ int) (int, error) {
+	return 4, nil
+}
+
+func (Length) FixedSize() (int, error) {
+	return 4, nil
+}
+
+// notNaN rejects NaN, which Borsh doesn't allow so that floats have a single encoding.
+type notNaN struct {
+	encodings.TypeCodec
+}
+
+func (n notNaN) Encode(value any, into []byte) ([]byte, error) {
+	if isNaN(value) {
+		return nil, fmt.Errorf("%w: NaN cannot be encoded", types.ErrInvalidType)
+	}
+	return n.TypeCodec.Encode(value, into)
+}
+
+func (n notNaN) Decode(encoded []byte) (any, []byte, error) {
+	value, remaining, err := n.TypeCodec.Decode(encoded)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if isNaN(value) {
+		return nil, nil, fmt.Errorf("%w: NaN cannot be decoded", types.ErrInvalidEncoding)
+	}
+
+	return value, remaining, nil
+}
+
+func isNaN(value any) bool {
+	switch f := value.(type) {
+	case float32:
+		return math.IsNaN(float64(f))
+	case float64:
+		return math.IsNaN(f)
+	default:
+		return false
+	}
+}
@@ -0,0 +1,101 @@
+package borsh_test
+
+import (
+	"encoding/hex"
+	"errors"
+	"math"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/codec/encodings/borsh"
+	"github.com/smartcontractkit/chainlink-common/pkg/codec/encodings/testutils"
+	"github.com/smartcontractkit/chainlink-common/pkg/types"
+)
+
+func TestBuilder(t *testing.T) {
+	t.Parallel()
+	testutils.RunBuilderTests(t, borsh.Builder(), borsh.Length{})
+
+	builder := borsh.Builder()
+
+	t.Run("Integers are little endian", func(t *testing.T) {
+		encoded, err := builder.Uint32().Encode(uint32(1), nil)
+		require.NoError(t, err)
+		assert.Equal(t, fromHex(t, "01000000"), encoded)
+	})
+
+	t.Run("i128 is encoded in two's complement", func(t *testing.T) {
+		i128, err := builder.BigInt(16, true)
+		require.NoError(t, err)
+
+		encoded, err := i128.Encode(big.NewInt(-1), nil)
+		require.NoError(t, err)
+		assert.Equal(t, fromHex(t, "ffffffffffffffffffffffffffffffff"), encoded)
+	})
+
+	t.Run("Strings are prefixed by their u32 length", func(t *testing.T) {
+		str, err := builder.String(10)
+		require.NoError(t, err)
+
+		encoded, err := str.Encode("hi", nil)
+		require.NoError(t, err)
+		assert.Equal(t, fromHex(t, "020000006869"), encoded)
+	})
+
+	t.Run("Decode returns an error for bools that are not 0 or 1", func(t *testing.T) {
+		_, _, err := builder.Bool().Decode([]byte{2})
+		assert.True(t, errors.Is(err, types.ErrInvalidEncoding))
+	})
+
+	t.Run("NaN cannot be encoded or decoded", func(t *testing.T) {
+		_, err := builder.Float64().Encode(math.NaN(), nil)
+		assert.True(t, errors.Is(err, types.ErrInvalidType))
+
+		_, err = builder.Float32().Encode(float32(math.NaN()), nil)
+		assert.True(t, errors.Is(err, types.ErrInvalidType))
+
+		_, _, err = builder.Float64().Decode(fromHex(t, "010000000000f87f"))
+		assert.True(t, errors.Is(err, types.ErrInvalidEncoding))
+	})
+}
+
+func TestLength(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Encode returns an error for lengths that don't fit into a u32", func(t *testing.T) {
+		_, err := borsh.Length{}.Encode(-1, nil)
+		assert.True(t, errors.Is(err, types.ErrInvalidType))
+
+		_, err = borsh.Length{}.Encode(math.MaxUint32+1, nil)
+		assert.True(t, errors.Is(err, types.ErrInvalidType))
+	})
+
+	t.Run("Decode returns an error if there are not enough bytes", func(t *testing.T) {
+		_, _, err := borsh.Length{}.Decode([]byte{1, 0, 0})
+		assert.True(t, errors.Is(err, types.ErrInvalidEncoding))
+	})
+}
+
+func TestNewSlice(t *testing.T) {
+	t.Parallel()
+	slice, err := borsh.NewSlice(borsh.Builder().Uint16())
+	require.NoError(t, err)
+
+	encoded, err := slice.Encode([]uint16{1, 2}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, fromHex(t, "0200000001000200"), encoded)
+
+	decoded, remaining, err := slice.Decode(encoded)
+	require.NoError(t, err)
+	assert.Equal(t, []uint16{1, 2}, decoded)
+	assert.Empty(t, remaining)
+}
+
+func fromHex(t *testing.T, s string) []byte {
+	b, err := hex.DecodeString(s)
+	require.NoError(t, err)
+	return b
+}
@@ -0,0 +1,24 @@
+package borsh
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/codec/encodings"
+	"github.com/smartcontractkit/chainlink-common/pkg/types"
+)
+
+// Enum creates a codec for an enum with the given variants, encoded as the u8 index of the variant followed by
+// its value. See encodings.NewUnion for the type of its values.
+func Enum(variants []encodings.NamedTypeCodec) (encodings.TypeCodec, error) {
+	if len(variants) > math.MaxUint8+1 {
+		return nil, fmt.Errorf("%w: enums can have at most %d variants", types.ErrInvalidConfig, math.MaxUint8+1)
+	}
+
+	byIndex := make(map[uint8]encodings.NamedTypeCodec, len(variants))
+	for i, variant := range variants {
+		byIndex[uint8(i)] = variant
+	}
+
+	return encodings.NewUnion(Builder().Uint8(), byIndex)
+}
@@ -0,0 +1,108 @@
+package borsh_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/codec/encodings"
+	"github.com/smartcontractkit/chainlink-common/pkg/codec/encodings/borsh"
+	"github.com/smartcontractkit/chainlink-common/pkg/types"
+)
+
+func TestEnum(t *testing.T) {
+	t.Parallel()
+	builder := borsh.Builder()
+	enum, err := borsh.Enum([]encodings.NamedTypeCodec{
+		{Name: "Unit", Codec: encodings.Empty{}},
+		{Name: "Amount", Codec: builder.Uint32()},
+		{Name: "Flag", Codec: builder.Bool()},
+	})
+	require.NoError(t, err)
+
+	type expected struct {
+		Unit   *struct{}
+		Amount *uint32
+		Flag   *bool
+	}
+
+	t.Run("GetType returns a pointer to a struct with a field per variant", func(t *testing.T) {
+		assert.True(t, enum.GetType().ConvertibleTo(reflect.TypeOf(&expected{})))
+	})
+
+	t.Run("Variants are encoded as their index followed by their value", func(t *testing.T) {
+		amount := uint32(7)
+		value := reflect.ValueOf(&expected{Amount: &amount}).Convert(enum.GetType()).Interface()
+		encoded, err := enum.Encode(value, nil)
+		require.NoError(t, err)
+		assert.Equal(t, []byte{1, 7, 0, 0, 0}, encoded)
+
+		decoded, remaining, err := enum.Decode(encoded)
+		require.NoError(t, err)
+		assert.Equal(t, value, decoded)
+		assert.Empty(t, remaining)
+	})
+
+	t.Run("Unit variants are encoded as their index", func(t *testing.T) {
+		value := reflect.ValueOf(&expected{Unit: &struct{}{}}).Convert(enum.GetType()).Interface()
+		encoded, err := enum.Encode(value, nil)
+		require.NoError(t, err)
+		assert.Equal(t, []byte{0}, encoded)
+
+		decoded, _, err := enum.Decode(encoded)
+		require.NoError(t, err)
+		assert.Equal(t, value, decoded)
+	})
+
+	t.Run("Encode returns an error unless exactly one variant is set", func(t *testing.T) {
+		flag := true
+		amount := uint32(7)
+		for _, value := range []*expected{{}, {Amount: &amount, Flag: &flag}} {
+			_, err := enum.Encode(reflect.ValueOf(value).Convert(enum.GetType()).Interface(), nil)
+			assert.True(t, errors.Is(err, types.ErrInvalidType))
+		}
+
+		_, err := enum.Encode(&expected{}, nil)
+		assert.True(t, errors.Is(err, types.ErrInvalidType))
+	})
+
+	t.Run("Decode returns an error for unknown variants", func(t *testing.T) {
+		_, _, err := enum.Decode([]byte{3})
+		assert.True(t, errors.Is(err, types.ErrInvalidEncoding))
+	})
+
+	t.Run("Size is the size of the largest variant", func(t *testing.T) {
+		size, err := enum.Size(1)
+		require.NoError(t, err)
+		assert.Equal(t, 5, size)
+
+		_, err = enum.FixedSize()
+		assert.True(t, errors.Is(err, types.ErrInvalidType))
+	})
+
+	t.Run("FixedSize is returned if all variants have the same size", func(t *testing.T) {
+		fixed, err := borsh.Enum([]encodings.NamedTypeCodec{
+			{Name: "A", Codec: builder.Uint16()},
+			{Name: "B", Codec: builder.Int16()},
+		})
+		require.NoError(t, err)
+
+		size, err := fixed.FixedSize()
+		require.NoError(t, err)
+		assert.Equal(t, 3, size)
+	})
+
+	t.Run("Enum returns an error for invalid variants", func(t *testing.T) {
+		_, err := borsh.Enum(nil)
+		assert.True(t, errors.Is(err, types.ErrInvalidConfig))
+
+		_, err = borsh.Enum([]encodings.NamedTypeCodec{{Name: "A"}})
+		assert.True(t, errors.Is(err, types.ErrInvalidConfig))
+
+		_, err = borsh.Enum([]encodings.NamedTypeCodec{{Name: "a", Codec: builder.Bool()}})
+		assert.True(t, errors.Is(err, types.ErrInvalidConfig))
+	})
+}
@@ -0,0 +1,11 @@
+package borsh
+
+import (
+	"github.com/smartcontractkit/chainlink-common/pkg/codec/encodings"
+)
+
+// Option creates a codec for Option<T>, encoded as 0 for None, or 1 followed by the value for Some.
+// See encodings.NewOptional for the type of its values.
+func Option(underlying encodings.TypeCodec) (encodings.TypeCodec, error) {
+	return encodings.NewOptional(underlying)
+}
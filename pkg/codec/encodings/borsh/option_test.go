@@ -0,0 +1,46 @@
+package borsh_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/codec/encodings/borsh"
+	"github.com/smartcontractkit/chainlink-common/pkg/types"
+)
+
+func TestOption(t *testing.T) {
+	t.Parallel()
+	option, err := borsh.Option(borsh.Builder().Uint8())
+	require.NoError(t, err)
+
+	t.Run("Some is encoded as 1 followed by the value", func(t *testing.T) {
+		value := uint8(5)
+		encoded, err := option.Encode(&value, nil)
+		require.NoError(t, err)
+		assert.Equal(t, []byte{1, 5}, encoded)
+
+		decoded, remaining, err := option.Decode([]byte{1, 5, 6})
+		require.NoError(t, err)
+		assert.Equal(t, &value, decoded)
+		assert.Equal(t, []byte{6}, remaining)
+	})
+
+	t.Run("None is encoded as 0", func(t *testing.T) {
+		encoded, err := option.Encode((*uint8)(nil), nil)
+		require.NoError(t, err)
+		assert.Equal(t, []byte{0}, encoded)
+
+		decoded, remaining, err := option.Decode([]byte{0, 6})
+		require.NoError(t, err)
+		assert.Equal(t, (*uint8)(nil), decoded)
+		assert.Equal(t, []byte{6}, remaining)
+	})
+
+	t.Run("Decode returns an error for invalid tags", func(t *testing.T) {
+		_, _, err := option.Decode([]byte{2, 5})
+		assert.True(t, errors.Is(err, types.ErrInvalidEncoding))
+	})
+}
@@ -0,0 +1,373 @@
+package encodings
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/types"
+)
+
+// TypeCodecConfig describes a TypeCodec built from the codecs of a Builder.
+type TypeCodecConfig interface {
+	ToTypeCodec(builder Builder) (TypeCodec, error)
+}
+
+// TypeCodecDefinition unmarshalls a [TypeCodecConfig] like codec.ModifiersConfig, by using a field called Type.
+// This allows relayers to declare the types of their chain in JSON.
+// The values available for Type are case-insensitive and the config they require are below:
+// - bool, int8, int16, int32, int64, uint8, uint16, uint32, uint64, float32, float64, oracle id -> [BuilderTypeConfig]
+// - int -> [IntConfig]
+// - big int -> [BigIntConfig]
+// - string -> [StringConfig]
+// - struct -> [StructConfig]
+// - array -> [ArrayConfig]
+// - slice -> [SliceConfig]
+// - optional -> [OptionalConfig]
+// - union -> [UnionConfig]
+type TypeCodecDefinition struct {
+	TypeCodecConfig
+}
+
+func (d *TypeCodecDefinition) UnmarshalJSON(data []byte) error {
+	t := typer{}
+	if err := decodeConfig(data, &t); err != nil {
+		return fmt.Errorf("%w: %w", types.ErrInvalidConfig, err)
+	}
+
+	cType := CodecType(strings.ToLower(t.Type))
+	switch cType {
+	case CodecBool, CodecInt8, CodecInt16, CodecInt32, CodecInt64, CodecUint8, CodecUint16, CodecUint32, CodecUint64,
+		CodecFloat32, CodecFloat64, CodecOracleID:
+		d.TypeCodecConfig = &BuilderTypeConfig{Type: cType}
+		return nil
+	case CodecInt:
+		d.TypeCodecConfig = &IntConfig{}
+	case CodecBigInt:
+		d.TypeCodecConfig = &BigIntConfig{}
+	case CodecString:
+		d.TypeCodecConfig = &StringConfig{}
+	case CodecStruct:
+		d.TypeCodecConfig = &StructConfig{}
+	case CodecArray:
+		d.TypeCodecConfig = &ArrayConfig{}
+	case CodecSlice:
+		d.TypeCodecConfig = &SliceConfig{}
+	case CodecOptional:
+		d.TypeCodecConfig = &OptionalConfig{}
+	case CodecUnion:
+		d.TypeCodecConfig = &UnionConfig{}
+	default:
+		return fmt.Errorf("%w: unknown codec type: %s", types.ErrInvalidConfig, cType)
+	}
+
+	if err := decodeConfig(data, d.TypeCodecConfig); err != nil {
+		return fmt.Errorf("%w: %w", types.ErrInvalidConfig, err)
+	}
+	return nil
+}
+
+func (d TypeCodecDefinition) MarshalJSON() ([]byte, error) {
+	return json.Marshal(d.TypeCodecConfig)
+}
+
+func (d TypeCodecDefinition) ToTypeCodec(builder Builder) (TypeCodec, error) {
+	if d.TypeCodecConfig == nil {
+		return nil, fmt.Errorf("%w: codec type is required", types.ErrInvalidConfig)
+	}
+	return d.TypeCodecConfig.ToTypeCodec(builder)
+}
+
+type CodecType string
+
+const (
+	CodecBool     CodecType = "bool"
+	CodecInt8     CodecType = "int8"
+	CodecInt16    CodecType = "int16"
+	CodecInt32    CodecType = "int32"
+	CodecInt64    CodecType = "int64"
+	CodecUint8    CodecType = "uint8"
+	CodecUint16   CodecType = "uint16"
+	CodecUint32   CodecType = "uint32"
+	CodecUint64   CodecType = "uint64"
+	CodecFloat32  CodecType = "float32"
+	CodecFloat64  CodecType = "float64"
+	CodecOracleID CodecType = "oracle id"
+	CodecInt      CodecType = "int"
+	CodecBigInt   CodecType = "big int"
+	CodecString   CodecType = "string"
+	CodecStruct   CodecType = "struct"
+	CodecArray    CodecType = "array"
+	CodecSlice    CodecType = "slice"
+	CodecOptional CodecType = "optional"
+	CodecUnion    CodecType = "union"
+)
+
+// BuilderTypeConfig is used for the types of a Builder that don't require any config, such as uint32.
+type BuilderTypeConfig struct {
+	Type CodecType
+}
+
+func (b *BuilderTypeConfig) ToTypeCodec(builder Builder) (TypeCodec, error) {
+	switch b.Type {
+	case CodecBool:
+		return builder.Bool(), nil
+	case CodecInt8:
+		return builder.Int8(), nil
+	case CodecInt16:
+		return builder.Int16(), nil
+	case CodecInt32:
+		return builder.Int32(), nil
+	case CodecInt64:
+		return builder.Int64(), nil
+	case CodecUint8:
+		return builder.Uint8(), nil
+	case CodecUint16:
+		return builder.Uint16(), nil
+	case CodecUint32:
+		return builder.Uint32(), nil
+	case CodecUint64:
+		return builder.Uint64(), nil
+	case CodecFloat32:
+		return builder.Float32(), nil
+	case CodecFloat64:
+		return builder.Float64(), nil
+	case CodecOracleID:
+		return builder.OracleID(), nil
+	default:
+		return nil, fmt.Errorf("%w: %s is not a builder type", types.ErrInvalidConfig, b.Type)
+	}
+}
+
+// IntConfig is used for a Go int or uint encoded with the given number of bytes.
+type IntConfig struct {
+	Bytes  uint
+	Signed bool
+}
+
+func (i *IntConfig) ToTypeCodec(builder Builder) (TypeCodec, error) {
+	if i.Signed {
+		return builder.Int(i.Bytes)
+	}
+	return builder.Uint(i.Bytes)
+}
+
+func (i *IntConfig) MarshalJSON() ([]byte, error) {
+	return json.Marshal(&configMarshaller[IntConfig]{Type: CodecInt, T: i})
+}
+
+// BigIntConfig is used for a *big.Int encoded with the given number of bytes.
+type BigIntConfig struct {
+	Bytes  uint
+	Signed bool
+}
+
+func (b *BigIntConfig) ToTypeCodec(builder Builder) (TypeCodec, error) {
+	return builder.BigInt(b.Bytes, b.Signed)
+}
+
+func (b *BigIntConfig) MarshalJSON() ([]byte, error) {
+	return json.Marshal(&configMarshaller[BigIntConfig]{Type: CodecBigInt, T: b})
+}
+
+// StringConfig is used for strings up to MaxLen bytes.
+type StringConfig struct {
+	MaxLen uint
+}
+
+func (s *StringConfig) ToTypeCodec(builder Builder) (TypeCodec, error) {
+	return builder.String(s.MaxLen)
+}
+
+func (s *StringConfig) MarshalJSON() ([]byte, error) {
+	return json.Marshal(&configMarshaller[StringConfig]{Type: CodecString, T: s})
+}
+
+// NamedTypeCodecConfig is the config of a [NamedTypeCodec].
+type NamedTypeCodecConfig struct {
+	Name  string
+	Codec TypeCodecDefinition
+}
+
+func (n *NamedTypeCodecConfig) toNamedTypeCodec(builder Builder) (NamedTypeCodec, error) {
+	c, err := n.Codec.ToTypeCodec(builder)
+	if err != nil {
+		return NamedTypeCodec{}, fmt.Errorf("%s: %w", n.Name, err)
+	}
+	return NamedTypeCodec{Name: n.Name, Codec: c}, nil
+}
+
+// StructConfig is used for a struct with the given fields, see [NewStructCodec].
+type StructConfig struct {
+	Fields []NamedTypeCodecConfig
+}
+
+func (s *StructConfig) ToTypeCodec(builder Builder) (TypeCodec, error) {
+	fields := make([]NamedTypeCodec, len(s.Fields))
+	for i, field := range s.Fields {
+		var err error
+		if fields[i], err = field.toNamedTypeCodec(builder); err != nil {
+			return nil, err
+		}
+	}
+	return NewStructCodec(fields)
+}
+
+func (s *StructConfig) MarshalJSON() ([]byte, error) {
+	return json.Marshal(&configMarshaller[StructConfig]{Type: CodecStruct, T: s})
+}
+
+// ArrayConfig is used for an array of Len elements, see [NewArray].
+type ArrayConfig struct {
+	Len  int
+	Elem TypeCodecDefinition
+}
+
+func (a *ArrayConfig) ToTypeCodec(builder Builder) (TypeCodec, error) {
+	elem, err := a.Elem.ToTypeCodec(builder)
+	if err != nil {
+		return nil, err
+	}
+	return NewArray(a.Len, elem)
+}
+
+func (a *ArrayConfig) MarshalJSON() ([]byte, error) {
+	return json.Marshal(&configMarshaller[ArrayConfig]{Type: CodecArray, T: a})
+}
+
+// SliceConfig is used for a slice with its length encoded using Size, see [NewSlice].
+type SliceConfig struct {
+	Elem TypeCodecDefinition
+	Size TypeCodecDefinition
+}
+
+func (s *SliceConfig) ToTypeCodec(builder Builder) (TypeCodec, error) {
+	elem, err := s.Elem.ToTypeCodec(builder)
+	if err != nil {
+		return nil, err
+	}
+
+	size, err := s.Size.ToTypeCodec(builder)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewSlice(elem, size)
+}
+
+func (s *SliceConfig) MarshalJSON() ([]byte, error) {
+	return json.Marshal(&configMarshaller[SliceConfig]{Type: CodecSlice, T: s})
+}
+
+// OptionalConfig is used for a value that may be absent, see [NewOptional].
+type OptionalConfig struct {
+	Codec TypeCodecDefinition
+}
+
+func (o *OptionalConfig) ToTypeCodec(builder Builder) (TypeCodec, error) {
+	c, err := o.Codec.ToTypeCodec(builder)
+	if err != nil {
+		return nil, err
+	}
+	return NewOptional(c)
+}
+
+func (o *OptionalConfig) MarshalJSON() ([]byte, error) {
+	return json.Marshal(&configMarshaller[OptionalConfig]{Type: CodecOptional, T: o})
+}
+
+// UnionConfig is used for a tagged union, see [NewUnion].
+// Tags are numbers or strings, converted to the type of the Tag codec.
+type UnionConfig struct {
+	Tag      TypeCodecDefinition
+	Variants []UnionVariantConfig
+}
+
+type UnionVariantConfig struct {
+	Tag any
+	NamedTypeCodecConfig
+}
+
+func (u *UnionConfig) ToTypeCodec(builder Builder) (TypeCodec, error) {
+	tag, err := u.Tag.ToTypeCodec(builder)
+	if err != nil {
+		return nil, err
+	}
+
+	tags := make([]any, len(u.Variants))
+	variants := make([]NamedTypeCodec, len(u.Variants))
+	for i, variant := range u.Variants {
+		if tags[i], err = convertTag(variant.Tag, tag.GetType()); err != nil {
+			return nil, fmt.Errorf("%s: %w", variant.Name, err)
+		}
+
+		if variants[i], err = variant.toNamedTypeCodec(builder); err != nil {
+			return nil, err
+		}
+	}
+
+	return newUnion(tag, tags, variants)
+}
+
+func (u *UnionConfig) MarshalJSON() ([]byte, error) {
+	return json.Marshal(&configMarshaller[UnionConfig]{Type: CodecUnion, T: u})
+}
+
+// convertTag converts a tag from JSON to a value of type t.
+func convertTag(tag any, t reflect.Type) (any, error) {
+	s := fmt.Sprint(tag)
+	value := reflect.New(t).Elem()
+	switch t.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i, err := strconv.ParseInt(s, 10, 64)
+		if err != nil || value.OverflowInt(i) {
+			return nil, fmt.Errorf("%w: tag %v is not a %v", types.ErrInvalidConfig, tag, t)
+		}
+		value.SetInt(i)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		u, err := strconv.ParseUint(s, 10, 64)
+		if err != nil || value.OverflowUint(u) {
+			return nil, fmt.Errorf("%w: tag %v is not a %v", types.ErrInvalidConfig, tag, t)
+		}
+		value.SetUint(u)
+	case reflect.String:
+		value.SetString(s)
+	default:
+		return nil, fmt.Errorf("%w: tags of type %v are not supported", types.ErrInvalidConfig, t)
+	}
+	return value.Interface(), nil
+}
+
+type typer struct {
+	Type string
+}
+
+type configMarshaller[T any] struct {
+	Type CodecType
+	T    *T
+}
+
+func (c *configMarshaller[T]) MarshalJSON() ([]byte, error) {
+	v := reflect.Indirect(reflect.ValueOf(c.T))
+	t := v.Type()
+
+	m := map[string]any{
+		"Type": c.Type,
+	}
+
+	for i := 0; i < t.NumField(); i++ {
+		m[t.Field(i).Name] = v.Field(i).Interface()
+	}
+
+	return json.Marshal(m)
+}
+
+func decodeConfig(bts []byte, val any) error {
+	decoder := json.NewDecoder(bytes.NewBuffer(bts))
+	decoder.UseNumber()
+
+	return decoder.Decode(val)
+}
@@ -0,0 +1,119 @@
+package encodings_test
+
+import (
+	"encoding/json"
+	"errors"
+	"math/big"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/codec/encodings"
+	"github.com/smartcontractkit/chainlink-common/pkg/codec/encodings/binary"
+	"github.com/smartcontractkit/chainlink-common/pkg/types"
+)
+
+func TestTypeCodecDefinition(t *testing.T) {
+	t.Parallel()
+	builder := binary.LittleEndian()
+
+	t.Run("Definitions are unmarshalled by type", func(t *testing.T) {
+		definition := encodings.TypeCodecDefinition{}
+		require.NoError(t, json.Unmarshal([]byte(`{
+			"Type": "Struct",
+			"Fields": [
+				{"Name": "Flag", "Codec": {"Type": "bool"}},
+				{"Name": "Count", "Codec": {"Type": "optional", "Codec": {"Type": "UINT32"}}},
+				{"Name": "Name", "Codec": {"Type": "string", "MaxLen": 10}},
+				{"Name": "Amount", "Codec": {"Type": "big int", "Bytes": 16, "Signed": true}},
+				{"Name": "Pair", "Codec": {"Type": "array", "Len": 2, "Elem": {"Type": "int", "Bytes": 2, "Signed": true}}},
+				{"Name": "Values", "Codec": {"Type": "slice", "Elem": {"Type": "int8"}, "Size": {"Type": "int", "Bytes": 1, "Signed": true}}},
+				{"Name": "Event", "Codec": {
+					"Type": "union",
+					"Tag": {"Type": "uint8"},
+					"Variants": [
+						{"Tag": 1, "Name": "Deposit", "Codec": {"Type": "uint64"}},
+						{"Tag": 0, "Name": "Empty", "Codec": {"Type": "struct", "Fields": []}}
+					]
+				}}
+			]
+		}`), &definition))
+
+		codec, err := definition.ToTypeCodec(builder)
+		require.NoError(t, err)
+
+		expected := reflect.TypeOf(&struct {
+			Flag   *bool
+			Count  *uint32
+			Name   *string
+			Amount *big.Int
+			Pair   *[2]int
+			Values *[]int8
+			Event  *struct {
+				Empty   *struct{}
+				Deposit *uint64
+			}
+		}{})
+		assert.True(t, codec.GetType().ConvertibleTo(expected), "%v is not %v", codec.GetType(), expected)
+	})
+
+	t.Run("Definitions round trip through JSON", func(t *testing.T) {
+		definition := encodings.TypeCodecDefinition{TypeCodecConfig: &encodings.UnionConfig{
+			Tag: encodings.TypeCodecDefinition{TypeCodecConfig: &encodings.BuilderTypeConfig{Type: encodings.CodecUint8}},
+			Variants: []encodings.UnionVariantConfig{{
+				Tag: json.Number("3"),
+				NamedTypeCodecConfig: encodings.NamedTypeCodecConfig{
+					Name:  "A",
+					Codec: encodings.TypeCodecDefinition{TypeCodecConfig: &encodings.OptionalConfig{Codec: encodings.TypeCodecDefinition{TypeCodecConfig: &encodings.StringConfig{MaxLen: 5}}}},
+				},
+			}},
+		}}
+
+		raw, err := json.Marshal(definition)
+		require.NoError(t, err)
+
+		actual := encodings.TypeCodecDefinition{}
+		require.NoError(t, json.Unmarshal(raw, &actual))
+		assert.Equal(t, definition, actual)
+	})
+
+	t.Run("Union tags are converted to the type of the tag", func(t *testing.T) {
+		definition := encodings.TypeCodecDefinition{}
+		require.NoError(t, json.Unmarshal([]byte(`{
+			"Type": "union",
+			"Tag": {"Type": "int16"},
+			"Variants": [{"Tag": -2, "Name": "A", "Codec": {"Type": "bool"}}]
+		}`), &definition))
+
+		codec, err := definition.ToTypeCodec(builder)
+		require.NoError(t, err)
+
+		decoded, _, err := codec.Decode([]byte{0xfe, 0xff, 1})
+		require.NoError(t, err)
+		assert.Equal(t, true, *reflect.ValueOf(decoded).Elem().Field(0).Interface().(*bool))
+	})
+
+	t.Run("ToTypeCodec returns an error for tags that don't fit the tag type", func(t *testing.T) {
+		definition := encodings.TypeCodecDefinition{}
+		require.NoError(t, json.Unmarshal([]byte(`{
+			"Type": "union",
+			"Tag": {"Type": "uint8"},
+			"Variants": [{"Tag": 256, "Name": "A", "Codec": {"Type": "bool"}}]
+		}`), &definition))
+
+		_, err := definition.ToTypeCodec(builder)
+		assert.True(t, errors.Is(err, types.ErrInvalidConfig))
+	})
+
+	t.Run("Unmarshal returns an error for unknown types", func(t *testing.T) {
+		err := json.Unmarshal([]byte(`{"Type": "unknown"}`), &encodings.TypeCodecDefinition{})
+		assert.True(t, errors.Is(err, types.ErrInvalidConfig))
+	})
+
+	t.Run("ToTypeCodec returns an error for missing types", func(t *testing.T) {
+		_, err := encodings.TypeCodecDefinition{}.ToTypeCodec(builder)
+		assert.True(t, errors.Is(err, types.ErrInvalidConfig))
+	})
+}
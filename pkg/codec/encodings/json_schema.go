@@ -0,0 +1,93 @@
+package encodings
+
+import (
+	"math"
+	"reflect"
+)
+
+// JSONSchemaDraft is the JSON schema version of the schemas returned by ItemSchema.OffChainJSONSchema.
+const JSONSchemaDraft = "https://json-schema.org/draft/2020-12/schema"
+
+// OffChainJSONSchema returns the JSON schema of the off-chain type of the item, titled with the item type.
+func (i *ItemSchema) OffChainJSONSchema() map[string]any {
+	schema := i.OffChain.JSONSchema()
+	schema["$schema"] = JSONSchemaDraft
+	schema["title"] = i.ItemType
+	return schema
+}
+
+// JSONSchema returns the JSON schema of values of the type, as they're marshalled by encoding/json.
+// Fields that are not pointers are required, and unions must have exactly one variant set.
+// A recursive type accepts any value where it's used within itself.
+func (s *TypeSchema) JSONSchema() map[string]any {
+	if s.Recursive {
+		return map[string]any{}
+	}
+
+	switch s.GoType {
+	case "big.Int":
+		return map[string]any{"type": "integer"}
+	case "decimal.Decimal":
+		// decimals are marshalled as strings, but can be unmarshalled from numbers too
+		return map[string]any{"type": []string{"string", "number"}}
+	case "time.Time":
+		return map[string]any{"type": "string", "format": "date-time"}
+	}
+
+	switch s.Kind {
+	case reflect.Bool.String():
+		return map[string]any{"type": "boolean"}
+	case reflect.Int.String(), reflect.Int8.String(), reflect.Int16.String(), reflect.Int32.String(), reflect.Int64.String():
+		bits := intBits(s.Kind)
+		return map[string]any{"type": "integer", "minimum": -(int64(1) << (bits - 1)), "maximum": int64(math.MaxInt64 >> (64 - bits))}
+	case reflect.Uint.String(), reflect.Uint8.String(), reflect.Uint16.String(), reflect.Uint32.String(), reflect.Uint64.String():
+		bits := intBits(s.Kind)
+		return map[string]any{"type": "integer", "minimum": 0, "maximum": uint64(math.MaxUint64 >> (64 - bits))}
+	case reflect.Float32.String(), reflect.Float64.String():
+		return map[string]any{"type": "number"}
+	case reflect.String.String():
+		return map[string]any{"type": "string"}
+	case reflect.Pointer.String(), KindOptional:
+		return s.Elem.JSONSchema()
+	case reflect.Slice.String():
+		if s.Elem.Kind == reflect.Uint8.String() {
+			return map[string]any{"type": "string", "contentEncoding": "base64"}
+		}
+		return map[string]any{"type": "array", "items": s.Elem.JSONSchema()}
+	case reflect.Array.String():
+		return map[string]any{"type": "array", "items": s.Elem.JSONSchema(), "minItems": s.Len, "maxItems": s.Len}
+	case reflect.Map.String():
+		return map[string]any{"type": "object", "additionalProperties": s.Elem.JSONSchema()}
+	case reflect.Struct.String():
+		properties := map[string]any{}
+		required := []string{}
+		for _, field := range s.Fields {
+			properties[field.Name] = field.Type.JSONSchema()
+			if field.Type.Kind != reflect.Pointer.String() && field.Type.Kind != KindOptional {
+				required = append(required, field.Name)
+			}
+		}
+		return map[string]any{"type": "object", "properties": properties, "required": required, "additionalProperties": false}
+	case KindUnion:
+		properties := map[string]any{}
+		for _, field := range s.Fields {
+			properties[field.Name] = field.Type.JSONSchema()
+		}
+		return map[string]any{"type": "object", "properties": properties, "minProperties": 1, "maxProperties": 1, "additionalProperties": false}
+	default:
+		return map[string]any{}
+	}
+}
+
+func intBits(kind string) int {
+	switch kind {
+	case reflect.Int8.String(), reflect.Uint8.String():
+		return 8
+	case reflect.Int16.String(), reflect.Uint16.String():
+		return 16
+	case reflect.Int32.String(), reflect.Uint32.String():
+		return 32
+	default:
+		return 64
+	}
+}
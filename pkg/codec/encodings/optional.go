@@ -0,0 +1,113 @@
+package encodings
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/types"
+)
+
+// NewOptional creates a codec for a value that may be absent, encoded as 0 when it's absent, or 1 followed by the value.
+// Optional values are pointers that are nil when absent. If the underlying type already is a pointer or an interface,
+// it's used as the optional type.
+func NewOptional(underlying TypeCodec) (TypeCodec, error) {
+	if underlying == nil {
+		return nil, fmt.Errorf("%w: optional type cannot be nil", types.ErrInvalidConfig)
+	}
+
+	return &optional{elm: underlying, tpe: optionalType(underlying.GetType())}, nil
+}
+
+type optional struct {
+	elm TypeCodec
+	tpe reflect.Type
+}
+
+var _ TypeCodec = &optional{}
+
+func (o *optional) Encode(value any, into []byte) ([]byte, error) {
+	rValue := reflect.ValueOf(value)
+	if !isOptional(rValue, o.tpe) {
+		return nil, fmt.Errorf("%w: expected %v, got %T", types.ErrInvalidType, o.tpe, value)
+	}
+
+	if isNil(rValue) {
+		return append(into, 0), nil
+	}
+
+	return o.elm.Encode(unwrapOptional(rValue, o.elm.GetType()), append(into, 1))
+}
+
+func (o *optional) Decode(encoded []byte) (any, []byte, error) {
+	if len(encoded) < 1 {
+		return nil, nil, fmt.Errorf("%w: not enough bytes to decode type", types.ErrInvalidEncoding)
+	}
+
+	switch encoded[0] {
+	case 0:
+		return reflect.Zero(o.tpe).Interface(), encoded[1:], nil
+	case 1:
+		value, remaining, err := o.elm.Decode(encoded[1:])
+		if err != nil {
+			return nil, nil, err
+		}
+		return wrapOptional(value, o.tpe), remaining, nil
+	default:
+		return nil, nil, fmt.Errorf("%w: %d is not an optional tag", types.ErrInvalidEncoding, encoded[0])
+	}
+}
+
+func (o *optional) GetType() reflect.Type {
+	return o.tpe
+}
+
+// Size returns the size of a value that's present, which is the largest.
+func (o *optional) Size(numItems int) (int, error) {
+	size, err := o.elm.Size(numItems)
+	return size + 1, err
+}
+
+func (o *optional) FixedSize() (int, error) {
+	return 0, fmt.Errorf("%w: optional values are not fixed size", types.ErrInvalidType)
+}
+
+// optionalType returns the type of an optional value of type t, which is nil when absent.
+func optionalType(t reflect.Type) reflect.Type {
+	if t.Kind() == reflect.Pointer || t.Kind() == reflect.Interface {
+		return t
+	}
+	return reflect.PointerTo(t)
+}
+
+// isOptional returns whether value is of the optional type t.
+// Values of interface types are passed with their dynamic type, so they only need to implement it.
+func isOptional(value reflect.Value, t reflect.Type) bool {
+	if t.Kind() == reflect.Interface {
+		return !value.IsValid() || value.Type().Implements(t)
+	}
+	return value.IsValid() && value.Type() == t
+}
+
+func isNil(value reflect.Value) bool {
+	return !value.IsValid() || (value.Kind() == reflect.Pointer || value.Kind() == reflect.Interface) && value.IsNil()
+}
+
+// unwrapOptional returns the value of an optional that's present for a codec of type t.
+func unwrapOptional(optional reflect.Value, t reflect.Type) any {
+	if t.Kind() == reflect.Interface || optional.Type() == t {
+		return optional.Interface()
+	}
+	return optional.Elem().Interface()
+}
+
+// wrapOptional returns a decoded value as an optional of type t.
+func wrapOptional(value any, t reflect.Type) any {
+	rValue := reflect.ValueOf(value)
+	if t.Kind() == reflect.Interface || rValue.Type() == t {
+		return value
+	}
+
+	ptr := reflect.New(rValue.Type())
+	ptr.Elem().Set(rValue)
+	return ptr.Interface()
+}
@@ -0,0 +1,180 @@
+package encodings_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/codec/encodings"
+	"github.com/smartcontractkit/chainlink-common/pkg/codec/encodings/binary"
+	"github.com/smartcontractkit/chainlink-common/pkg/types"
+)
+
+func TestOptional(t *testing.T) {
+	t.Parallel()
+	optional, err := encodings.NewOptional(binary.LittleEndian().Uint16())
+	require.NoError(t, err)
+
+	t.Run("NewOptional returns an error for nil codecs", func(t *testing.T) {
+		_, err := encodings.NewOptional(nil)
+		assert.True(t, errors.Is(err, types.ErrInvalidConfig))
+	})
+
+	t.Run("GetType returns a pointer", func(t *testing.T) {
+		assert.Equal(t, reflect.TypeOf((*uint16)(nil)), optional.GetType())
+	})
+
+	t.Run("Present values are encoded as 1 followed by the value", func(t *testing.T) {
+		value := uint16(258)
+		encoded, err := optional.Encode(&value, []byte{9})
+		require.NoError(t, err)
+		assert.Equal(t, []byte{9, 1, 2, 1}, encoded)
+
+		decoded, remaining, err := optional.Decode([]byte{1, 2, 1, 9})
+		require.NoError(t, err)
+		assert.Equal(t, &value, decoded)
+		assert.Equal(t, []byte{9}, remaining)
+	})
+
+	t.Run("Absent values are encoded as 0", func(t *testing.T) {
+		encoded, err := optional.Encode((*uint16)(nil), nil)
+		require.NoError(t, err)
+		assert.Equal(t, []byte{0}, encoded)
+
+		decoded, remaining, err := optional.Decode([]byte{0, 9})
+		require.NoError(t, err)
+		assert.Equal(t, (*uint16)(nil), decoded)
+		assert.Equal(t, []byte{9}, remaining)
+	})
+
+	t.Run("Pointer types are used as the optional type", func(t *testing.T) {
+		inner, err := encodings.NewStructCodec([]encodings.NamedTypeCodec{{Name: "A", Codec: binary.LittleEndian().Uint8()}})
+		require.NoError(t, err)
+		structOptional, err := encodings.NewOptional(inner)
+		require.NoError(t, err)
+		assert.Equal(t, inner.GetType(), structOptional.GetType())
+
+		value := reflect.New(inner.GetType().Elem())
+		a := uint8(3)
+		value.Elem().Field(0).Set(reflect.ValueOf(&a))
+		encoded, err := structOptional.Encode(value.Interface(), nil)
+		require.NoError(t, err)
+		assert.Equal(t, []byte{1, 3}, encoded)
+
+		decoded, _, err := structOptional.Decode(encoded)
+		require.NoError(t, err)
+		assert.Equal(t, value.Interface(), decoded)
+	})
+
+	t.Run("Interface types are used as the optional type", func(t *testing.T) {
+		stringerOptional, err := encodings.NewOptional(stringerCodec{})
+		require.NoError(t, err)
+		assert.Equal(t, reflect.TypeOf((*fmt.Stringer)(nil)).Elem(), stringerOptional.GetType())
+
+		encoded, err := stringerOptional.Encode(name("a"), nil)
+		require.NoError(t, err)
+		assert.Equal(t, []byte{1, 1, 'a'}, encoded)
+
+		decoded, _, err := stringerOptional.Decode(encoded)
+		require.NoError(t, err)
+		assert.Equal(t, name("a"), decoded)
+
+		encoded, err = stringerOptional.Encode(nil, nil)
+		require.NoError(t, err)
+		assert.Equal(t, []byte{0}, encoded)
+
+		decoded, _, err = stringerOptional.Decode(encoded)
+		require.NoError(t, err)
+		assert.Nil(t, decoded)
+	})
+
+	t.Run("Optional pointer and interface fields round trip through structs", func(t *testing.T) {
+		stringerOptional, err := encodings.NewOptional(stringerCodec{})
+		require.NoError(t, err)
+		structCodec, err := encodings.NewStructCodec([]encodings.NamedTypeCodec{
+			{Name: "A", Codec: optional},
+			{Name: "B", Codec: stringerOptional},
+		})
+		require.NoError(t, err)
+		c := encodings.CodecFromTypeCodec{"test": structCodec}
+
+		type withOptionals struct {
+			A *uint16
+			B fmt.Stringer
+		}
+
+		a := uint16(1)
+		for _, input := range []withOptionals{{}, {A: &a, B: name("b")}} {
+			encoded, err := c.Encode(context.Background(), &input, "test")
+			require.NoError(t, err)
+
+			output := withOptionals{}
+			require.NoError(t, c.Decode(context.Background(), encoded, &output, "test"))
+			assert.Equal(t, input, output)
+		}
+	})
+
+	t.Run("Encode returns an error if the type is wrong", func(t *testing.T) {
+		_, err := optional.Encode(uint16(1), nil)
+		assert.True(t, errors.Is(err, types.ErrInvalidType))
+	})
+
+	t.Run("Decode returns an error for invalid tags", func(t *testing.T) {
+		_, _, err := optional.Decode([]byte{2, 1, 1})
+		assert.True(t, errors.Is(err, types.ErrInvalidEncoding))
+
+		_, _, err = optional.Decode(nil)
+		assert.True(t, errors.Is(err, types.ErrInvalidEncoding))
+	})
+
+	t.Run("Size is the size of a present value", func(t *testing.T) {
+		size, err := optional.Size(1)
+		require.NoError(t, err)
+		assert.Equal(t, 3, size)
+
+		_, err = optional.FixedSize()
+		assert.True(t, errors.Is(err, types.ErrInvalidType))
+	})
+}
+
+type name string
+
+func (n name) String() string {
+	return string(n)
+}
+
+// stringerCodec encodes a fmt.Stringer as its u8 length followed by its string, decoding it as a name.
+type stringerCodec struct{}
+
+func (stringerCodec) Encode(value any, into []byte) ([]byte, error) {
+	stringer, ok := value.(fmt.Stringer)
+	if !ok {
+		return nil, types.ErrInvalidType
+	}
+	s := stringer.String()
+	return append(append(into, byte(len(s))), s...), nil
+}
+
+func (stringerCodec) Decode(encoded []byte) (any, []byte, error) {
+	if len(encoded) < 1 || len(encoded) < int(encoded[0])+1 {
+		return nil, nil, types.ErrInvalidEncoding
+	}
+	return name(encoded[1 : encoded[0]+1]), encoded[encoded[0]+1:], nil
+}
+
+func (stringerCodec) GetType() reflect.Type {
+	return reflect.TypeOf((*fmt.Stringer)(nil)).Elem()
+}
+
+func (stringerCodec) Size(_ int) (int, error) {
+	return 256, nil
+}
+
+func (stringerCodec) FixedSize() (int, error) {
+	return 0, types.ErrInvalidType
+}
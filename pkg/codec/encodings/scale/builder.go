@@ -0,0 +1,36 @@
+// Package scale encodes values following the SCALE codec used by Substrate chains,
+// see https://docs.substrate.io/reference/scale-codec/.
+// Fixed-size types are encoded like Borsh, while strings and slices are prefixed by their compact length.
+package scale
+
+import (
+	"github.com/smartcontractkit/chainlink-common/pkg/codec/encodings"
+	"github.com/smartcontractkit/chainlink-common/pkg/codec/encodings/binary"
+	"github.com/smartcontractkit/chainlink-common/pkg/codec/encodings/borsh"
+)
+
+// Builder returns an encodings.Builder for SCALE types.
+// u128 and i128 are built with BigInt(16, signed).
+// SCALE doesn't define floats, they are encoded like Borsh.
+func Builder() encodings.Builder {
+	return builder{Builder: borsh.Builder()}
+}
+
+type builder struct {
+	encodings.Builder
+}
+
+func (builder) String(maxLen uint) (encodings.TypeCodec, error) {
+	return binary.NewLengthPrefixedString(maxLen, Compact{})
+}
+
+// NewSlice creates a codec for Vec<T>, encoded as its compact length followed by its elements.
+func NewSlice(underlying encodings.TypeCodec) (encodings.TypeCodec, error) {
+	return encodings.NewSlice(underlying, Compact{})
+}
+
+// Enum creates a codec for an enum with the given variants, which SCALE encodes like Borsh.
+// See borsh.Enum for the type of its values.
+func Enum(variants []encodings.NamedTypeCodec) (encodings.TypeCodec, error) {
+	return borsh.Enum(variants)
+}
@@ -0,0 +1,68 @@
+package scale_test
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/codec/encodings"
+	"github.com/smartcontractkit/chainlink-common/pkg/codec/encodings/scale"
+	"github.com/smartcontractkit/chainlink-common/pkg/codec/encodings/testutils"
+)
+
+func TestBuilder(t *testing.T) {
+	t.Parallel()
+	testutils.RunBuilderTests(t, scale.Builder(), scale.Compact{})
+
+	t.Run("Strings are prefixed by their compact length", func(t *testing.T) {
+		str, err := scale.Builder().String(100)
+		require.NoError(t, err)
+
+		encoded, err := str.Encode("hi", nil)
+		require.NoError(t, err)
+		assert.Equal(t, fromHex(t, "086869"), encoded)
+	})
+}
+
+func TestNewSlice(t *testing.T) {
+	t.Parallel()
+	slice, err := scale.NewSlice(scale.Builder().Uint16())
+	require.NoError(t, err)
+
+	values := []uint16{4, 8, 15, 16, 23, 42}
+	encoded, err := slice.Encode(values, nil)
+	require.NoError(t, err)
+	assert.Equal(t, fromHex(t, "18040008000f00100017002a00"), encoded)
+
+	decoded, remaining, err := slice.Decode(encoded)
+	require.NoError(t, err)
+	assert.Equal(t, values, decoded)
+	assert.Empty(t, remaining)
+
+	size, err := slice.Size(len(values))
+	require.NoError(t, err)
+	assert.GreaterOrEqual(t, size, len(encoded))
+}
+
+func TestEnum(t *testing.T) {
+	t.Parallel()
+	enum, err := scale.Enum([]encodings.NamedTypeCodec{
+		{Name: "A", Codec: encodings.Empty{}},
+		{Name: "B", Codec: scale.Builder().Uint8()},
+	})
+	require.NoError(t, err)
+
+	decoded, _, err := enum.Decode([]byte{1, 42})
+	require.NoError(t, err)
+	encoded, err := enum.Encode(decoded, nil)
+	require.NoError(t, err)
+	assert.Equal(t, []byte{1, 42}, encoded)
+}
+
+func fromHex(t *testing.T, s string) []byte {
+	b, err := hex.DecodeString(s)
+	require.NoError(t, err)
+	return b
+}
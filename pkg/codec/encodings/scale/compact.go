@@ -0,0 +1,114 @@
+package scale
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+	"math/bits"
+	"reflect"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/codec/encodings"
+	"github.com/smartcontractkit/chainlink-common/pkg/types"
+)
+
+const (
+	singleByteMode = 0b00
+	twoByteMode    = 0b01
+	fourByteMode   = 0b10
+	bigIntMode     = 0b11
+
+	// maxCompactSize is the size of the largest int, a prefix followed by eight bytes.
+	maxCompactSize = 9
+)
+
+// Compact encodes a non-negative int as a SCALE compact integer, using one, two or four bytes for values smaller than
+// 2^6, 2^14 and 2^30 respectively, and a byte with the number of bytes followed by the value for larger ones.
+// Decoding rejects encodings that don't use the smallest mode for the value.
+type Compact struct{}
+
+var _ encodings.TypeCodec = Compact{}
+
+func (Compact) Encode(value any, into []byte) ([]byte, error) {
+	i, ok := value.(int)
+	if !ok {
+		return nil, fmt.Errorf("%w: %T is not an int", types.ErrInvalidType, value)
+	}
+
+	if i < 0 {
+		return nil, fmt.Errorf("%w: compact integers cannot be negative, got %d", types.ErrInvalidType, i)
+	}
+
+	n := uint64(i)
+	switch {
+	case n < 1<<6:
+		return append(into, byte(n<<2|singleByteMode)), nil
+	case n < 1<<14:
+		return binary.LittleEndian.AppendUint16(into, uint16(n<<2|twoByteMode)), nil
+	case n < 1<<30:
+		return binary.LittleEndian.AppendUint32(into, uint32(n<<2|fourByteMode)), nil
+	default:
+		numBytes := (bits.Len64(n) + 7) / 8
+		into = append(into, byte((numBytes-4)<<2|bigIntMode))
+		return binary.LittleEndian.AppendUint64(into, n)[:len(into)+numBytes], nil
+	}
+}
+
+func (Compact) Decode(encoded []byte) (any, []byte, error) {
+	if len(encoded) < 1 {
+		return nil, nil, fmt.Errorf("%w: not enough bytes to decode type", types.ErrInvalidEncoding)
+	}
+
+	var n, smallest uint64
+	var size int
+	switch encoded[0] & 0b11 {
+	case singleByteMode:
+		return int(encoded[0] >> 2), encoded[1:], nil
+	case twoByteMode:
+		if len(encoded) < 2 {
+			return nil, nil, fmt.Errorf("%w: not enough bytes to decode type", types.ErrInvalidEncoding)
+		}
+		n, smallest, size = uint64(binary.LittleEndian.Uint16(encoded)>>2), 1<<6, 2
+	case fourByteMode:
+		if len(encoded) < 4 {
+			return nil, nil, fmt.Errorf("%w: not enough bytes to decode type", types.ErrInvalidEncoding)
+		}
+		n, smallest, size = uint64(binary.LittleEndian.Uint32(encoded)>>2), 1<<14, 4
+	default:
+		numBytes := int(encoded[0]>>2) + 4
+		if numBytes > 8 {
+			return nil, nil, fmt.Errorf("%w: compact integer of %d bytes doesn't fit into an int", types.ErrInvalidEncoding, numBytes)
+		}
+
+		size = numBytes + 1
+		if len(encoded) < size {
+			return nil, nil, fmt.Errorf("%w: not enough bytes to decode type", types.ErrInvalidEncoding)
+		}
+
+		var word [8]byte
+		copy(word[:], encoded[1:size])
+		n, smallest = binary.LittleEndian.Uint64(word[:]), max(1<<30, uint64(1)<<((numBytes-1)*8))
+	}
+
+	if n < smallest {
+		return nil, nil, fmt.Errorf("%w: %d is not encoded in its smallest mode", types.ErrInvalidEncoding, n)
+	}
+
+	if n > math.MaxInt {
+		return nil, nil, fmt.Errorf("%w: %d doesn't fit into an int", types.ErrInvalidEncoding, n)
+	}
+
+	return int(n), encoded[size:], nil
+}
+
+func (Compact) GetType() reflect.Type {
+	return reflect.TypeOf(0)
+}
+
+// Size returns the size of the largest int.
+func (Compact) Size(_ int) (int, error) {
+	return maxCompactSize, nil
+}
+
+func (Compact) FixedSize() (int, error) {
+	return 0, fmt.Errorf("%w: compact integers are not fixed size", types.ErrInvalidType)
+}
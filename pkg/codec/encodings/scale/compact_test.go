@@ -0,0 +1,85 @@
+package scale_test
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/codec/encodings/scale"
+	"github.com/smartcontractkit/chainlink-common/pkg/types"
+)
+
+func TestCompact(t *testing.T) {
+	t.Parallel()
+
+	for _, test := range []struct {
+		value   int
+		encoded string
+	}{
+		{value: 0, encoded: "00"},
+		{value: 1, encoded: "04"},
+		{value: 42, encoded: "a8"},
+		{value: 63, encoded: "fc"},
+		{value: 64, encoded: "0101"},
+		{value: 69, encoded: "1501"},
+		{value: 16383, encoded: "fdff"},
+		{value: 16384, encoded: "02000100"},
+		{value: 65535, encoded: "feff0300"},
+		{value: 1<<30 - 1, encoded: "feffffff"},
+		{value: 1 << 30, encoded: "0300000040"},
+		{value: 100000000000000, encoded: "0b00407a10f35a"},
+		{value: math.MaxInt64, encoded: "13ffffffffffffff7f"},
+	} {
+		t.Run(test.encoded, func(t *testing.T) {
+			encoded, err := scale.Compact{}.Encode(test.value, []byte{0xff})
+			require.NoError(t, err)
+			assert.Equal(t, append([]byte{0xff}, fromHex(t, test.encoded)...), encoded)
+
+			decoded, remaining, err := scale.Compact{}.Decode(append(fromHex(t, test.encoded), 0xff))
+			require.NoError(t, err)
+			assert.Equal(t, test.value, decoded)
+			assert.Equal(t, []byte{0xff}, remaining)
+		})
+	}
+
+	t.Run("Encode returns an error for negative values and the wrong type", func(t *testing.T) {
+		_, err := scale.Compact{}.Encode(-1, nil)
+		assert.True(t, errors.Is(err, types.ErrInvalidType))
+
+		_, err = scale.Compact{}.Encode(uint(1), nil)
+		assert.True(t, errors.Is(err, types.ErrInvalidType))
+	})
+
+	t.Run("Decode returns an error for values not encoded in their smallest mode", func(t *testing.T) {
+		for _, encoded := range []string{"0100", "02010000", "03ffffff3f", "07ffffffff00"} {
+			_, _, err := scale.Compact{}.Decode(fromHex(t, encoded))
+			assert.True(t, errors.Is(err, types.ErrInvalidEncoding), encoded)
+		}
+	})
+
+	t.Run("Decode returns an error for values that don't fit into an int", func(t *testing.T) {
+		for _, encoded := range []string{"13ffffffffffffffff", "170000000000000000ff"} {
+			_, _, err := scale.Compact{}.Decode(fromHex(t, encoded))
+			assert.True(t, errors.Is(err, types.ErrInvalidEncoding), encoded)
+		}
+	})
+
+	t.Run("Decode returns an error if there are not enough bytes", func(t *testing.T) {
+		for _, encoded := range []string{"", "01", "020000", "0b00407a10f3"} {
+			_, _, err := scale.Compact{}.Decode(fromHex(t, encoded))
+			assert.True(t, errors.Is(err, types.ErrInvalidEncoding), encoded)
+		}
+	})
+
+	t.Run("Size is the size of the largest int", func(t *testing.T) {
+		size, err := scale.Compact{}.Size(1)
+		require.NoError(t, err)
+		assert.Equal(t, 9, size)
+
+		_, err = scale.Compact{}.FixedSize()
+		assert.True(t, errors.Is(err, types.ErrInvalidType))
+	})
+}
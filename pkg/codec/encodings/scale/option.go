@@ -0,0 +1,68 @@
+package scale
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/codec/encodings"
+	"github.com/smartcontractkit/chainlink-common/pkg/codec/encodings/borsh"
+	"github.com/smartcontractkit/chainlink-common/pkg/types"
+)
+
+// Option creates a codec for Option<T>, which SCALE encodes like Borsh, except for Option<bool>, which is a single
+// byte that's 0 for None, 1 for true and 2 for false. See borsh.Option for the type of its values.
+func Option(underlying encodings.TypeCodec) (encodings.TypeCodec, error) {
+	if underlying != nil && underlying.GetType() == reflect.TypeOf(true) {
+		return optionBool{}, nil
+	}
+
+	return borsh.Option(underlying)
+}
+
+type optionBool struct{}
+
+var _ encodings.TypeCodec = optionBool{}
+
+func (optionBool) Encode(value any, into []byte) ([]byte, error) {
+	b, ok := value.(*bool)
+	switch {
+	case !ok:
+		return nil, fmt.Errorf("%w: expected *bool, got %T", types.ErrInvalidType, value)
+	case b == nil:
+		return append(into, 0), nil
+	case *b:
+		return append(into, 1), nil
+	default:
+		return append(into, 2), nil
+	}
+}
+
+func (optionBool) Decode(encoded []byte) (any, []byte, error) {
+	if len(encoded) < 1 {
+		return nil, nil, fmt.Errorf("%w: not enough bytes to decode type", types.ErrInvalidEncoding)
+	}
+
+	var b *bool
+	switch encoded[0] {
+	case 0:
+	case 1, 2:
+		value := encoded[0] == 1
+		b = &value
+	default:
+		return nil, nil, fmt.Errorf("%w: %d is not an Option<bool>", types.ErrInvalidEncoding, encoded[0])
+	}
+
+	return b, encoded[1:], nil
+}
+
+func (optionBool) GetType() reflect.Type {
+	return reflect.TypeOf((*bool)(nil))
+}
+
+func (optionBool) Size(_ int) (int, error) {
+	return 1, nil
+}
+
+func (optionBool) FixedSize() (int, error) {
+	return 1, nil
+}
@@ -0,0 +1,52 @@
+package scale_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/codec/encodings/scale"
+	"github.com/smartcontractkit/chainlink-common/pkg/types"
+)
+
+func TestOption(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Option<bool> is a single byte", func(t *testing.T) {
+		option, err := scale.Option(scale.Builder().Bool())
+		require.NoError(t, err)
+
+		yes, no := true, false
+		for _, test := range []struct {
+			value   *bool
+			encoded byte
+		}{{value: nil, encoded: 0}, {value: &yes, encoded: 1}, {value: &no, encoded: 2}} {
+			encoded, err := option.Encode(test.value, nil)
+			require.NoError(t, err)
+			assert.Equal(t, []byte{test.encoded}, encoded)
+
+			decoded, remaining, err := option.Decode([]byte{test.encoded})
+			require.NoError(t, err)
+			assert.Equal(t, test.value, decoded)
+			assert.Empty(t, remaining)
+		}
+
+		_, _, err = option.Decode([]byte{3})
+		assert.True(t, errors.Is(err, types.ErrInvalidEncoding))
+
+		_, err = option.Encode(true, nil)
+		assert.True(t, errors.Is(err, types.ErrInvalidType))
+	})
+
+	t.Run("Other options are prefixed by a tag", func(t *testing.T) {
+		option, err := scale.Option(scale.Builder().Uint16())
+		require.NoError(t, err)
+
+		value := uint16(258)
+		encoded, err := option.Encode(&value, nil)
+		require.NoError(t, err)
+		assert.Equal(t, []byte{1, 2, 1}, encoded)
+	})
+}
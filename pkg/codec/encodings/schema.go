@@ -0,0 +1,224 @@
+package encodings
+
+import (
+	"fmt"
+	"maps"
+	"math/big"
+	"reflect"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/codec"
+	"github.com/smartcontractkit/chainlink-common/pkg/types"
+)
+
+const (
+	// KindOptional is the kind of optional values, see NewOptional.
+	KindOptional = "optional"
+	// KindUnion is the kind of tagged unions, see NewUnion.
+	KindUnion = "union"
+)
+
+// ItemSchema describes an item type of a codec, as encoded on-chain and as used off-chain after modifiers are applied.
+type ItemSchema struct {
+	ItemType string
+	OnChain  *TypeSchema
+	OffChain *TypeSchema
+	// Modifiers are the names of the modifiers applied to the on-chain type, in order, see codec.ModifierName.
+	Modifiers []string `json:",omitempty"`
+}
+
+// TypeSchema describes a type.
+// Its kind is the reflect.Kind of its Go type, or KindOptional or KindUnion for those codecs.
+type TypeSchema struct {
+	GoType string
+	Kind   string
+	// Size is the number of bytes the type is encoded into on-chain, if it's fixed.
+	Size *int `json:",omitempty"`
+	// Len is the number of elements of arrays.
+	Len    int            `json:",omitempty"`
+	Elem   *TypeSchema    `json:",omitempty"`
+	Fields []*FieldSchema `json:",omitempty"`
+	// Recursive is set on a type used within itself, which is only described where it's first used.
+	Recursive bool `json:",omitempty"`
+}
+
+// FieldSchema describes a field of a struct or a variant of a union.
+type FieldSchema struct {
+	Name string
+	// Tag is the tag of union variants.
+	Tag  any `json:",omitempty"`
+	Type *TypeSchema
+}
+
+// SchemaDescriber is implemented by a TypeCodec composed of other codecs, to describe its schema with theirs.
+type SchemaDescriber interface {
+	DescribeSchema() *TypeSchema
+}
+
+// DescribeTypeCodec describes the schema of a TypeCodec.
+// Codecs that don't implement SchemaDescriber are described by their Go type and fixed size.
+func DescribeTypeCodec(c TypeCodec) *TypeSchema {
+	if describer, ok := c.(SchemaDescriber); ok {
+		return describer.DescribeSchema()
+	}
+	return describeCodecType(c)
+}
+
+// opaqueGoTypes are the structs DescribeGoType describes as a whole, since they marshal as a single value.
+var opaqueGoTypes = []reflect.Type{reflect.TypeOf(big.Int{}), reflect.TypeOf(decimal.Decimal{}), reflect.TypeOf(time.Time{})}
+
+// DescribeGoType describes the schema of a Go type, such as an off-chain type, as it's marshalled by encoding/json.
+// Only exported fields of structs are described, under the name of their json tag if they have one, and fields
+// tagged with "-" are skipped. Named structs without exported fields, big.Int, decimal.Decimal and time.Time are
+// described as a whole.
+func DescribeGoType(t reflect.Type) *TypeSchema {
+	return describeGoType(t, map[reflect.Type]bool{})
+}
+
+// describeGoType describes t, visiting holds the types t is used in, to stop at recursive types.
+func describeGoType(t reflect.Type, visiting map[reflect.Type]bool) *TypeSchema {
+	schema := &TypeSchema{GoType: t.String(), Kind: t.Kind().String()}
+	if slices.Contains(opaqueGoTypes, t) {
+		return schema
+	}
+
+	switch t.Kind() {
+	case reflect.Pointer, reflect.Slice, reflect.Map, reflect.Array, reflect.Struct:
+		if visiting[t] {
+			schema.Recursive = true
+			return schema
+		}
+		visiting[t] = true
+		defer delete(visiting, t)
+	default:
+	}
+
+	switch t.Kind() {
+	case reflect.Pointer, reflect.Slice, reflect.Map:
+		schema.Elem = describeGoType(t.Elem(), visiting)
+	case reflect.Array:
+		schema.Len = t.Len()
+		schema.Elem = describeGoType(t.Elem(), visiting)
+	case reflect.Struct:
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+			if !field.IsExported() {
+				continue
+			}
+
+			name := field.Name
+			if tag, ok := field.Tag.Lookup("json"); ok {
+				if tag == "-" {
+					continue
+				}
+				if tagName, _, _ := strings.Cut(tag, ","); tagName != "" {
+					name = tagName
+				}
+			}
+			schema.Fields = append(schema.Fields, &FieldSchema{Name: name, Type: describeGoType(field.Type, visiting)})
+		}
+	default:
+	}
+	return schema
+}
+
+// DescribeItemType describes the item type on-chain and off-chain, after applying modifier, which can be nil.
+func (c CodecFromTypeCodec) DescribeItemType(itemType string, modifier codec.Modifier) (*ItemSchema, error) {
+	ntcwt, ok := c[itemType]
+	if !ok {
+		return nil, fmt.Errorf("%w: cannot find type %s", types.ErrInvalidType, itemType)
+	}
+
+	offChainType := ntcwt.GetType()
+	var modifiers []string
+	if modifier != nil {
+		var err error
+		if offChainType, err = modifier.RetypeToOffChain(offChainType, itemType); err != nil {
+			return nil, err
+		}
+
+		for _, m := range codec.ModifiersForItemType(modifier, itemType) {
+			modifiers = append(modifiers, codec.ModifierName(m))
+		}
+	}
+
+	return &ItemSchema{
+		ItemType:  itemType,
+		OnChain:   DescribeTypeCodec(ntcwt),
+		OffChain:  DescribeGoType(offChainType),
+		Modifiers: modifiers,
+	}, nil
+}
+
+// Describe describes all the item types of the codec, sorted by item type, see DescribeItemType.
+func (c CodecFromTypeCodec) Describe(modifier codec.Modifier) ([]*ItemSchema, error) {
+	itemTypes := slices.Sorted(maps.Keys(c))
+	schemas := make([]*ItemSchema, len(itemTypes))
+	for i, itemType := range itemTypes {
+		var err error
+		if schemas[i], err = c.DescribeItemType(itemType, modifier); err != nil {
+			return nil, err
+		}
+	}
+	return schemas, nil
+}
+
+func (s *structCodec) DescribeSchema() *TypeSchema {
+	schema := describeCodecType(s)
+	schema.Kind = reflect.Struct.String()
+	for i, field := range s.fields {
+		schema.Fields = append(schema.Fields, &FieldSchema{Name: s.tpe.Elem().Field(i).Name, Type: DescribeTypeCodec(field)})
+	}
+	return schema
+}
+
+// DescribeSchema describes the pointed to type, as the pointer is only used to verify fields are set.
+func (n *NotNilPointer) DescribeSchema() *TypeSchema {
+	return DescribeTypeCodec(n.Elm)
+}
+
+func (s *slice) DescribeSchema() *TypeSchema {
+	schema := describeCodecType(s)
+	schema.Elem = DescribeTypeCodec(s.Field)
+	return schema
+}
+
+func (a *array) DescribeSchema() *TypeSchema {
+	schema := describeCodecType(a)
+	schema.Len = a.NumElements
+	schema.Elem = DescribeTypeCodec(a.Field)
+	return schema
+}
+
+func (o *optional) DescribeSchema() *TypeSchema {
+	schema := describeCodecType(o)
+	schema.Kind = KindOptional
+	schema.Elem = DescribeTypeCodec(o.elm)
+	return schema
+}
+
+func (u *union) DescribeSchema() *TypeSchema {
+	schema := describeCodecType(u)
+	schema.Kind = KindUnion
+	for i, variant := range u.variants {
+		schema.Fields = append(schema.Fields, &FieldSchema{
+			Name: u.tpe.Elem().Field(i).Name,
+			Tag:  u.tags[i],
+			Type: DescribeTypeCodec(variant),
+		})
+	}
+	return schema
+}
+
+// describeCodecType describes the Go type and fixed size of a codec, without the codecs it's composed of.
+func describeCodecType(c TypeCodec) *TypeSchema {
+	schema := &TypeSchema{GoType: c.GetType().String(), Kind: c.GetType().Kind().String()}
+	if size, err := c.FixedSize(); err == nil {
+		schema.Size = &size
+	}
+	return schema
+}
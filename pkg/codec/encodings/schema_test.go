@@ -0,0 +1,215 @@
+package encodings_test
+
+import (
+	"encoding/json"
+	"errors"
+	"math/big"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/codec"
+	"github.com/smartcontractkit/chainlink-common/pkg/codec/encodings"
+	"github.com/smartcontractkit/chainlink-common/pkg/codec/encodings/binary"
+	"github.com/smartcontractkit/chainlink-common/pkg/types"
+)
+
+func TestDescribe(t *testing.T) {
+	t.Parallel()
+	builder := binary.BigEndian()
+	str, err := builder.String(10)
+	require.NoError(t, err)
+	values, err := encodings.NewSlice(builder.Int16(), mustInt(t, builder, 1))
+	require.NoError(t, err)
+	pair, err := encodings.NewArray(2, builder.Uint8())
+	require.NoError(t, err)
+	optional, err := encodings.NewOptional(builder.Uint32())
+	require.NoError(t, err)
+	union, err := encodings.NewUnion(builder.Uint8(), map[uint8]encodings.NamedTypeCodec{
+		2: {Name: "Name", Codec: str},
+		1: {Name: "Unit", Codec: encodings.Empty{}},
+	})
+	require.NoError(t, err)
+	structCodec, err := encodings.NewStructCodec([]encodings.NamedTypeCodec{
+		{Name: "Timestamp", Codec: builder.Uint64()},
+		{Name: "Values", Codec: values},
+		{Name: "Pair", Codec: pair},
+		{Name: "Count", Codec: optional},
+		{Name: "Event", Codec: union},
+	})
+	require.NoError(t, err)
+	c := encodings.CodecFromTypeCodec{"item": structCodec, "flag": builder.Bool()}
+
+	modifier, err := codec.NewByItemTypeModifier(map[string]codec.Modifier{
+		"item": codec.MultiModifier{
+			codec.NewEpochToTimeModifier([]string{"Timestamp"}),
+			codec.NewRenamer(map[string]string{"Pair": "Bytes"}),
+		},
+		"flag": codec.MultiModifier{},
+	})
+	require.NoError(t, err)
+
+	t.Run("DescribeItemType describes the on-chain codecs", func(t *testing.T) {
+		schema, err := c.DescribeItemType("item", modifier)
+		require.NoError(t, err)
+		assert.Equal(t, "item", schema.ItemType)
+
+		onChain := schema.OnChain
+		assert.Equal(t, reflect.Struct.String(), onChain.Kind)
+		assert.Nil(t, onChain.Size)
+		require.Len(t, onChain.Fields, 5)
+
+		timestamp := onChain.Fields[0]
+		assert.Equal(t, "Timestamp", timestamp.Name)
+		assert.Equal(t, &encodings.TypeSchema{GoType: "uint64", Kind: "uint64", Size: intPtr(8)}, timestamp.Type)
+
+		assert.Equal(t, reflect.Slice.String(), onChain.Fields[1].Type.Kind)
+		assert.Equal(t, "int16", onChain.Fields[1].Type.Elem.GoType)
+
+		assert.Equal(t, &encodings.TypeSchema{
+			GoType: "[2]uint8",
+			Kind:   "array",
+			Size:   intPtr(2),
+			Len:    2,
+			Elem:   &encodings.TypeSchema{GoType: "uint8", Kind: "uint8", Size: intPtr(1)},
+		}, onChain.Fields[2].Type)
+
+		assert.Equal(t, encodings.KindOptional, onChain.Fields[3].Type.Kind)
+		assert.Equal(t, "uint32", onChain.Fields[3].Type.Elem.GoType)
+
+		event := onChain.Fields[4].Type
+		assert.Equal(t, encodings.KindUnion, event.Kind)
+		require.Len(t, event.Fields, 2)
+		assert.Equal(t, "Unit", event.Fields[0].Name)
+		assert.Equal(t, uint8(1), event.Fields[0].Tag)
+		assert.Equal(t, "Name", event.Fields[1].Name)
+		assert.Equal(t, uint8(2), event.Fields[1].Tag)
+	})
+
+	t.Run("DescribeItemType describes the off-chain type and the modifiers applied", func(t *testing.T) {
+		schema, err := c.DescribeItemType("item", modifier)
+		require.NoError(t, err)
+
+		offChain := schema.OffChain
+		assert.Equal(t, reflect.Pointer.String(), offChain.Kind)
+		require.Len(t, offChain.Elem.Fields, 5)
+		assert.Equal(t, "Timestamp", offChain.Elem.Fields[0].Name)
+		assert.Equal(t, reflect.TypeOf(&time.Time{}).String(), offChain.Elem.Fields[0].Type.GoType)
+		assert.Empty(t, offChain.Elem.Fields[0].Type.Elem.Fields, "time.Time has no exported fields")
+		assert.Equal(t, "Bytes", offChain.Elem.Fields[2].Name)
+
+		assert.Equal(t, []string{"epoch to time", "rename"}, schema.Modifiers)
+	})
+
+	t.Run("OffChainJSONSchema returns the JSON schema of the off-chain type", func(t *testing.T) {
+		schema, err := c.DescribeItemType("item", modifier)
+		require.NoError(t, err)
+
+		raw, err := json.Marshal(schema.OffChainJSONSchema())
+		require.NoError(t, err)
+		assert.JSONEq(t, `{
+			"$schema": "https://json-schema.org/draft/2020-12/schema",
+			"title": "item",
+			"type": "object",
+			"additionalProperties": false,
+			"required": [],
+			"properties": {
+				"Timestamp": {"type": "string", "format": "date-time"},
+				"Values": {"type": "array", "items": {"type": "integer", "minimum": -32768, "maximum": 32767}},
+				"Bytes": {"type": "array", "items": {"type": "integer", "minimum": 0, "maximum": 255}, "minItems": 2, "maxItems": 2},
+				"Count": {"type": "integer", "minimum": 0, "maximum": 4294967295},
+				"Event": {
+					"type": "object",
+					"additionalProperties": false,
+					"required": [],
+					"properties": {
+						"Unit": {"type": "object", "additionalProperties": false, "required": [], "properties": {}},
+						"Name": {"type": "string"}
+					}
+				}
+			}
+		}`, string(raw))
+	})
+
+	t.Run("OnChain JSON schema requires fields and a single union variant", func(t *testing.T) {
+		schema, err := c.DescribeItemType("item", nil)
+		require.NoError(t, err)
+
+		jsonSchema := schema.OnChain.JSONSchema()
+		assert.ElementsMatch(t, []string{"Timestamp", "Values", "Pair", "Event"}, jsonSchema["required"])
+		event := jsonSchema["properties"].(map[string]any)["Event"].(map[string]any)
+		assert.Equal(t, 1, event["minProperties"])
+		assert.Equal(t, 1, event["maxProperties"])
+	})
+
+	t.Run("Describe describes all item types in order", func(t *testing.T) {
+		schemas, err := c.Describe(modifier)
+		require.NoError(t, err)
+		require.Len(t, schemas, 2)
+		assert.Equal(t, "flag", schemas[0].ItemType)
+		assert.Equal(t, &encodings.TypeSchema{GoType: "bool", Kind: "bool", Size: intPtr(1)}, schemas[0].OnChain)
+		assert.Empty(t, schemas[0].Modifiers)
+		assert.Equal(t, "item", schemas[1].ItemType)
+	})
+
+	t.Run("DescribeItemType returns an error for unknown item types", func(t *testing.T) {
+		_, err := c.DescribeItemType("unknown", nil)
+		assert.True(t, errors.Is(err, types.ErrInvalidType))
+	})
+}
+
+type describedNode struct {
+	Name     string          `json:"name"`
+	Price    decimal.Decimal `json:"price,omitempty"`
+	Amount   *big.Int
+	Ignored  string `json:"-"`
+	Dash     string `json:"-,"`
+	Children []describedNode
+	Parent   *describedNode
+}
+
+func TestDescribeGoType(t *testing.T) {
+	t.Parallel()
+	schema := encodings.DescribeGoType(reflect.TypeOf(describedNode{}))
+
+	names := make([]string, len(schema.Fields))
+	for i, field := range schema.Fields {
+		names[i] = field.Name
+	}
+	assert.Equal(t, []string{"name", "price", "Amount", "-", "Children", "Parent"}, names)
+
+	assert.Empty(t, schema.Fields[1].Type.Fields, "decimal.Decimal is described as a whole")
+	assert.Empty(t, schema.Fields[2].Type.Elem.Fields, "big.Int is described as a whole")
+	assert.True(t, schema.Fields[4].Type.Elem.Recursive)
+	assert.True(t, schema.Fields[5].Type.Elem.Recursive)
+
+	raw, err := json.Marshal(schema.JSONSchema())
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"type": "object",
+		"additionalProperties": false,
+		"required": ["name", "price", "-", "Children"],
+		"properties": {
+			"name": {"type": "string"},
+			"price": {"type": ["string", "number"]},
+			"Amount": {"type": "integer"},
+			"-": {"type": "string"},
+			"Children": {"type": "array", "items": {}},
+			"Parent": {}
+		}
+	}`, string(raw))
+}
+
+func mustInt(t *testing.T, builder encodings.Builder, bytes uint) encodings.TypeCodec {
+	c, err := builder.Int(bytes)
+	require.NoError(t, err)
+	return c
+}
+
+func intPtr(i int) *int {
+	return &i
+}
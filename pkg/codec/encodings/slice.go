@@ -36,19 +36,17 @@ func (s *slice) Encode(value any, into []byte) ([]byte, error) {
 		return nil, types.ErrNotASlice
 	}
 
-	fs, err := s.SizeCodec.FixedSize()
-	if err != nil {
-		return nil, err
-	}
-
+	// sizes with a variable length, such as varints, check the number of elements when they encode it
 	numElements := rValue.Len()
-	maxElements := new(big.Int).Lsh(big.NewInt(1), uint(fs*8))
-	if big.NewInt(int64(numElements)).Cmp(maxElements) > 0 {
-		return nil, fmt.Errorf("%w: %v is too big to encode into a %v-bytes slice", types.ErrSliceWrongLen, numElements, fs)
+	if fs, err := s.SizeCodec.FixedSize(); err == nil {
+		maxElements := new(big.Int).Lsh(big.NewInt(1), uint(fs*8))
+		if big.NewInt(int64(numElements)).Cmp(maxElements) > 0 {
+			return nil, fmt.Errorf("%w: %v is too big to encode into a %v-bytes slice", types.ErrSliceWrongLen, numElements, fs)
+		}
 	}
 
 	toEncode := reflect.ValueOf(rValue.Len()).Convert(s.SizeCodec.GetType()).Interface()
-	into, err = s.SizeCodec.Encode(toEncode, into)
+	into, err := s.SizeCodec.Encode(toEncode, into)
 	if err != nil {
 		return nil, err
 	}
@@ -82,7 +80,10 @@ func (s *slice) GetType() reflect.Type {
 func (s *slice) Size(numItems int) (int, error) {
 	sizeSize, err := s.SizeCodec.FixedSize()
 	if err != nil {
-		return 0, err
+		// sizes with a variable length, such as varints, return the max size of the length instead
+		if sizeSize, err = s.SizeCodec.Size(numItems); err != nil {
+			return 0, err
+		}
 	}
 
 	elemSize, err := s.Field.FixedSize()
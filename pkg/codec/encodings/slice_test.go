@@ -171,8 +171,36 @@ func TestSlice(t *testing.T) {
 		require.Equal(t, anyErr, err)
 	})
 
+	varSizeSlice, sliceCreateErr := encodings.NewSlice(elementCodec, &variableSizeCodec{TestTypeCodec: *sizeCodec})
+	require.NoError(t, sliceCreateErr)
+
+	t.Run("Encode works with size codecs without a fixed size", func(t *testing.T) {
+		encoded, err := varSizeSlice.Encode([]int{anyValue, anyValue, anyValue}, nil)
+		require.NoError(t, err)
+		assert.Equal(t, []byte{3, 0x03, 0x04, 0x03, 0x04, 0x03, 0x04}, encoded)
+	})
+
+	t.Run("Size uses the size of the length for size codecs without a fixed size", func(t *testing.T) {
+		size, err := varSizeSlice.Size(3)
+		require.NoError(t, err)
+		assert.Equal(t, 3*len(elementCodec.Bytes)+len(sizeCodec.Bytes), size)
+	})
+
 	t.Run("FixedSize returns an error", func(t *testing.T) {
 		_, err := testSlice.FixedSize()
 		require.True(t, errors.Is(err, types.ErrInvalidType))
 	})
 }
+
+// variableSizeCodec acts like a size codec with a variable length, such as a varint.
+type variableSizeCodec struct {
+	testutils.TestTypeCodec
+}
+
+func (v *variableSizeCodec) Size(int) (int, error) {
+	return len(v.Bytes), v.Err
+}
+
+func (v *variableSizeCodec) FixedSize() (int, error) {
+	return 0, types.ErrInvalidType
+}
@@ -14,7 +14,8 @@ type NamedTypeCodec struct {
 
 // NewStructCodec creates a codec that encodes fields with the given names and codecs in-order.
 // Note: To verify fields are not defaulted,
-// Codecs with non-pointer types in fields will be wrapped with encodings.NotNilPointer
+// Codecs with non-pointer types in fields will be wrapped with encodings.NotNilPointer,
+// except for interfaces, which are left nil when the codec decodes nil.
 func NewStructCodec(fields []NamedTypeCodec) (c TopLevelCodec, err error) {
 	defer func() {
 		if r := recover(); r != nil {
@@ -26,7 +27,7 @@ func NewStructCodec(fields []NamedTypeCodec) (c TopLevelCodec, err error) {
 	codecFields := make([]TypeCodec, len(fields))
 	for i, field := range fields {
 		ft := field.Codec.GetType()
-		if ft.Kind() != reflect.Pointer {
+		if ft.Kind() != reflect.Pointer && ft.Kind() != reflect.Interface {
 			field.Codec = &NotNilPointer{Elm: field.Codec}
 			ft = reflect.PointerTo(ft)
 		}
@@ -76,7 +77,9 @@ func (s *structCodec) Decode(encoded []byte) (any, []byte, error) {
 		if fieldValue, encoded, err = field.Decode(encoded); err != nil {
 			return nil, nil, err
 		}
-		iVal.Field(i).Set(reflect.ValueOf(fieldValue))
+		if fieldValue != nil {
+			iVal.Field(i).Set(reflect.ValueOf(fieldValue))
+		}
 	}
 
 	return rVal.Interface(), encoded, nil
@@ -0,0 +1,143 @@
+package testutils
+
+import (
+	"context"
+	"errors"
+	"math"
+	"math/big"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/codec/encodings"
+	"github.com/smartcontractkit/chainlink-common/pkg/types"
+	"github.com/smartcontractkit/libocr/commontypes"
+)
+
+// RunBuilderTests runs the tests every encodings.Builder is expected to pass.
+// The codecs it builds must round trip values of their type without touching the bytes around them,
+// and must compose with the struct, array and slice codecs, using size to encode the length of slices.
+func RunBuilderTests(t *testing.T, builder encodings.Builder, size encodings.TypeCodec) {
+	str, err := builder.String(10)
+	require.NoError(t, err)
+
+	maxUint128 := new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 128), big.NewInt(1))
+	maxInt128 := new(big.Int).Rsh(maxUint128, 1)
+	minInt128 := new(big.Int).Neg(new(big.Int).Add(maxInt128, big.NewInt(1)))
+
+	for _, test := range []struct {
+		name   string
+		codec  func() (encodings.TypeCodec, error)
+		values []any
+	}{
+		{name: "Bool", codec: noErr(builder.Bool), values: []any{true, false}},
+		{name: "Int8", codec: noErr(builder.Int8), values: []any{int8(math.MinInt8), int8(0), int8(math.MaxInt8)}},
+		{name: "Int16", codec: noErr(builder.Int16), values: []any{int16(math.MinInt16), int16(0), int16(math.MaxInt16)}},
+		{name: "Int32", codec: noErr(builder.Int32), values: []any{int32(math.MinInt32), int32(0), int32(math.MaxInt32)}},
+		{name: "Int64", codec: noErr(builder.Int64), values: []any{int64(math.MinInt64), int64(0), int64(math.MaxInt64)}},
+		{name: "Uint8", codec: noErr(builder.Uint8), values: []any{uint8(0), uint8(math.MaxUint8)}},
+		{name: "Uint16", codec: noErr(builder.Uint16), values: []any{uint16(0), uint16(math.MaxUint16)}},
+		{name: "Uint32", codec: noErr(builder.Uint32), values: []any{uint32(0), uint32(math.MaxUint32)}},
+		{name: "Uint64", codec: noErr(builder.Uint64), values: []any{uint64(0), uint64(math.MaxUint64)}},
+		{name: "String", codec: func() (encodings.TypeCodec, error) { return str, nil }, values: []any{"", "0123456789"}},
+		{name: "Float32", codec: noErr(builder.Float32), values: []any{float32(-1.5), float32(0), float32(math.MaxFloat32)}},
+		{name: "Float64", codec: noErr(builder.Float64), values: []any{-1.5, float64(0), math.MaxFloat64}},
+		{name: "OracleID", codec: noErr(builder.OracleID), values: []any{commontypes.OracleID(0), commontypes.OracleID(math.MaxUint8)}},
+		{name: "Int", codec: func() (encodings.TypeCodec, error) { return builder.Int(8) }, values: []any{math.MinInt64, 0, math.MaxInt64}},
+		{name: "Uint", codec: func() (encodings.TypeCodec, error) { return builder.Uint(8) }, values: []any{uint(0), uint(math.MaxUint64)}},
+		{name: "BigInt signed", codec: func() (encodings.TypeCodec, error) { return builder.BigInt(16, true) }, values: []any{minInt128, big.NewInt(0), maxInt128}},
+		{name: "BigInt unsigned", codec: func() (encodings.TypeCodec, error) { return builder.BigInt(16, false) }, values: []any{big.NewInt(0), maxUint128}},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			codec, err := test.codec()
+			require.NoError(t, err)
+
+			for _, value := range test.values {
+				assertRoundTrip(t, codec, value)
+			}
+
+			t.Run("Encode returns an error for the wrong type", func(t *testing.T) {
+				_, err := codec.Encode(struct{}{}, nil)
+				assert.True(t, errors.Is(err, types.ErrInvalidType))
+			})
+
+			t.Run("Decode returns an error if there are no bytes", func(t *testing.T) {
+				_, _, err := codec.Decode(nil)
+				assert.True(t, errors.Is(err, types.ErrInvalidEncoding))
+			})
+		})
+	}
+
+	t.Run("Codecs compose with struct, array and slice codecs", func(t *testing.T) {
+		array, err := encodings.NewArray(2, builder.Uint16())
+		require.NoError(t, err)
+		slice, err := encodings.NewSlice(builder.Int32(), size)
+		require.NoError(t, err)
+		bigInt, err := builder.BigInt(16, true)
+		require.NoError(t, err)
+		structCodec, err := encodings.NewStructCodec([]encodings.NamedTypeCodec{
+			{Name: "Flag", Codec: builder.Bool()},
+			{Name: "Name", Codec: str},
+			{Name: "Pair", Codec: array},
+			{Name: "Values", Codec: slice},
+			{Name: "Amount", Codec: bigInt},
+		})
+		require.NoError(t, err)
+
+		type composite struct {
+			Flag   bool
+			Name   string
+			Pair   [2]uint16
+			Values []int32
+			Amount *big.Int
+		}
+
+		input := composite{Flag: true, Name: "name", Pair: [2]uint16{1, math.MaxUint16}, Values: []int32{-1, 0, 1}, Amount: minInt128}
+		c := encodings.CodecFromTypeCodec{"composite": structCodec}
+		encoded, err := c.Encode(context.Background(), &input, "composite")
+		require.NoError(t, err)
+
+		output := composite{}
+		require.NoError(t, c.Decode(context.Background(), encoded, &output, "composite"))
+		assert.Equal(t, input.Flag, output.Flag)
+		assert.Equal(t, input.Name, output.Name)
+		assert.Equal(t, input.Pair, output.Pair)
+		assert.Equal(t, input.Values, output.Values)
+		assert.Equal(t, 0, input.Amount.Cmp(output.Amount))
+	})
+}
+
+// assertRoundTrip checks that value is appended to the bytes it's encoded into, that it decodes back from the start
+// of the bytes it's decoded from, and that its encoding matches its fixed size if it has one.
+func assertRoundTrip(t *testing.T, codec encodings.TypeCodec, value any) {
+	prefix := []byte{0xde, 0xad}
+	suffix := []byte{0xbe, 0xef}
+
+	encoded, err := codec.Encode(value, append([]byte{}, prefix...))
+	require.NoError(t, err)
+	require.Equal(t, prefix, encoded[:len(prefix)])
+	encoded = encoded[len(prefix):]
+
+	if fixedSize, err := codec.FixedSize(); err == nil {
+		assert.Len(t, encoded, fixedSize)
+	}
+
+	decoded, remaining, err := codec.Decode(append(append([]byte{}, encoded...), suffix...))
+	require.NoError(t, err)
+	assert.Equal(t, suffix, remaining)
+	assert.Equal(t, codec.GetType(), reflect.TypeOf(decoded))
+
+	if expected, ok := value.(*big.Int); ok {
+		assert.Equal(t, 0, expected.Cmp(decoded.(*big.Int)), "expected %v, got %v", expected, decoded)
+	} else {
+		assert.Equal(t, value, decoded)
+	}
+}
+
+func noErr(fn func() encodings.TypeCodec) func() (encodings.TypeCodec, error) {
+	return func() (encodings.TypeCodec, error) {
+		return fn(), nil
+	}
+}
@@ -0,0 +1,221 @@
+package encodings
+
+import (
+	"cmp"
+	"fmt"
+	"reflect"
+	"slices"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/types"
+)
+
+// NewUnion creates a codec for a tagged union of the given variants, encoded as the tag of the variant, encoded with
+// the tag codec, followed by its value.
+// Unions are pointers to a struct with an optional field per variant, ordered by tag, of which exactly one must be set.
+// Variants without a value can use Empty.
+func NewUnion[T cmp.Ordered](tag TypeCodec, variants map[T]NamedTypeCodec) (TypeCodec, error) {
+	tags := make([]any, 0, len(variants))
+	namedVariants := make([]NamedTypeCodec, 0, len(variants))
+	for t, variant := range variants {
+		tags = append(tags, t)
+		namedVariants = append(namedVariants, variant)
+	}
+
+	return newUnion(tag, tags, namedVariants)
+}
+
+// newUnion creates a union of the variants with the given tags, which must be of the type of the tag codec.
+func newUnion(tag TypeCodec, tags []any, variants []NamedTypeCodec) (c TypeCodec, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("%w: %v", types.ErrInvalidConfig, r)
+		}
+	}()
+
+	if tag == nil {
+		return nil, fmt.Errorf("%w: tag cannot be nil", types.ErrInvalidConfig)
+	}
+
+	if len(variants) == 0 || len(tags) != len(variants) {
+		return nil, fmt.Errorf("%w: unions must have at least one variant", types.ErrInvalidConfig)
+	}
+
+	tags, variants = sortByTag(tags, variants)
+	sfs := make([]reflect.StructField, len(variants))
+	codecs := make([]TypeCodec, len(variants))
+	tagIndices := make(map[any]int, len(variants))
+	for i, variant := range variants {
+		if reflect.TypeOf(tags[i]) != tag.GetType() {
+			return nil, fmt.Errorf("%w: tag of variant %s must be a %v, got %T", types.ErrInvalidConfig, variant.Name, tag.GetType(), tags[i])
+		}
+
+		if _, ok := tagIndices[tags[i]]; ok {
+			return nil, fmt.Errorf("%w: tag %v is used by more than one variant", types.ErrInvalidConfig, tags[i])
+		}
+
+		if variant.Codec == nil {
+			return nil, fmt.Errorf("%w: variant %s cannot have a nil type", types.ErrInvalidConfig, variant.Name)
+		}
+
+		sfs[i] = reflect.StructField{
+			Name: variant.Name,
+			Type: optionalType(variant.Codec.GetType()),
+		}
+		codecs[i] = variant.Codec
+		tagIndices[tags[i]] = i
+	}
+
+	return &union{
+		tag:        tag,
+		tags:       tags,
+		tagIndices: tagIndices,
+		variants:   codecs,
+		tpe:        reflect.PointerTo(reflect.StructOf(sfs)),
+	}, nil
+}
+
+// sortByTag sorts the variants by their tags, which are ints, uints or strings.
+func sortByTag(tags []any, variants []NamedTypeCodec) ([]any, []NamedTypeCodec) {
+	indices := make([]int, len(tags))
+	for i := range indices {
+		indices[i] = i
+	}
+
+	slices.SortFunc(indices, func(a, b int) int {
+		ra, rb := reflect.ValueOf(tags[a]), reflect.ValueOf(tags[b])
+		switch ra.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			return cmp.Compare(ra.Int(), rb.Int())
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			return cmp.Compare(ra.Uint(), rb.Uint())
+		case reflect.Float32, reflect.Float64:
+			return cmp.Compare(ra.Float(), rb.Float())
+		default:
+			return cmp.Compare(ra.String(), rb.String())
+		}
+	})
+
+	sortedTags := make([]any, len(tags))
+	sortedVariants := make([]NamedTypeCodec, len(variants))
+	for i, index := range indices {
+		sortedTags[i] = tags[index]
+		sortedVariants[i] = variants[index]
+	}
+	return sortedTags, sortedVariants
+}
+
+type union struct {
+	tag        TypeCodec
+	tags       []any
+	tagIndices map[any]int
+	variants   []TypeCodec
+	tpe        reflect.Type
+}
+
+var _ TypeCodec = &union{}
+
+func (u *union) Encode(value any, into []byte) ([]byte, error) {
+	rValue := reflect.ValueOf(value)
+	if !rValue.IsValid() || rValue.Type() != u.tpe || rValue.IsNil() {
+		return nil, fmt.Errorf("%w: expected non-nil %v, got %T", types.ErrInvalidType, u.tpe, value)
+	}
+
+	rValue = rValue.Elem()
+	set := -1
+	for i := range u.variants {
+		if rValue.Field(i).IsNil() {
+			continue
+		}
+
+		if set != -1 {
+			return nil, fmt.Errorf("%w: more than one variant of %v is set", types.ErrInvalidType, u.tpe)
+		}
+		set = i
+	}
+
+	if set == -1 {
+		return nil, fmt.Errorf("%w: no variant of %v is set", types.ErrInvalidType, u.tpe)
+	}
+
+	into, err := u.tag.Encode(u.tags[set], into)
+	if err != nil {
+		return nil, err
+	}
+
+	variant := u.variants[set]
+	return variant.Encode(unwrapOptional(rValue.Field(set), variant.GetType()), into)
+}
+
+func (u *union) Decode(encoded []byte) (any, []byte, error) {
+	tag, remaining, err := u.tag.Decode(encoded)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	i, ok := u.tagIndices[tag]
+	if !ok {
+		return nil, nil, fmt.Errorf("%w: %v is not a variant of %v", types.ErrInvalidEncoding, tag, u.tpe)
+	}
+
+	value, remaining, err := u.variants[i].Decode(remaining)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	rValue := reflect.New(u.tpe.Elem())
+	if value != nil {
+		field := rValue.Elem().Field(i)
+		field.Set(reflect.ValueOf(wrapOptional(value, field.Type())))
+	}
+	return rValue.Interface(), remaining, nil
+}
+
+func (u *union) GetType() reflect.Type {
+	return u.tpe
+}
+
+// Size returns the size of the largest variant.
+func (u *union) Size(numItems int) (int, error) {
+	size := 0
+	for _, variant := range u.variants {
+		variantSize, err := variant.Size(numItems)
+		if err != nil {
+			return 0, err
+		}
+		size = max(size, variantSize)
+	}
+
+	tagSize, err := u.tagSize(numItems)
+	return tagSize + size, err
+}
+
+// FixedSize returns the size of the union if its tag is fixed size and all its variants have the same fixed size.
+func (u *union) FixedSize() (int, error) {
+	tagSize, err := u.tag.FixedSize()
+	if err != nil {
+		return 0, err
+	}
+
+	size := -1
+	for _, variant := range u.variants {
+		variantSize, err := variant.FixedSize()
+		if err != nil {
+			return 0, err
+		}
+
+		if size != -1 && size != variantSize {
+			return 0, fmt.Errorf("%w: unions with variants of different sizes are not fixed size", types.ErrInvalidType)
+		}
+		size = variantSize
+	}
+
+	return tagSize + size, nil
+}
+
+// tagSize returns the max size of the tag, for tags with a variable length, such as varints.
+func (u *union) tagSize(numItems int) (int, error) {
+	if size, err := u.tag.FixedSize(); err == nil {
+		return size, nil
+	}
+	return u.tag.Size(numItems)
+}
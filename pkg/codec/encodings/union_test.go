@@ -0,0 +1,137 @@
+package encodings_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/codec/encodings"
+	"github.com/smartcontractkit/chainlink-common/pkg/codec/encodings/binary"
+	"github.com/smartcontractkit/chainlink-common/pkg/types"
+)
+
+func TestUnion(t *testing.T) {
+	t.Parallel()
+	builder := binary.BigEndian()
+	union, err := encodings.NewUnion(builder.Uint16(), map[uint16]encodings.NamedTypeCodec{
+		7:  {Name: "Amount", Codec: builder.Uint32()},
+		1:  {Name: "Unit", Codec: encodings.Empty{}},
+		10: {Name: "Name", Codec: stringerCodec{}},
+	})
+	require.NoError(t, err)
+
+	type expected struct {
+		Unit   *struct{}
+		Amount *uint32
+		Name   fmt.Stringer
+	}
+
+	toUnion := func(value *expected) any {
+		return reflect.ValueOf(value).Convert(union.GetType()).Interface()
+	}
+
+	t.Run("GetType returns a pointer to a struct with a field per variant ordered by tag", func(t *testing.T) {
+		assert.True(t, union.GetType().ConvertibleTo(reflect.TypeOf(&expected{})))
+	})
+
+	t.Run("Variants are encoded as their tag followed by their value", func(t *testing.T) {
+		amount := uint32(5)
+		for _, test := range []struct {
+			value   *expected
+			encoded []byte
+		}{
+			{value: &expected{Unit: &struct{}{}}, encoded: []byte{0, 1}},
+			{value: &expected{Amount: &amount}, encoded: []byte{0, 7, 0, 0, 0, 5}},
+			{value: &expected{Name: name("n")}, encoded: []byte{0, 10, 1, 'n'}},
+		} {
+			encoded, err := union.Encode(toUnion(test.value), []byte{9})
+			require.NoError(t, err)
+			assert.Equal(t, append([]byte{9}, test.encoded...), encoded)
+
+			decoded, remaining, err := union.Decode(append(test.encoded, 9))
+			require.NoError(t, err)
+			assert.Equal(t, toUnion(test.value), decoded)
+			assert.Equal(t, []byte{9}, remaining)
+		}
+	})
+
+	t.Run("Unions round trip through structs", func(t *testing.T) {
+		structCodec, err := encodings.NewStructCodec([]encodings.NamedTypeCodec{{Name: "U", Codec: union}})
+		require.NoError(t, err)
+		c := encodings.CodecFromTypeCodec{"test": structCodec}
+
+		type withUnion struct {
+			U *expected
+		}
+
+		input := withUnion{U: &expected{Name: name("n")}}
+		encoded, err := c.Encode(context.Background(), &input, "test")
+		require.NoError(t, err)
+
+		output := withUnion{}
+		require.NoError(t, c.Decode(context.Background(), encoded, &output, "test"))
+		assert.Equal(t, input, output)
+	})
+
+	t.Run("Encode returns an error unless exactly one variant is set", func(t *testing.T) {
+		amount := uint32(5)
+		for _, value := range []*expected{{}, {Amount: &amount, Name: name("n")}} {
+			_, err := union.Encode(toUnion(value), nil)
+			assert.True(t, errors.Is(err, types.ErrInvalidType))
+		}
+
+		_, err := union.Encode(&expected{Unit: &struct{}{}}, nil)
+		assert.True(t, errors.Is(err, types.ErrInvalidType))
+	})
+
+	t.Run("Decode returns an error for unknown tags", func(t *testing.T) {
+		_, _, err := union.Decode([]byte{0, 2})
+		assert.True(t, errors.Is(err, types.ErrInvalidEncoding))
+
+		_, _, err = union.Decode([]byte{0})
+		assert.True(t, errors.Is(err, types.ErrInvalidEncoding))
+	})
+
+	t.Run("Size is the size of the tag and the largest variant", func(t *testing.T) {
+		size, err := union.Size(1)
+		require.NoError(t, err)
+		assert.Equal(t, 258, size)
+
+		_, err = union.FixedSize()
+		assert.True(t, errors.Is(err, types.ErrInvalidType))
+	})
+
+	t.Run("FixedSize is returned if all variants have the same size", func(t *testing.T) {
+		fixed, err := encodings.NewUnion(builder.Uint8(), map[uint8]encodings.NamedTypeCodec{
+			0: {Name: "A", Codec: builder.Uint16()},
+			1: {Name: "B", Codec: builder.Int16()},
+		})
+		require.NoError(t, err)
+
+		size, err := fixed.FixedSize()
+		require.NoError(t, err)
+		assert.Equal(t, 3, size)
+	})
+
+	t.Run("NewUnion returns an error for invalid config", func(t *testing.T) {
+		_, err := encodings.NewUnion(builder.Uint8(), map[uint8]encodings.NamedTypeCodec{})
+		assert.True(t, errors.Is(err, types.ErrInvalidConfig))
+
+		_, err = encodings.NewUnion(builder.Uint8(), map[uint16]encodings.NamedTypeCodec{0: {Name: "A", Codec: builder.Bool()}})
+		assert.True(t, errors.Is(err, types.ErrInvalidConfig))
+
+		_, err = encodings.NewUnion(builder.Uint8(), map[uint8]encodings.NamedTypeCodec{0: {Name: "A"}})
+		assert.True(t, errors.Is(err, types.ErrInvalidConfig))
+
+		_, err = encodings.NewUnion(builder.Uint8(), map[uint8]encodings.NamedTypeCodec{
+			0: {Name: "A", Codec: builder.Bool()},
+			1: {Name: "A", Codec: builder.Bool()},
+		})
+		assert.True(t, errors.Is(err, types.ErrInvalidConfig))
+	})
+}
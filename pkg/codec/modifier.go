@@ -1,6 +1,7 @@
 package codec
 
 import (
+	"fmt"
 	"reflect"
 )
 
@@ -17,3 +18,52 @@ type Modifier interface {
 	// It is used to send back the object after it has been decoded
 	TransformToOffChain(onChainValue any, itemType string) (any, error)
 }
+
+// ModifiersForItemType returns the modifiers that m applies to itemType, in order,
+// expanding a [MultiModifier] into its modifiers and a modifier by item type into the one for itemType.
+func ModifiersForItemType(m Modifier, itemType string) []Modifier {
+	switch mod := m.(type) {
+	case nil:
+		return nil
+	case MultiModifier:
+		var modifiers []Modifier
+		for _, inner := range mod {
+			modifiers = append(modifiers, ModifiersForItemType(inner, itemType)...)
+		}
+		return modifiers
+	case *byItemTypeModifier:
+		return ModifiersForItemType(mod.modByitemType[itemType], itemType)
+	default:
+		return []Modifier{m}
+	}
+}
+
+// ModifierName returns the [ModifierType] of modifiers that can be created from a [ModifierConfig],
+// and the Go type of other modifiers.
+func ModifierName(m Modifier) string {
+	switch mod := m.(type) {
+	case *renamer:
+		if mod.drops {
+			return string(ModifierDrop)
+		}
+		return string(ModifierRename)
+	case *onChainHardCoder:
+		return string(ModifierHardCode)
+	case *elementExtractor:
+		return string(ModifierExtractElement)
+	case *timeToUnixModifier:
+		return string(ModifierEpochToTime)
+	case *propertyExtractor:
+		return string(ModifierExtractProperty)
+	case *bytesToStringModifier:
+		return string(ModifierAddressToString)
+	case *wrapperModifier:
+		return string(ModifierWrapper)
+	case *preCodec:
+		return string(ModifierPreCodec)
+	case *scaleModifier:
+		return string(ModifierScale)
+	default:
+		return fmt.Sprintf("%T", m)
+	}
+}
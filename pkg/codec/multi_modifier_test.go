@@ -1,6 +1,7 @@
 package codec_test
 
 import (
+	"encoding/json"
 	"reflect"
 	"testing"
 
@@ -57,3 +58,41 @@ func TestMultiModifier(t *testing.T) {
 		assert.Equal(t, expected, actual)
 	})
 }
+
+func TestModifiersForItemType(t *testing.T) {
+	t.Parallel()
+
+	mod1 := codec.NewRenamer(map[string]string{"A": "B"})
+	mod2 := codec.NewRenamer(map[string]string{"B": "C"})
+	mod3 := codec.NewEpochToTimeModifier([]string{"C"})
+	byItemType, err := codec.NewByItemTypeModifier(map[string]codec.Modifier{
+		"item": codec.MultiModifier{mod2, codec.MultiModifier{mod3}},
+	})
+	require.NoError(t, err)
+
+	modifier := codec.MultiModifier{mod1, byItemType}
+	assert.Equal(t, []codec.Modifier{mod1, mod2, mod3}, codec.ModifiersForItemType(modifier, "item"))
+	assert.Equal(t, []codec.Modifier{mod1}, codec.ModifiersForItemType(modifier, "other"))
+	assert.Empty(t, codec.ModifiersForItemType(nil, "item"))
+}
+
+func TestModifierName(t *testing.T) {
+	t.Parallel()
+
+	var configs codec.ModifiersConfig
+	require.NoError(t, json.Unmarshal([]byte(`[
+		{"Type": "rename", "Fields": {"A": "B"}},
+		{"Type": "drop", "Fields": ["C"]},
+		{"Type": "epoch to time", "Fields": ["D"]},
+		{"Type": "wrapper", "Fields": {"E": "F"}}
+	]`), &configs))
+	modifier, err := configs.ToModifier()
+	require.NoError(t, err)
+
+	var names []string
+	for _, m := range codec.ModifiersForItemType(modifier, "item") {
+		names = append(names, codec.ModifierName(m))
+	}
+	assert.Equal(t, []string{"rename", "drop", "epoch to time", "wrapper"}, names)
+	assert.Equal(t, "codec.MultiModifier", codec.ModifierName(codec.MultiModifier{}))
+}
@@ -28,6 +28,8 @@ func NewRenamer(fields map[string]string) Modifier {
 
 type renamer struct {
 	modifierBase[string]
+	// drops is set if the renamer drops fields, by renaming them to unexported names
+	drops bool
 }
 
 func (r *renamer) TransformToOffChain(onChainValue any, _ string) (any, error) {
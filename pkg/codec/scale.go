@@ -0,0 +1,250 @@
+package codec
+
+import (
+	"fmt"
+	"math/big"
+	"reflect"
+	"strings"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/types"
+)
+
+// DecimalScale is how an integer on-chain is scaled to a decimal off-chain.
+type DecimalScale struct {
+	// Exponent is the power of ten the on-chain integer is multiplied by off-chain.
+	// For example, an answer with 8 decimals on-chain uses -8.
+	Exponent int32
+	// Rounding is how decimals that are not a whole number on-chain are rounded, defaulting to RoundingExact.
+	Rounding RoundingMode
+}
+
+// RoundingMode is how a decimal is rounded to an integer.
+type RoundingMode string
+
+const (
+	// RoundingExact doesn't round, returning an error if the decimal is not a whole number on-chain.
+	RoundingExact    RoundingMode = "exact"
+	RoundingHalfEven RoundingMode = "half even"
+	// RoundingHalfUp rounds halves away from zero.
+	RoundingHalfUp RoundingMode = "half up"
+	// RoundingDown rounds towards zero.
+	RoundingDown RoundingMode = "down"
+	// RoundingUp rounds away from zero.
+	RoundingUp      RoundingMode = "up"
+	RoundingFloor   RoundingMode = "floor"
+	RoundingCeiling RoundingMode = "ceiling"
+)
+
+// NewScaleModifier converts integer fields on-chain, including *big.Int and pointers to integers, to *decimal.Decimal
+// off-chain, scaled as configured for each field. Slices and arrays of integers are converted element by element.
+func NewScaleModifier(fields map[string]*DecimalScale) Modifier {
+	m := &scaleModifier{
+		modifierBase: modifierBase[*DecimalScale]{
+			fields:           fields,
+			onToOffChainType: map[reflect.Type]reflect.Type{},
+			offToOnChainType: map[reflect.Type]reflect.Type{},
+		},
+	}
+
+	m.modifyFieldForInput = func(_ string, field *reflect.StructField, fullPath string, scale *DecimalScale) error {
+		if scale == nil {
+			return fmt.Errorf("%w: no scale for field %s", types.ErrInvalidConfig, fullPath)
+		}
+
+		if _, err := scale.Rounding.round(decimal.Zero); err != nil {
+			return err
+		}
+
+		t, err := scaledType(field.Type)
+		if err != nil {
+			return fmt.Errorf("%w: cannot scale field %s", err, fullPath)
+		}
+		field.Type = t
+		return nil
+	}
+
+	return m
+}
+
+type scaleModifier struct {
+	modifierBase[*DecimalScale]
+}
+
+func (s *scaleModifier) TransformToOnChain(offChainValue any, _ string) (any, error) {
+	return transformWithMaps(offChainValue, s.offToOnChainType, s.fields, scaleToOnChain, BigIntHook, SliceToArrayVerifySizeHook)
+}
+
+func (s *scaleModifier) TransformToOffChain(onChainValue any, _ string) (any, error) {
+	return transformWithMaps(onChainValue, s.onToOffChainType, s.fields, scaleToOffChain)
+}
+
+func scaleToOffChain(extractMap map[string]any, key string, scale *DecimalScale) error {
+	value, ok := extractMap[key]
+	if !ok {
+		return fmt.Errorf("%w: field %s does not exist", types.ErrInvalidType, key)
+	}
+
+	scaled, err := integerToDecimal(reflect.ValueOf(value), scale.Exponent)
+	if err != nil {
+		return err
+	}
+
+	extractMap[key] = scaled.Interface()
+	return nil
+}
+
+func scaleToOnChain(extractMap map[string]any, key string, scale *DecimalScale) error {
+	value, ok := extractMap[key]
+	if !ok {
+		return fmt.Errorf("%w: field %s does not exist", types.ErrInvalidType, key)
+	}
+
+	scaled, err := decimalToInteger(reflect.ValueOf(value), scale)
+	if err != nil {
+		return err
+	}
+
+	extractMap[key] = scaled.Interface()
+	return nil
+}
+
+var decimalPtrType = reflect.TypeOf(&decimal.Decimal{})
+
+// scaledType returns the off-chain type of an integer type, or a slice, array or pointer of them.
+// Integers are scaled to *decimal.Decimal, as decimals are structs without exported fields that cannot be mapped.
+func scaledType(t reflect.Type) (reflect.Type, error) {
+	if isScalable(t) || (t.Kind() == reflect.Pointer && isScalable(t.Elem())) {
+		return decimalPtrType, nil
+	}
+
+	switch t.Kind() {
+	case reflect.Slice, reflect.Array:
+		elem, err := scaledType(t.Elem())
+		if err != nil {
+			return nil, err
+		}
+
+		if t.Kind() == reflect.Slice {
+			return reflect.SliceOf(elem), nil
+		}
+		return reflect.ArrayOf(t.Len(), elem), nil
+	default:
+		return nil, fmt.Errorf("%w: %v is not an integer", types.ErrInvalidType, t)
+	}
+}
+
+func isScalable(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return true
+	default:
+		return t == reflect.TypeOf(&big.Int{})
+	}
+}
+
+func integerToDecimal(value reflect.Value, exponent int32) (reflect.Value, error) {
+	t, err := scaledType(value.Type())
+	if err != nil {
+		return reflect.Value{}, err
+	}
+
+	if t == decimalPtrType {
+		var i *big.Int
+		switch value.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			i = big.NewInt(value.Int())
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			i = new(big.Int).SetUint64(value.Uint())
+		default:
+			if value.IsNil() {
+				return reflect.Zero(t), nil
+			}
+
+			if bi, ok := value.Interface().(*big.Int); ok {
+				i = bi
+			} else {
+				return integerToDecimal(value.Elem(), exponent)
+			}
+		}
+
+		d := decimal.NewFromBigInt(i, exponent)
+		return reflect.ValueOf(&d), nil
+	}
+
+	var scaled reflect.Value
+	if value.Kind() == reflect.Slice {
+		scaled = reflect.MakeSlice(t, value.Len(), value.Len())
+	} else {
+		scaled = reflect.New(t).Elem()
+	}
+
+	for i := 0; i < value.Len(); i++ {
+		elem, err := integerToDecimal(value.Index(i), exponent)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		scaled.Index(i).Set(elem)
+	}
+	return scaled, nil
+}
+
+// decimalToInteger converts decimals to *big.Int, and slices or arrays of decimals to slices of *big.Int,
+// which are converted to the on-chain types by BigIntHook and SliceToArrayVerifySizeHook.
+func decimalToInteger(value reflect.Value, scale *DecimalScale) (reflect.Value, error) {
+	switch value.Kind() {
+	case reflect.Pointer:
+		d, ok := value.Interface().(*decimal.Decimal)
+		if !ok {
+			return reflect.Value{}, fmt.Errorf("%w: expected *decimal.Decimal, got %v", types.ErrInvalidType, value.Type())
+		}
+
+		if d == nil {
+			return reflect.Zero(reflect.TypeOf(&big.Int{})), nil
+		}
+
+		rounded, err := scale.Rounding.round(d.Shift(-scale.Exponent))
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		return reflect.ValueOf(rounded.BigInt()), nil
+	case reflect.Slice, reflect.Array:
+		scaled := make([]*big.Int, value.Len())
+		for i := range scaled {
+			elem, err := decimalToInteger(value.Index(i), scale)
+			if err != nil {
+				return reflect.Value{}, err
+			}
+			scaled[i] = elem.Interface().(*big.Int)
+		}
+		return reflect.ValueOf(scaled), nil
+	default:
+		return reflect.Value{}, fmt.Errorf("%w: expected *decimal.Decimal, got %v", types.ErrInvalidType, value.Type())
+	}
+}
+
+func (r RoundingMode) round(d decimal.Decimal) (decimal.Decimal, error) {
+	switch RoundingMode(strings.ToLower(string(r))) {
+	case "", RoundingExact:
+		if !d.IsInteger() {
+			return decimal.Decimal{}, fmt.Errorf("%w: %s is not a whole number", types.ErrInvalidType, d)
+		}
+		return d, nil
+	case RoundingHalfEven:
+		return d.RoundBank(0), nil
+	case RoundingHalfUp:
+		return d.Round(0), nil
+	case RoundingDown:
+		return d.Truncate(0), nil
+	case RoundingUp:
+		return d.RoundUp(0), nil
+	case RoundingFloor:
+		return d.RoundFloor(0), nil
+	case RoundingCeiling:
+		return d.RoundCeil(0), nil
+	default:
+		return decimal.Decimal{}, fmt.Errorf("%w: unknown rounding mode %s", types.ErrInvalidConfig, r)
+	}
+}
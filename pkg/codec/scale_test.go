@@ -0,0 +1,176 @@
+package codec_test
+
+import (
+	"encoding/json"
+	"errors"
+	"math/big"
+	"reflect"
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/codec"
+	"github.com/smartcontractkit/chainlink-common/pkg/types"
+)
+
+func TestScaleModifier(t *testing.T) {
+	t.Parallel()
+
+	type onChain struct {
+		A       string
+		Answer  *big.Int
+		Amount  uint64
+		Pointer *int32
+		Values  []int64
+		Pair    [2]int8
+	}
+
+	type offChain struct {
+		A       string
+		Answer  *decimal.Decimal
+		Amount  *decimal.Decimal
+		Pointer *decimal.Decimal
+		Values  []*decimal.Decimal
+		Pair    [2]*decimal.Decimal
+	}
+
+	newModifier := func(rounding codec.RoundingMode) codec.Modifier {
+		return codec.NewScaleModifier(map[string]*codec.DecimalScale{
+			"Answer":  {Exponent: -8, Rounding: rounding},
+			"Amount":  {Exponent: 3, Rounding: rounding},
+			"Pointer": {Exponent: -1, Rounding: rounding},
+			"Values":  {Exponent: -2, Rounding: rounding},
+			"Pair":    {Exponent: 0, Rounding: rounding},
+		})
+	}
+
+	t.Run("RetypeToOffChain converts integers to decimals", func(t *testing.T) {
+		offChainType, err := newModifier("").RetypeToOffChain(reflect.TypeOf(&onChain{}), "")
+		require.NoError(t, err)
+		assert.True(t, offChainType.ConvertibleTo(reflect.TypeOf(&offChain{})), offChainType.String())
+	})
+
+	t.Run("RetypeToOffChain returns an error if a field is not an integer", func(t *testing.T) {
+		_, err := codec.NewScaleModifier(map[string]*codec.DecimalScale{"A": {}}).RetypeToOffChain(reflect.TypeOf(&onChain{}), "")
+		assert.True(t, errors.Is(err, types.ErrInvalidType))
+	})
+
+	t.Run("RetypeToOffChain returns an error for unknown rounding modes", func(t *testing.T) {
+		_, err := newModifier("sideways").RetypeToOffChain(reflect.TypeOf(&onChain{}), "")
+		assert.True(t, errors.Is(err, types.ErrInvalidConfig))
+	})
+
+	t.Run("Transforms scale values in both directions", func(t *testing.T) {
+		modifier := newModifier(codec.RoundingExact)
+		offChainType, err := modifier.RetypeToOffChain(reflect.TypeOf(&onChain{}), "")
+		require.NoError(t, err)
+
+		pointer := int32(-15)
+		input := &onChain{
+			A:       "a",
+			Answer:  big.NewInt(123456789),
+			Amount:  42,
+			Pointer: &pointer,
+			Values:  []int64{1, -250},
+			Pair:    [2]int8{-128, 127},
+		}
+
+		actual, err := modifier.TransformToOffChain(input, "")
+		require.NoError(t, err)
+
+		expected := reflect.ValueOf(&offChain{
+			A:       "a",
+			Answer:  decimalPtr("1.23456789"),
+			Amount:  decimalPtr("42000"),
+			Pointer: decimalPtr("-1.5"),
+			Values:  []*decimal.Decimal{decimalPtr("0.01"), decimalPtr("-2.5")},
+			Pair:    [2]*decimal.Decimal{decimalPtr("-128"), decimalPtr("127")},
+		}).Convert(offChainType).Interface()
+		assertDecimalsEqual(t, expected, actual)
+
+		onChainValue, err := modifier.TransformToOnChain(actual, "")
+		require.NoError(t, err)
+		assert.Equal(t, input, onChainValue)
+	})
+
+	t.Run("TransformToOnChain rounds using the rounding mode", func(t *testing.T) {
+		for _, test := range []struct {
+			rounding codec.RoundingMode
+			expected uint64
+		}{
+			{codec.RoundingHalfEven, 2},
+			{codec.RoundingHalfUp, 3},
+			{codec.RoundingDown, 2},
+			{codec.RoundingUp, 3},
+			{codec.RoundingFloor, 2},
+			{codec.RoundingCeiling, 3},
+		} {
+			t.Run(string(test.rounding), func(t *testing.T) {
+				modifier := newModifier(test.rounding)
+				offChainType, err := modifier.RetypeToOffChain(reflect.TypeOf(&onChain{}), "")
+				require.NoError(t, err)
+
+				item := reflect.New(offChainType.Elem())
+				item.Elem().FieldByName("Amount").Set(reflect.ValueOf(decimalPtr("2500")))
+
+				onChainValue, err := modifier.TransformToOnChain(item.Interface(), "")
+				require.NoError(t, err)
+				assert.Equal(t, test.expected, onChainValue.(*onChain).Amount)
+			})
+		}
+	})
+
+	t.Run("TransformToOnChain returns an error if an exact value isn't whole", func(t *testing.T) {
+		modifier := newModifier(codec.RoundingExact)
+		offChainType, err := modifier.RetypeToOffChain(reflect.TypeOf(&onChain{}), "")
+		require.NoError(t, err)
+
+		item := reflect.New(offChainType.Elem())
+		item.Elem().FieldByName("Answer").Set(reflect.ValueOf(decimalPtr("0.000000001")))
+
+		_, err = modifier.TransformToOnChain(item.Interface(), "")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "not a whole number")
+	})
+
+	t.Run("TransformToOnChain returns an error if the value doesn't fit on-chain", func(t *testing.T) {
+		modifier := newModifier(codec.RoundingExact)
+		offChainType, err := modifier.RetypeToOffChain(reflect.TypeOf(&onChain{}), "")
+		require.NoError(t, err)
+
+		item := reflect.New(offChainType.Elem())
+		item.Elem().FieldByName("Pair").Index(0).Set(reflect.ValueOf(decimalPtr("128")))
+
+		_, err = modifier.TransformToOnChain(item.Interface(), "")
+		assert.True(t, errors.Is(err, types.ErrInvalidType))
+	})
+
+	t.Run("ScaleModifierConfig is unmarshalled with ModifiersConfig", func(t *testing.T) {
+		conf := codec.ModifiersConfig{}
+		require.NoError(t, json.Unmarshal([]byte(`[{"Type": "scale", "Fields": {"answer": {"Exponent": -8, "Rounding": "Half Even"}}}]`), &conf))
+
+		modifier, err := conf.ToModifier()
+		require.NoError(t, err)
+
+		type answer struct{ Answer *big.Int }
+		offChainType, err := modifier.RetypeToOffChain(reflect.TypeOf(&answer{}), "")
+		require.NoError(t, err)
+		assert.Equal(t, reflect.TypeOf(&decimal.Decimal{}), offChainType.Elem().Field(0).Type)
+	})
+}
+
+// assertDecimalsEqual compares decimals by value, as equal decimals can have different exponents.
+func assertDecimalsEqual(t *testing.T, expected, actual any) {
+	expectedJSON, err := json.Marshal(expected)
+	require.NoError(t, err)
+	actualJSON, err := json.Marshal(actual)
+	require.NoError(t, err)
+	assert.JSONEq(t, string(expectedJSON), string(actualJSON))
+}
+
+func decimalPtr(value string) *decimal.Decimal {
+	d := decimal.RequireFromString(value)
+	return &d
+}
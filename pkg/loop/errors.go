@@ -4,4 +4,8 @@ import (
 	"github.com/smartcontractkit/chainlink-common/pkg/loop/internal/goplugin"
 )
 
-var ErrPluginUnavailable = goplugin.ErrPluginUnavailable
+var (
+	ErrPluginUnavailable   = goplugin.ErrPluginUnavailable
+	ErrPluginCrashLoop     = goplugin.ErrPluginCrashLoop
+	ErrPluginResourceLimit = goplugin.ErrPluginResourceLimit
+)
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
 	"os/exec"
 	"sync"
 	"time"
@@ -45,6 +46,14 @@ type PluginService[P grpcPlugin, S services.Service] struct {
 
 	newService func(context.Context, any) (S, error)
 
+	restarts restarts
+	stderr   stderrTail
+	limits   ResourceLimits
+
+	healthMu    sync.RWMutex
+	launchErr   error // from the last launch, or the circuit breaker
+	resourceErr error // from the last resource usage sample
+
 	serviceCh chan struct{} // closed when service is available
 	Service   S
 
@@ -60,6 +69,18 @@ func (s *PluginService[P, S]) Init(pluginName string, p P, newService func(conte
 	s.grpcPlug = p
 	s.newService = newService
 	s.serviceCh = make(chan struct{})
+	s.SetRestartPolicy(DefaultRestartPolicy)
+}
+
+// SetRestartPolicy overrides the DefaultRestartPolicy. It must be called before Start.
+func (s *PluginService[P, S]) SetRestartPolicy(policy RestartPolicy) {
+	s.restarts.policy = policy
+	s.stderr.max = policy.StderrLines
+}
+
+// SetResourceLimits sets the ResourceLimits applied to each launch of the plugin. It must be called before Start.
+func (s *PluginService[P, S]) SetResourceLimits(limits ResourceLimits) {
+	s.limits = limits
 }
 
 func (s *PluginService[P, S]) keepAlive() {
@@ -67,19 +88,47 @@ func (s *PluginService[P, S]) keepAlive() {
 
 	s.lggr.Debugw("Starting keepAlive", "tick", KeepAliveTickDuration)
 
+	var retry <-chan time.Time // set while a relaunch is delayed
 	check := func() {
+		retry = nil
 		c := s.client
 		cp := s.clientProtocol
 		if c != nil && !c.Exited() && cp != nil {
 			// launched
 			err := cp.Ping()
 			if err == nil {
-				return // healthy
+				// healthy
+				s.restarts.healthy()
+				s.setLaunchErr(nil)
+				if rc := c.ReattachConfig(); rc != nil {
+					s.sampleResources(rc.Pid)
+				}
+				return
 			}
 			s.lggr.Errorw("Relaunching unhealthy plugin", "err", err)
 		}
+		deleteResources(s.Name())
+		now := time.Now()
+		delay, err := s.restarts.delay(now)
+		if err != nil {
+			err = s.stderr.wrap(err)
+			s.lggr.Errorw("Not relaunching plugin", "err", err, "delay", delay)
+			s.setLaunchErr(err)
+			pluginCrashLoop.WithLabelValues(s.Name()).Set(1)
+		}
+		if delay > 0 {
+			s.lggr.Debugw("Delaying plugin relaunch", "delay", delay)
+			retry = time.After(delay)
+			return
+		}
+		pluginCrashLoop.WithLabelValues(s.Name()).Set(0)
+		if s.restarts.launched(now) {
+			pluginRestarts.WithLabelValues(s.Name()).Inc()
+		}
 		if err := s.tryLaunch(cp); err != nil {
+			err = s.stderr.wrap(err)
 			s.lggr.Errorw("Failed to launch plugin", "err", err)
+			s.setLaunchErr(err)
 		}
 	}
 
@@ -92,6 +141,10 @@ func (s *PluginService[P, S]) keepAlive() {
 		case <-s.stopCh:
 			return
 		case <-t.C:
+			if retry == nil {
+				check()
+			}
+		case <-retry:
 			check()
 		case fn := <-s.testInterrupt:
 			fn(s)
@@ -120,6 +173,11 @@ func (s *PluginService[P, S]) launch() (*plugin.Client, plugin.ClientProtocol, e
 	cc := s.grpcPlug.ClientConfig()
 	cc.SkipHostEnv = true
 	cc.Cmd = s.cmd()
+	if cc.Stderr != nil {
+		cc.Stderr = io.MultiWriter(cc.Stderr, &s.stderr)
+	} else {
+		cc.Stderr = &s.stderr
+	}
 	client := plugin.NewClient(cc)
 	cp, err := client.Client()
 	if err != nil {
@@ -132,6 +190,15 @@ func (s *PluginService[P, S]) launch() (*plugin.Client, plugin.ClientProtocol, e
 		}
 		client.Kill()
 	}
+	if s.limits != (ResourceLimits{}) {
+		err = setResourceLimits(cc.Cmd.Process.Pid, s.limits)
+		if errors.Is(err, errors.ErrUnsupported) {
+			s.lggr.Warnw("Ignoring plugin resource limits", "err", err)
+		} else if err != nil {
+			abort()
+			return nil, nil, fmt.Errorf("failed to set resource limits: %w", err)
+		}
+	}
 	i, err := cp.Dispense(s.pluginName)
 	if err != nil {
 		abort()
@@ -174,18 +241,51 @@ func (s *PluginService[P, S]) Name() string { return s.lggr.Name() }
 func (s *PluginService[P, S]) HealthReport() map[string]error {
 	select {
 	case <-s.serviceCh:
-		hr := map[string]error{s.Name(): s.Healthy()}
+		hr := map[string]error{s.Name(): errors.Join(s.Healthy(), s.healthErr())}
 		services.CopyHealth(hr, s.Service.HealthReport())
 		return hr
 	default:
-		return map[string]error{s.Name(): ErrPluginUnavailable}
+		return map[string]error{s.Name(): errors.Join(ErrPluginUnavailable, s.healthErr())}
+	}
+}
+
+func (s *PluginService[P, S]) setLaunchErr(err error) {
+	s.healthMu.Lock()
+	defer s.healthMu.Unlock()
+	s.launchErr = err
+}
+
+func (s *PluginService[P, S]) healthErr() error {
+	s.healthMu.RLock()
+	defer s.healthMu.RUnlock()
+	return errors.Join(s.launchErr, s.resourceErr)
+}
+
+// sampleResources records the resource usage of the plugin process pid, and whether it nears its limits.
+func (s *PluginService[P, S]) sampleResources(pid int) {
+	usage, err := readResourceUsage(pid)
+	if err != nil {
+		if !errors.Is(err, errors.ErrUnsupported) {
+			s.lggr.Warnw("Failed to read plugin resource usage", "pid", pid, "err", err)
+		}
+		return
+	}
+	setResources(s.Name(), PluginResources{Usage: usage, Limits: s.limits})
+
+	err = s.limits.check(usage)
+	if err != nil {
+		s.lggr.Warnw("Plugin near resource limit", "err", err)
 	}
+	s.healthMu.Lock()
+	defer s.healthMu.Unlock()
+	s.resourceErr = err
 }
 
 func (s *PluginService[P, S]) Close() error {
 	return s.StopOnce("PluginService", func() (err error) {
 		close(s.stopCh)
 		s.wg.Wait()
+		deleteResources(s.Name())
 
 		select {
 		case <-s.serviceCh:
@@ -201,7 +301,7 @@ func (s *PluginService[P, S]) Close() error {
 
 func (s *PluginService[P, S]) closeClient() (err error) {
 	if s.clientProtocol != nil {
-		if cerr := s.clientProtocol.Close(); !errors.Is(cerr, context.Canceled) {
+		if cerr := s.clientProtocol.Close(); !errors.Is(cerr, context.Canceled) && status.Code(cerr) != codes.Canceled {
 			err = cerr
 		}
 	}
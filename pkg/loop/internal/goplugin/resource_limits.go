@@ -0,0 +1,94 @@
+package goplugin
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// ErrPluginResourceLimit is reported by HealthReport while a plugin nears one of its ResourceLimits.
+var ErrPluginResourceLimit = errors.New("plugin near resource limit")
+
+// DefaultResourceWarnPct is the default ResourceLimits.WarnPct.
+const DefaultResourceWarnPct = 0.9
+
+// ResourceLimits bounds the resources of a plugin process. Zero values are unlimited.
+// They are applied on Linux only, once the plugin is launched, and do not apply to processes it already started.
+type ResourceLimits struct {
+	// Memory limits the virtual memory of the plugin in bytes (RLIMIT_AS).
+	Memory uint64
+	// CPUTime limits the CPU time of the plugin, rounded up to seconds (RLIMIT_CPU).
+	// The plugin is killed when it exceeds it, and then relaunched.
+	CPUTime time.Duration
+	// OpenFiles limits the number of file descriptors of the plugin (RLIMIT_NOFILE).
+	OpenFiles uint64
+	// WarnPct is the fraction of a limit above which the plugin is reported unhealthy.
+	// Defaults to DefaultResourceWarnPct.
+	WarnPct float64
+}
+
+// ResourceUsage is the resource usage of a plugin process.
+type ResourceUsage struct {
+	VirtualMemory  uint64
+	ResidentMemory uint64
+	CPUTime        time.Duration
+	OpenFiles      uint64
+}
+
+// PluginResources is the last sampled ResourceUsage of a plugin, along with its ResourceLimits.
+type PluginResources struct {
+	Usage  ResourceUsage
+	Limits ResourceLimits
+}
+
+// check returns an ErrPluginResourceLimit for each limit the usage nears.
+func (l ResourceLimits) check(u ResourceUsage) error {
+	warnPct := l.WarnPct
+	if warnPct == 0 {
+		warnPct = DefaultResourceWarnPct
+	}
+	near := func(used, limit float64) bool {
+		return limit > 0 && used >= warnPct*limit
+	}
+
+	var errs []error
+	if near(float64(u.VirtualMemory), float64(l.Memory)) {
+		errs = append(errs, fmt.Errorf("%w: virtual memory %d of %d bytes", ErrPluginResourceLimit, u.VirtualMemory, l.Memory))
+	}
+	if near(u.CPUTime.Seconds(), l.CPUTime.Seconds()) {
+		errs = append(errs, fmt.Errorf("%w: CPU time %s of %s", ErrPluginResourceLimit, u.CPUTime, l.CPUTime))
+	}
+	if near(float64(u.OpenFiles), float64(l.OpenFiles)) {
+		errs = append(errs, fmt.Errorf("%w: %d of %d open files", ErrPluginResourceLimit, u.OpenFiles, l.OpenFiles))
+	}
+	return errors.Join(errs...)
+}
+
+var (
+	resourcesMu sync.RWMutex
+	resources   = map[string]PluginResources{}
+)
+
+// Resources returns the PluginResources of the running plugins, by name.
+func Resources() map[string]PluginResources {
+	resourcesMu.RLock()
+	defer resourcesMu.RUnlock()
+	m := make(map[string]PluginResources, len(resources))
+	for name, r := range resources {
+		m[name] = r
+	}
+	return m
+}
+
+func setResources(name string, r PluginResources) {
+	resourcesMu.Lock()
+	defer resourcesMu.Unlock()
+	resources[name] = r
+}
+
+func deleteResources(name string) {
+	resourcesMu.Lock()
+	defer resourcesMu.Unlock()
+	delete(resources, name)
+}
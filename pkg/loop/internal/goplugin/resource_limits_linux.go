@@ -0,0 +1,53 @@
+//go:build linux
+
+package goplugin
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/prometheus/procfs"
+	"golang.org/x/sys/unix"
+)
+
+// setResourceLimits sets the limits of the process pid.
+func setResourceLimits(pid int, limits ResourceLimits) error {
+	set := func(name string, resource int, value uint64) error {
+		if value == 0 {
+			return nil
+		}
+		if err := unix.Prlimit(pid, resource, &unix.Rlimit{Cur: value, Max: value}, nil); err != nil {
+			return fmt.Errorf("failed to set %s to %d: %w", name, value, err)
+		}
+		return nil
+	}
+	return errors.Join(
+		set("RLIMIT_AS", unix.RLIMIT_AS, limits.Memory),
+		set("RLIMIT_CPU", unix.RLIMIT_CPU, uint64(math.Ceil(limits.CPUTime.Seconds()))),
+		set("RLIMIT_NOFILE", unix.RLIMIT_NOFILE, limits.OpenFiles),
+	)
+}
+
+// readResourceUsage reads the resource usage of the process pid from /proc.
+func readResourceUsage(pid int) (ResourceUsage, error) {
+	p, err := procfs.NewProc(pid)
+	if err != nil {
+		return ResourceUsage{}, err
+	}
+	stat, err := p.Stat()
+	if err != nil {
+		return ResourceUsage{}, err
+	}
+	fds, err := p.FileDescriptorsLen()
+	if err != nil {
+		return ResourceUsage{}, err
+	}
+	return ResourceUsage{
+		VirtualMemory:  uint64(stat.VirtualMemory()),
+		ResidentMemory: uint64(stat.ResidentMemory()),
+		CPUTime:        time.Duration(stat.CPUTime() * float64(time.Second)),
+		OpenFiles:      uint64(fds),
+	}, nil
+}
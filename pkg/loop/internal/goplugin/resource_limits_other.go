@@ -0,0 +1,16 @@
+//go:build !linux
+
+package goplugin
+
+import (
+	"errors"
+	"fmt"
+)
+
+func setResourceLimits(int, ResourceLimits) error {
+	return fmt.Errorf("resource limits: %w", errors.ErrUnsupported)
+}
+
+func readResourceUsage(int) (ResourceUsage, error) {
+	return ResourceUsage{}, fmt.Errorf("resource usage: %w", errors.ErrUnsupported)
+}
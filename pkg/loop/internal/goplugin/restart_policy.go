@@ -0,0 +1,174 @@
+package goplugin
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/services"
+	"github.com/smartcontractkit/chainlink-common/pkg/timeutil"
+)
+
+// ErrPluginCrashLoop is reported by HealthReport while a plugin restarting too often is not relaunched.
+var ErrPluginCrashLoop = errors.New("plugin crash loop")
+
+var (
+	pluginRestarts = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "loop_plugin_restarts_total",
+			Help: "Number of times a LOOP plugin was relaunched after exiting or becoming unhealthy",
+		},
+		[]string{"plugin"},
+	)
+	pluginCrashLoop = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "loop_plugin_crash_loop",
+			Help: "1 while a LOOP plugin is not relaunched because it restarted too often, 0 otherwise",
+		},
+		[]string{"plugin"},
+	)
+)
+
+// RestartPolicy configures how a PluginService relaunches a plugin which exited or became unhealthy.
+type RestartPolicy struct {
+	// MinBackoff is the delay between the launch of a plugin and its relaunch. It doubles for each consecutive
+	// relaunch, up to MaxBackoff, and is reset once the plugin passes a health check.
+	MinBackoff time.Duration
+	MaxBackoff time.Duration
+	// Jitter to apply to each backoff.
+	Jitter timeutil.JitterPct
+	// MaxRestarts within Window trip the circuit breaker: the plugin is reported unhealthy with ErrPluginCrashLoop
+	// and is not relaunched until the oldest restart is out of the Window. Zero disables the circuit breaker, so the
+	// plugin keeps being relaunched at most every MaxBackoff.
+	MaxRestarts int
+	Window      time.Duration
+	// StderrLines is the number of the last lines of plugin stderr included in health errors.
+	StderrLines int
+}
+
+// DefaultRestartPolicy is the RestartPolicy of a PluginService, unless set with SetRestartPolicy.
+// The circuit breaker is disabled by default.
+var DefaultRestartPolicy = RestartPolicy{
+	MinBackoff:  time.Second,
+	MaxBackoff:  time.Minute,
+	Jitter:      services.DefaultJitter,
+	StderrLines: 20,
+}
+
+// maxStderrLineBytes bounds the lines kept by stderrTail, longer lines are split.
+const maxStderrLineBytes = 4 * 1024
+
+// backoff returns the delay before the n-th consecutive relaunch.
+func (p RestartPolicy) backoff(n int) time.Duration {
+	d := p.MinBackoff
+	for i := 1; i < n && d < p.MaxBackoff; i++ {
+		d *= 2
+	}
+	if d > p.MaxBackoff {
+		d = p.MaxBackoff
+	}
+	return p.Jitter.Apply(d)
+}
+
+// restarts tracks the launches of a plugin to apply a RestartPolicy.
+type restarts struct {
+	policy RestartPolicy
+
+	lastLaunch time.Time
+	failures   int         // consecutive relaunches since the last successful health check
+	times      []time.Time // of the relaunches within the policy window
+}
+
+// delay returns how long to wait before relaunching the plugin, along with an ErrPluginCrashLoop when the circuit
+// breaker is open.
+func (r *restarts) delay(now time.Time) (time.Duration, error) {
+	if r.lastLaunch.IsZero() {
+		return 0, nil // initial launch
+	}
+	if r.policy.MaxRestarts > 0 {
+		i := 0
+		for i < len(r.times) && now.Sub(r.times[i]) >= r.policy.Window {
+			i++
+		}
+		r.times = r.times[i:]
+		if len(r.times) >= r.policy.MaxRestarts {
+			return r.times[0].Add(r.policy.Window).Sub(now),
+				fmt.Errorf("%w: restarted %d times within %s", ErrPluginCrashLoop, len(r.times), r.policy.Window)
+		}
+	}
+	return r.lastLaunch.Add(r.policy.backoff(r.failures + 1)).Sub(now), nil
+}
+
+// launched records a launch, and returns true if it was a relaunch.
+func (r *restarts) launched(now time.Time) bool {
+	initial := r.lastLaunch.IsZero()
+	r.lastLaunch = now
+	if initial {
+		return false
+	}
+	r.failures++
+	if r.policy.MaxRestarts > 0 {
+		r.times = append(r.times, now)
+	}
+	return true
+}
+
+// healthy resets the backoff.
+func (r *restarts) healthy() { r.failures = 0 }
+
+// stderrTail is an io.Writer keeping the last lines written to the stderr of a plugin.
+type stderrTail struct {
+	mu      sync.Mutex
+	max     int
+	lines   []string
+	partial []byte
+}
+
+func (t *stderrTail) Write(p []byte) (int, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	written := len(p)
+	for len(p) > 0 {
+		i := bytes.IndexByte(p, '\n')
+		line := p
+		if i >= 0 {
+			line = p[:i]
+		}
+		n := min(len(line), maxStderrLineBytes-len(t.partial))
+		t.partial = append(t.partial, line[:n]...)
+		p = p[n:]
+		switch {
+		case i >= 0 && n == len(line):
+			p = p[1:]
+			t.endLine()
+		case len(t.partial) == maxStderrLineBytes:
+			t.endLine()
+		}
+	}
+	return written, nil
+}
+
+// endLine keeps the partial line, and drops the oldest line beyond max.
+func (t *stderrTail) endLine() {
+	t.lines = append(t.lines, string(t.partial))
+	t.partial = t.partial[:0]
+	if over := len(t.lines) - t.max; over > 0 {
+		t.lines = append(t.lines[:0], t.lines[over:]...)
+	}
+}
+
+// wrap returns err with the last lines of stderr appended, if any.
+func (t *stderrTail) wrap(err error) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if len(t.lines) == 0 {
+		return err
+	}
+	return fmt.Errorf("%w\nlast lines of plugin stderr:\n%s", err, strings.Join(t.lines, "\n"))
+}
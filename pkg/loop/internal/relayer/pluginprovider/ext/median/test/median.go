@@ -140,7 +140,9 @@ func (s staticReportingPluginFactory) Close() error { return nil }
 
 func (s staticReportingPluginFactory) Ready() error { panic("implement me") }
 
-func (s staticReportingPluginFactory) HealthReport() map[string]error { panic("implement me") }
+func (s staticReportingPluginFactory) HealthReport() map[string]error {
+	return map[string]error{s.Name(): nil}
+}
 
 func (s staticReportingPluginFactory) NewReportingPlugin(ctx context.Context, config libocr.ReportingPluginConfig) (libocr.ReportingPlugin, libocr.ReportingPluginInfo, error) {
 	if config.ConfigDigest != s.ConfigDigest {
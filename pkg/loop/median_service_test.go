@@ -1,11 +1,16 @@
 package loop_test
 
 import (
+	"errors"
 	"os/exec"
+	"runtime"
 	"sync/atomic"
 	"testing"
 	"time"
 
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/require"
+
 	"github.com/smartcontractkit/chainlink-common/pkg/logger"
 	"github.com/smartcontractkit/chainlink-common/pkg/loop"
 	errorlogtest "github.com/smartcontractkit/chainlink-common/pkg/loop/internal/core/services/errorlog/test"
@@ -61,3 +66,94 @@ func TestMedianService_recovery(t *testing.T) {
 
 	reportingplugintest.RunFactory(t, median)
 }
+
+func TestMedianService_crashLoop(t *testing.T) {
+	t.Parallel()
+	median := loop.NewMedianService(logger.Named(logger.Test(t), "CrashLoop"), loop.GRPCOpts{}, func() *exec.Cmd {
+		return NewHelperProcessCommand(loop.PluginMedianName, false, 0)
+	}, mediantest.MedianProvider, mediantest.MedianContractID, mediantest.DataSource, mediantest.JuelsPerFeeCoinDataSource, mediantest.GasPriceSubunitsDataSource, errorlogtest.ErrorLog)
+	median.SetRestartPolicy(loop.RestartPolicy{
+		MinBackoff:  time.Millisecond,
+		MaxBackoff:  time.Millisecond,
+		MaxRestarts: 2,
+		Window:      time.Hour,
+		StderrLines: 5,
+	})
+	hook := median.PluginService.XXXTestHook()
+	servicetest.Run(t, median)
+
+	reportingplugintest.RunFactory(t, median)
+
+	for i := 0; i < 2; i++ {
+		hook.Kill()
+
+		// wait for relaunch
+		time.Sleep(2 * goplugin.KeepAliveTickDuration)
+
+		reportingplugintest.RunFactory(t, median)
+	}
+	require.NoError(t, median.HealthReport()[median.Name()])
+	require.Equal(t, 2.0, pluginMetric(t, "loop_plugin_restarts_total", median.Name()))
+
+	hook.Kill()
+
+	require.Eventually(t, func() bool {
+		return errors.Is(median.HealthReport()[median.Name()], loop.ErrPluginCrashLoop)
+	}, 2*goplugin.KeepAliveTickDuration, 100*time.Millisecond)
+	require.ErrorContains(t, median.HealthReport()[median.Name()], "last lines of plugin stderr")
+	require.Equal(t, 2.0, pluginMetric(t, "loop_plugin_restarts_total", median.Name()))
+}
+
+func TestMedianService_resourceLimits(t *testing.T) {
+	if runtime.GOOS != "linux" {
+		t.Skip("resource limits are only supported on Linux")
+	}
+	t.Parallel()
+	median := loop.NewMedianService(logger.Named(logger.Test(t), "ResourceLimits"), loop.GRPCOpts{}, func() *exec.Cmd {
+		return NewHelperProcessCommand(loop.PluginMedianName, false, 0)
+	}, mediantest.MedianProvider, mediantest.MedianContractID, mediantest.DataSource, mediantest.JuelsPerFeeCoinDataSource, mediantest.GasPriceSubunitsDataSource, errorlogtest.ErrorLog)
+	median.SetResourceLimits(loop.ResourceLimits{
+		Memory:    1 << 40,
+		CPUTime:   time.Hour,
+		OpenFiles: 64,
+		WarnPct:   0.01, // a single open file nears the limit
+	})
+	servicetest.Run(t, median)
+
+	reportingplugintest.RunFactory(t, median)
+
+	require.Eventually(t, func() bool {
+		return errors.Is(median.HealthReport()[median.Name()], loop.ErrPluginResourceLimit)
+	}, 2*goplugin.KeepAliveTickDuration, 100*time.Millisecond)
+	err := median.HealthReport()[median.Name()]
+	require.ErrorContains(t, err, "of 64 open files")
+	require.NotContains(t, err.Error(), "virtual memory")
+	require.NotContains(t, err.Error(), "CPU time")
+
+	require.Equal(t, 64.0, pluginMetric(t, "loop_plugin_max_fds", median.Name()))
+	require.Equal(t, float64(1<<40), pluginMetric(t, "loop_plugin_virtual_memory_max_bytes", median.Name()))
+	require.Equal(t, time.Hour.Seconds(), pluginMetric(t, "loop_plugin_cpu_max_seconds", median.Name()))
+	require.NotZero(t, pluginMetric(t, "loop_plugin_open_fds", median.Name()))
+	require.NotZero(t, pluginMetric(t, "loop_plugin_resident_memory_bytes", median.Name()))
+}
+
+func pluginMetric(t *testing.T, metric, name string) float64 {
+	families, err := prometheus.DefaultGatherer.Gather()
+	require.NoError(t, err)
+	for _, family := range families {
+		if family.GetName() != metric {
+			continue
+		}
+		for _, m := range family.GetMetric() {
+			for _, l := range m.GetLabel() {
+				if l.GetName() == "plugin" && l.GetValue() == name {
+					if m.Counter != nil {
+						return m.GetCounter().GetValue()
+					}
+					return m.GetGauge().GetValue()
+				}
+			}
+		}
+	}
+	return 0
+}
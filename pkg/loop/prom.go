@@ -12,8 +12,62 @@ import (
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 
 	"github.com/smartcontractkit/chainlink-common/pkg/logger"
+	"github.com/smartcontractkit/chainlink-common/pkg/loop/internal/goplugin"
 )
 
+func init() {
+	prometheus.MustRegister(pluginResourceCollector{})
+}
+
+var (
+	pluginVirtualMemory = prometheus.NewDesc("loop_plugin_virtual_memory_bytes",
+		"Virtual memory size of LOOP plugin processes in bytes", []string{"plugin"}, nil)
+	pluginVirtualMemoryMax = prometheus.NewDesc("loop_plugin_virtual_memory_max_bytes",
+		"Limit of the virtual memory size of LOOP plugin processes in bytes", []string{"plugin"}, nil)
+	pluginResidentMemory = prometheus.NewDesc("loop_plugin_resident_memory_bytes",
+		"Resident memory size of LOOP plugin processes in bytes", []string{"plugin"}, nil)
+	pluginCPUSeconds = prometheus.NewDesc("loop_plugin_cpu_seconds_total",
+		"CPU time of LOOP plugin processes in seconds, since their last launch", []string{"plugin"}, nil)
+	pluginCPUSecondsMax = prometheus.NewDesc("loop_plugin_cpu_max_seconds",
+		"Limit of the CPU time of LOOP plugin processes in seconds", []string{"plugin"}, nil)
+	pluginOpenFDs = prometheus.NewDesc("loop_plugin_open_fds",
+		"Number of open file descriptors of LOOP plugin processes", []string{"plugin"}, nil)
+	pluginMaxFDs = prometheus.NewDesc("loop_plugin_max_fds",
+		"Limit of the number of open file descriptors of LOOP plugin processes", []string{"plugin"}, nil)
+)
+
+// pluginResourceCollector collects the resource usage and limits of the running LOOP plugins, as sampled by their
+// plugin services.
+type pluginResourceCollector struct{}
+
+func (pluginResourceCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- pluginVirtualMemory
+	ch <- pluginVirtualMemoryMax
+	ch <- pluginResidentMemory
+	ch <- pluginCPUSeconds
+	ch <- pluginCPUSecondsMax
+	ch <- pluginOpenFDs
+	ch <- pluginMaxFDs
+}
+
+func (pluginResourceCollector) Collect(ch chan<- prometheus.Metric) {
+	for name, r := range goplugin.Resources() {
+		ch <- prometheus.MustNewConstMetric(pluginVirtualMemory, prometheus.GaugeValue, float64(r.Usage.VirtualMemory), name)
+		ch <- prometheus.MustNewConstMetric(pluginResidentMemory, prometheus.GaugeValue, float64(r.Usage.ResidentMemory), name)
+		ch <- prometheus.MustNewConstMetric(pluginCPUSeconds, prometheus.CounterValue, r.Usage.CPUTime.Seconds(), name)
+		ch <- prometheus.MustNewConstMetric(pluginOpenFDs, prometheus.GaugeValue, float64(r.Usage.OpenFiles), name)
+		if r.Limits.Memory > 0 {
+			ch <- prometheus.MustNewConstMetric(pluginVirtualMemoryMax, prometheus.GaugeValue, float64(r.Limits.Memory), name)
+		}
+		if r.Limits.CPUTime > 0 {
+			ch <- prometheus.MustNewConstMetric(pluginCPUSecondsMax, prometheus.GaugeValue, r.Limits.CPUTime.Seconds(), name)
+		}
+		if r.Limits.OpenFiles > 0 {
+			ch <- prometheus.MustNewConstMetric(pluginMaxFDs, prometheus.GaugeValue, float64(r.Limits.OpenFiles), name)
+		}
+	}
+}
+
 type PromServer struct {
 	port        int
 	srvrDone    chan struct{} // closed when the http server is done
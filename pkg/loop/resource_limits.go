@@ -0,0 +1,9 @@
+package loop
+
+import (
+	"github.com/smartcontractkit/chainlink-common/pkg/loop/internal/goplugin"
+)
+
+// ResourceLimits bounds the memory, CPU time and open files of a plugin process on Linux.
+// It is set with SetResourceLimits before starting the service.
+type ResourceLimits = goplugin.ResourceLimits
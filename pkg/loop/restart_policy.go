@@ -0,0 +1,11 @@
+package loop
+
+import (
+	"github.com/smartcontractkit/chainlink-common/pkg/loop/internal/goplugin"
+)
+
+// RestartPolicy configures how plugin services relaunch plugins which exited or became unhealthy.
+// It is set with SetRestartPolicy before starting the service.
+type RestartPolicy = goplugin.RestartPolicy
+
+var DefaultRestartPolicy = goplugin.DefaultRestartPolicy
@@ -0,0 +1,373 @@
+package monitoring
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/smartcontractkit/libocr/offchainreporting2plus/types"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/beholder"
+)
+
+const (
+	beholderDomain                   = "monitoring"
+	beholderTransmissionEntity       = "Transmission"
+	beholderTransmissionSchema       = "/monitoring/transmission/versions/1"
+	beholderTxResultsEntity          = "TxResults"
+	beholderTxResultsSchema          = "/monitoring/tx-results/versions/1"
+	beholderMetricHeadTrackerHead    = "head_tracker_current_head"
+	beholderMetricFeedMetadata       = "feed_contract_metadata"
+	beholderMetricLinkBalance        = "feed_contract_link_balance"
+	beholderMetricLinkAvailable      = "link_available_for_payments"
+	beholderMetricTxSucceeded        = "feed_contract_transactions_succeeded"
+	beholderMetricTxFailed           = "feed_contract_transactions_failed"
+	beholderMetricNodeMetadata       = "node_metadata"
+	beholderMetricAnswers            = "offchain_aggregator_answers"
+	beholderMetricAnswersTotal       = "offchain_aggregator_answers_total"
+	beholderMetricLatestTimestamp    = "offchain_aggregator_answers_latest_timestamp"
+	beholderMetricJuelsPerFeeCoin    = "offchain_aggregator_juels_per_fee_coin"
+	beholderMetricSubmissionReceived = "offchain_aggregator_submission_received_values"
+	beholderMetricJuelsReceived      = "offchain_aggregator_juels_per_fee_coin_received_values"
+	beholderMetricAnswerStalled      = "offchain_aggregator_answer_stalled"
+	beholderMetricRoundID            = "offchain_aggregator_round_id"
+)
+
+// beholderFeedGauges are reported with the labels of the feed.
+var beholderFeedGauges = []string{
+	beholderMetricLinkBalance,
+	beholderMetricLinkAvailable,
+	beholderMetricTxSucceeded,
+	beholderMetricTxFailed,
+	beholderMetricAnswers,
+	beholderMetricLatestTimestamp,
+	beholderMetricJuelsPerFeeCoin,
+	beholderMetricAnswerStalled,
+	beholderMetricRoundID,
+}
+
+// beholderSenderGauges are reported with the labels of the feed and the sender of the transmission.
+var beholderSenderGauges = []string{
+	beholderMetricSubmissionReceived,
+	beholderMetricJuelsReceived,
+}
+
+// NewBeholderExporterFactory produces exporters which emit source outputs as beholder messages
+// and report them as OTel metrics with the same names and labels as the prometheus exporter.
+// Metrics are observed from the latest values of each exporter, so a feed's series stop being reported once its
+// exporter is cleaned up.
+func NewBeholderExporterFactory(
+	log Logger,
+	client *beholder.Client,
+) (ExporterFactory, error) {
+	f := &beholderExporterFactory{
+		log:       log,
+		emitter:   client.Emitter,
+		gauges:    map[string]metric.Float64ObservableGauge{},
+		exporters: map[*beholderExporter]struct{}{},
+	}
+
+	var instruments []metric.Observable
+	for _, name := range append([]string{beholderMetricHeadTrackerHead, beholderMetricFeedMetadata, beholderMetricNodeMetadata},
+		append(beholderFeedGauges, beholderSenderGauges...)...) {
+		gauge, err := client.Meter.Float64ObservableGauge(name)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create gauge %s: %w", name, err)
+		}
+		f.gauges[name] = gauge
+		instruments = append(instruments, gauge)
+	}
+
+	var err error
+	if f.answersTotal, err = client.Meter.Int64ObservableCounter(beholderMetricAnswersTotal); err != nil {
+		return nil, fmt.Errorf("failed to create counter %s: %w", beholderMetricAnswersTotal, err)
+	}
+	instruments = append(instruments, f.answersTotal)
+
+	if _, err = client.Meter.RegisterCallback(f.observe, instruments...); err != nil {
+		return nil, fmt.Errorf("failed to register metrics callback: %w", err)
+	}
+	return f, nil
+}
+
+type beholderExporterFactory struct {
+	log     Logger
+	emitter beholder.Emitter
+
+	gauges       map[string]metric.Float64ObservableGauge
+	answersTotal metric.Int64ObservableCounter
+
+	exporters   map[*beholderExporter]struct{}
+	exportersMu sync.Mutex
+}
+
+func (b *beholderExporterFactory) NewExporter(params ExporterParams) (Exporter, error) {
+	exporter := &beholderExporter{
+		chainConfig: params.ChainConfig,
+		feedConfig:  params.FeedConfig,
+		nodes:       params.Nodes,
+		log:         b.log,
+		emitter:     b.emitter,
+		factory:     b,
+		feed:        map[string]float64{},
+		senders:     map[string]map[string]float64{},
+		prevValue:   new(big.Int),
+	}
+
+	b.exportersMu.Lock()
+	defer b.exportersMu.Unlock()
+	b.exporters[exporter] = struct{}{}
+	return exporter, nil
+}
+
+func (b *beholderExporterFactory) observe(_ context.Context, o metric.Observer) error {
+	b.exportersMu.Lock()
+	defer b.exportersMu.Unlock()
+	for exporter := range b.exporters {
+		exporter.observe(o, b)
+	}
+	return nil
+}
+
+func (b *beholderExporterFactory) remove(exporter *beholderExporter) {
+	b.exportersMu.Lock()
+	defer b.exportersMu.Unlock()
+	delete(b.exporters, exporter)
+}
+
+type beholderExporter struct {
+	chainConfig ChainConfig
+	feedConfig  FeedConfig
+	nodes       []NodeConfig
+
+	log     Logger
+	emitter beholder.Emitter
+	factory *beholderExporterFactory
+
+	// The latest values of the metrics, by metric name.
+	feed         map[string]float64
+	senders      map[string]map[string]float64 // by sender, then by metric name
+	blockNumber  *uint64
+	answersTotal int64
+	valuesMu     sync.Mutex
+
+	prevValue     *big.Int
+	prevTimestamp time.Time
+}
+
+func (b *beholderExporter) Export(ctx context.Context, data interface{}) {
+	switch typed := data.(type) {
+	case Envelope:
+		b.exportEnvelope(ctx, typed)
+	case TxResults:
+		b.exportTxResults(ctx, typed)
+	}
+}
+
+func (b *beholderExporter) exportEnvelope(ctx context.Context, envelope Envelope) {
+	multiply := toFloat64(b.feedConfig.GetMultiply())
+	if multiply == 0.0 {
+		multiply = 1.0
+	}
+	latestAnswer := toFloat64(envelope.LatestAnswer)
+	juelsPerFeeCoin := toFloat64(envelope.JuelsPerFeeCoin)
+	sender := string(envelope.Transmitter)
+
+	b.valuesMu.Lock()
+	b.feed[beholderMetricLinkBalance] = toFloat64(envelope.LinkBalance)
+	b.feed[beholderMetricLinkAvailable] = toFloat64(envelope.LinkAvailableForPayment)
+	blockNumber := envelope.BlockNumber
+	b.blockNumber = &blockNumber
+	if _, found := b.senders[sender]; !found {
+		b.senders[sender] = map[string]float64{}
+	}
+	if b.feedConfig.GetHeartbeatSec() != 0 {
+		isLateAnswer := time.Since(envelope.LatestTimestamp).Seconds() > float64(b.feedConfig.GetHeartbeatSec())
+		b.feed[beholderMetricAnswerStalled] = boolToFloat64(isLateAnswer)
+	}
+	// All the metrics below are only updated if there was a fresh
+	// transmission since the last chain read.
+	isNew := b.isNewTransmission(envelope.LatestAnswer, envelope.LatestTimestamp)
+	if isNew {
+		b.feed[beholderMetricAnswers] = latestAnswer / multiply
+		b.answersTotal++
+		b.feed[beholderMetricLatestTimestamp] = float64(envelope.LatestTimestamp.Unix())
+		b.feed[beholderMetricJuelsPerFeeCoin] = juelsPerFeeCoin / multiply
+		b.senders[sender][beholderMetricSubmissionReceived] = latestAnswer / multiply
+		b.senders[sender][beholderMetricJuelsReceived] = juelsPerFeeCoin / multiply
+		b.feed[beholderMetricRoundID] = float64(envelope.AggregatorRoundID)
+	}
+	b.valuesMu.Unlock()
+
+	if !isNew {
+		return
+	}
+	b.emit(ctx, beholderTransmissionEntity, beholderTransmissionSchema, beholderTransmission{
+		ConfigDigest:            envelope.ConfigDigest.Hex(),
+		Epoch:                   envelope.Epoch,
+		Round:                   envelope.Round,
+		LatestAnswer:            bigIntString(envelope.LatestAnswer),
+		LatestTimestamp:         envelope.LatestTimestamp.UTC(),
+		BlockNumber:             envelope.BlockNumber,
+		Transmitter:             sender,
+		LinkBalance:             bigIntString(envelope.LinkBalance),
+		LinkAvailableForPayment: bigIntString(envelope.LinkAvailableForPayment),
+		JuelsPerFeeCoin:         bigIntString(envelope.JuelsPerFeeCoin),
+		AggregatorRoundID:       envelope.AggregatorRoundID,
+	}, "sender", sender, "oracle_name", b.oracleName(types.Account(sender)))
+}
+
+func (b *beholderExporter) exportTxResults(ctx context.Context, res TxResults) {
+	b.valuesMu.Lock()
+	b.feed[beholderMetricTxSucceeded] = float64(res.NumSucceeded)
+	b.feed[beholderMetricTxFailed] = float64(res.NumFailed)
+	b.valuesMu.Unlock()
+
+	b.emit(ctx, beholderTxResultsEntity, beholderTxResultsSchema, beholderTxResults{
+		NumSucceeded: res.NumSucceeded,
+		NumFailed:    res.NumFailed,
+	})
+}
+
+// Cleanup stops reporting the metrics of this exporter.
+func (b *beholderExporter) Cleanup(_ context.Context) {
+	b.factory.remove(b)
+}
+
+// beholderTransmission is the body of the messages emitted for each new transmission.
+type beholderTransmission struct {
+	ConfigDigest            string    `json:"configDigest"`
+	Epoch                   uint32    `json:"epoch"`
+	Round                   uint8     `json:"round"`
+	LatestAnswer            string    `json:"latestAnswer"`
+	LatestTimestamp         time.Time `json:"latestTimestamp"`
+	BlockNumber             uint64    `json:"blockNumber"`
+	Transmitter             string    `json:"transmitter"`
+	LinkBalance             string    `json:"linkBalance"`
+	LinkAvailableForPayment string    `json:"linkAvailableForPayment"`
+	JuelsPerFeeCoin         string    `json:"juelsPerFeeCoin"`
+	AggregatorRoundID       uint32    `json:"aggregatorRoundID"`
+}
+
+// beholderTxResults is the body of the messages emitted for each TxResults.
+type beholderTxResults struct {
+	NumSucceeded uint64 `json:"numSucceeded"`
+	NumFailed    uint64 `json:"numFailed"`
+}
+
+func (b *beholderExporter) emit(ctx context.Context, entity, schema string, body any, attrKVs ...any) {
+	encoded, err := json.Marshal(body)
+	if err != nil {
+		b.log.Errorw("failed to encode beholder message", "entity", entity, "error", err)
+		return
+	}
+
+	attrs := b.feedAttributes()
+	for i := 0; i+1 < len(attrKVs); i += 2 {
+		attrs[attrKVs[i].(string)] = attrKVs[i+1]
+	}
+	attrs["beholder_domain"] = beholderDomain
+	attrs["beholder_entity"] = entity
+	attrs["beholder_data_schema"] = schema
+	if err = b.emitter.Emit(ctx, encoded, attrs); err != nil {
+		b.log.Errorw("failed to emit beholder message", "entity", entity, "error", err)
+	}
+}
+
+// feedAttributes returns the labels used by the prometheus exporter for the metrics of a feed.
+// network_name is a list, as expected in beholder.Metadata.
+func (b *beholderExporter) feedAttributes() beholder.Attributes {
+	return beholder.Attributes{
+		"contract_address": b.feedConfig.GetID(),
+		"feed_id":          b.feedConfig.GetID(),
+		"chain_id":         b.chainConfig.GetChainID(),
+		"contract_status":  b.feedConfig.GetContractStatus(),
+		"contract_type":    b.feedConfig.GetContractType(),
+		"feed_name":        b.feedConfig.GetName(),
+		"feed_path":        b.feedConfig.GetPath(),
+		"network_id":       b.chainConfig.GetNetworkID(),
+		"network_name":     []string{b.chainConfig.GetNetworkName()},
+	}
+}
+
+func (b *beholderExporter) observe(o metric.Observer, f *beholderExporterFactory) {
+	b.valuesMu.Lock()
+	defer b.valuesMu.Unlock()
+
+	chain := []attribute.KeyValue{
+		attribute.String("chain_id", b.chainConfig.GetChainID()),
+		attribute.String("network_id", b.chainConfig.GetNetworkID()),
+		attribute.String("network_name", b.chainConfig.GetNetworkName()),
+	}
+	feed := append([]attribute.KeyValue{
+		attribute.String("contract_address", b.feedConfig.GetID()),
+		attribute.String("feed_id", b.feedConfig.GetID()),
+		attribute.String("contract_status", b.feedConfig.GetContractStatus()),
+		attribute.String("contract_type", b.feedConfig.GetContractType()),
+		attribute.String("feed_name", b.feedConfig.GetName()),
+		attribute.String("feed_path", b.feedConfig.GetPath()),
+	}, chain...)
+
+	o.ObserveFloat64(f.gauges[beholderMetricFeedMetadata], 1,
+		metric.WithAttributes(append(feed, attribute.String("symbol", b.feedConfig.GetSymbol()))...))
+	if b.blockNumber != nil {
+		o.ObserveFloat64(f.gauges[beholderMetricHeadTrackerHead], float64(*b.blockNumber), metric.WithAttributes(chain...))
+	}
+	for _, name := range beholderFeedGauges {
+		if value, found := b.feed[name]; found {
+			o.ObserveFloat64(f.gauges[name], value, metric.WithAttributes(feed...))
+		}
+	}
+	o.ObserveInt64(f.answersTotal, b.answersTotal, metric.WithAttributes(feed...))
+
+	for sender, values := range b.senders {
+		o.ObserveFloat64(f.gauges[beholderMetricNodeMetadata], 1, metric.WithAttributes(append(chain,
+			attribute.String("oracle_name", b.oracleName(types.Account(sender))),
+			attribute.String("sender", sender),
+		)...))
+
+		withSender := metric.WithAttributes(append(feed, attribute.String("sender", sender))...)
+		for _, name := range beholderSenderGauges {
+			if value, found := values[name]; found {
+				o.ObserveFloat64(f.gauges[name], value, withSender)
+			}
+		}
+	}
+}
+
+func (b *beholderExporter) oracleName(sender types.Account) string {
+	oracleName, found := getOracleName(sender, b.nodes)
+	if !found {
+		oracleName = string(sender)
+	}
+	return oracleName
+}
+
+// isNewTransmission follows prometheusExporter.isNewTransmission. It must be called with valuesMu held.
+func (b *beholderExporter) isNewTransmission(value *big.Int, timestamp time.Time) bool {
+	if value.Cmp(b.prevValue) == 0 && timestamp.Equal(b.prevTimestamp) {
+		return false
+	}
+	b.prevValue = value
+	b.prevTimestamp = timestamp
+	return true
+}
+
+func bigIntString(bignum *big.Int) string {
+	if bignum == nil {
+		return ""
+	}
+	return bignum.String()
+}
+
+func boolToFloat64(b bool) float64 {
+	if b {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,144 @@
+package monitoring
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/beholder"
+)
+
+func TestBeholderExporter(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	var output syncBuffer
+	client, err := beholder.NewWriterClient(&output)
+	require.NoError(t, err)
+	defer client.Close()
+	// Flush twice so that an export started by the periodic reader before the reset is not included.
+	flushMetrics := func() string {
+		meterProvider := client.MeterProvider.(*sdkmetric.MeterProvider)
+		require.NoError(t, meterProvider.ForceFlush(ctx))
+		output.Reset()
+		require.NoError(t, meterProvider.ForceFlush(ctx))
+		return output.String()
+	}
+
+	factory, err := NewBeholderExporterFactory(newNullLogger(), client)
+	require.NoError(t, err)
+
+	chainConfig := generateChainConfig()
+	feedConfig1, feedConfig2 := generateFeedConfig(), generateFeedConfig()
+	nodes := []NodeConfig{generateNodeConfig()}
+	exporter1, err := factory.NewExporter(ExporterParams{chainConfig, feedConfig1, nodes})
+	require.NoError(t, err)
+	exporter2, err := factory.NewExporter(ExporterParams{chainConfig, feedConfig2, nodes})
+	require.NoError(t, err)
+
+	envelope, err := generateEnvelope(ctx)
+	require.NoError(t, err)
+	envelope.Transmitter = nodes[0].GetAccount()
+
+	t.Run("should emit messages with the labels of the feed", func(t *testing.T) {
+		output.Reset()
+		exporter1.Export(ctx, envelope)
+		exporter1.Export(ctx, TxResults{NumSucceeded: 10, NumFailed: 2})
+
+		emitted := output.String()
+		for _, expected := range []string{
+			beholderTransmissionSchema,
+			beholderTxResultsSchema,
+			feedConfig1.GetID(),
+			feedConfig1.GetName(),
+			chainConfig.GetNetworkName(),
+			nodes[0].GetName(),
+		} {
+			require.Contains(t, emitted, expected)
+		}
+
+		bodies := emittedBodies(t, emitted)
+		require.Len(t, bodies, 2)
+		var transmission beholderTransmission
+		require.NoError(t, json.Unmarshal(bodies[0], &transmission))
+		require.Equal(t, envelope.LatestAnswer.String(), transmission.LatestAnswer)
+		require.Equal(t, envelope.ConfigDigest.Hex(), transmission.ConfigDigest)
+		require.Equal(t, string(envelope.Transmitter), transmission.Transmitter)
+		require.JSONEq(t, `{"numSucceeded":10,"numFailed":2}`, string(bodies[1]))
+
+		output.Reset()
+		exporter1.Export(ctx, envelope)
+		require.NotContains(t, output.String(), beholderTransmissionSchema, "transmissions should only be emitted once")
+	})
+	t.Run("should report metrics of each feed until it is cleaned up", func(t *testing.T) {
+		exporter2.Export(ctx, envelope)
+
+		metrics := flushMetrics()
+		for _, name := range append([]string{
+			beholderMetricHeadTrackerHead, beholderMetricFeedMetadata, beholderMetricNodeMetadata, beholderMetricAnswersTotal,
+		}, append(beholderFeedGauges, beholderSenderGauges...)...) {
+			require.Contains(t, metrics, `"Name":"`+name+`"`)
+		}
+		require.Contains(t, metrics, feedConfig1.GetID())
+		require.Contains(t, metrics, feedConfig2.GetID())
+		require.Contains(t, metrics, string(envelope.Transmitter))
+
+		exporter2.Cleanup(ctx)
+		metrics = flushMetrics()
+		require.Contains(t, metrics, feedConfig1.GetID())
+		require.NotContains(t, metrics, feedConfig2.GetID())
+
+		exporter1.Cleanup(ctx)
+		metrics = flushMetrics()
+		require.NotContains(t, metrics, feedConfig1.GetID())
+		require.NotContains(t, metrics, string(envelope.Transmitter))
+	})
+}
+
+// emittedBodies decodes the bodies of the messages written by a beholder writer client, skipping metrics.
+func emittedBodies(t *testing.T, output string) [][]byte {
+	var bodies [][]byte
+	for _, line := range strings.Split(strings.TrimSpace(output), "\n") {
+		var record struct {
+			Body struct {
+				Value []byte
+			}
+		}
+		require.NoError(t, json.Unmarshal([]byte(line), &record))
+		if record.Body.Value != nil {
+			bodies = append(bodies, record.Body.Value)
+		}
+	}
+	return bodies
+}
+
+// syncBuffer is a bytes.Buffer safe for the concurrent writes of the beholder exporters.
+type syncBuffer struct {
+	buf bytes.Buffer
+	mu  sync.Mutex
+}
+
+func (s *syncBuffer) Write(p []byte) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.buf.Write(p)
+}
+
+func (s *syncBuffer) String() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.buf.String()
+}
+
+func (s *syncBuffer) Reset() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.buf.Reset()
+}
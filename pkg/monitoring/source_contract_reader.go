@@ -0,0 +1,321 @@
+package monitoring
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/smartcontractkit/libocr/offchainreporting2plus/types"
+
+	commontypes "github.com/smartcontractkit/chainlink-common/pkg/types"
+	"github.com/smartcontractkit/chainlink-common/pkg/values"
+)
+
+// ContractReaderRead identifies a value of an Envelope in the results of a ContractReader.
+type ContractReaderRead struct {
+	// ReadName is the name of the read in the ContractReader config of the feed contracts.
+	ReadName string `json:"readName"`
+	// Field is the dot separated path of the value in the result of the read,
+	// or empty if the result is the value itself.
+	Field string `json:"field,omitempty"`
+}
+
+// ContractReaderSourceConfig maps the reads of a ContractReader onto the fields of an Envelope.
+// Several fields can use the same read, which is only made once per Fetch().
+type ContractReaderSourceConfig struct {
+	// ContractName is the name of the feed contracts in the ContractReader config.
+	// Each source binds it to the address of its feed.
+	ContractName string `json:"contractName"`
+
+	LatestAnswer ContractReaderRead `json:"latestAnswer"`
+	Round        ContractReaderRead `json:"round"`
+	Transmitter  ContractReaderRead `json:"transmitter"`
+	ConfigDigest ContractReaderRead `json:"configDigest"`
+	LinkBalance  ContractReaderRead `json:"linkBalance"`
+
+	// Optional reads, the fields are left at their zero value if the read name is empty.
+	LatestTimestamp         ContractReaderRead `json:"latestTimestamp,omitempty"`
+	Epoch                   ContractReaderRead `json:"epoch,omitempty"`
+	AggregatorRoundID       ContractReaderRead `json:"aggregatorRoundID,omitempty"`
+	LinkAvailableForPayment ContractReaderRead `json:"linkAvailableForPayment,omitempty"`
+	JuelsPerFeeCoin         ContractReaderRead `json:"juelsPerFeeCoin,omitempty"`
+}
+
+// envelopeField sets a field of an Envelope from the value of a read.
+type envelopeField struct {
+	name     string
+	read     ContractReaderRead
+	required bool
+	set      func(envelope *Envelope, value any) error
+}
+
+func (c ContractReaderSourceConfig) fields() []envelopeField {
+	return []envelopeField{
+		{"LatestAnswer", c.LatestAnswer, true, func(e *Envelope, v any) (err error) {
+			e.LatestAnswer, err = toBigInt(v)
+			return
+		}},
+		{"Round", c.Round, true, func(e *Envelope, v any) error {
+			round, err := toUint(v, math.MaxUint8)
+			e.Round = uint8(round)
+			return err
+		}},
+		{"Transmitter", c.Transmitter, true, func(e *Envelope, v any) (err error) {
+			e.Transmitter, err = toAccount(v)
+			return
+		}},
+		{"ConfigDigest", c.ConfigDigest, true, func(e *Envelope, v any) (err error) {
+			e.ConfigDigest, err = toConfigDigest(v)
+			return
+		}},
+		{"LinkBalance", c.LinkBalance, true, func(e *Envelope, v any) (err error) {
+			e.LinkBalance, err = toBigInt(v)
+			return
+		}},
+		{"LatestTimestamp", c.LatestTimestamp, false, func(e *Envelope, v any) (err error) {
+			e.LatestTimestamp, err = toTime(v)
+			return
+		}},
+		{"Epoch", c.Epoch, false, func(e *Envelope, v any) error {
+			epoch, err := toUint(v, math.MaxUint32)
+			e.Epoch = uint32(epoch)
+			return err
+		}},
+		{"AggregatorRoundID", c.AggregatorRoundID, false, func(e *Envelope, v any) error {
+			roundID, err := toUint(v, math.MaxUint32)
+			e.AggregatorRoundID = uint32(roundID)
+			return err
+		}},
+		{"LinkAvailableForPayment", c.LinkAvailableForPayment, false, func(e *Envelope, v any) (err error) {
+			e.LinkAvailableForPayment, err = toBigInt(v)
+			return
+		}},
+		{"JuelsPerFeeCoin", c.JuelsPerFeeCoin, false, func(e *Envelope, v any) (err error) {
+			e.JuelsPerFeeCoin, err = toBigInt(v)
+			return
+		}},
+	}
+}
+
+// Validate returns an error if the contract name or any of the required reads is missing.
+func (c ContractReaderSourceConfig) Validate() error {
+	var err error
+	if c.ContractName == "" {
+		err = errors.Join(err, errors.New("contractName is required"))
+	}
+	for _, field := range c.fields() {
+		if field.required && field.read.ReadName == "" {
+			err = errors.Join(err, fmt.Errorf("a read for %s is required", field.name))
+		}
+	}
+	return err
+}
+
+// NewContractReaderSourceFactory returns a SourceFactory producing Envelope instances for each feed
+// by reading its contract with a ContractReader, as configured in config.
+// This lets any chain with a ContractReader implementation be monitored without a chain-specific source.
+func NewContractReaderSourceFactory(
+	reader commontypes.ContractReader,
+	config ContractReaderSourceConfig,
+	log Logger,
+) (SourceFactory, error) {
+	if err := config.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid contract reader source config: %w", err)
+	}
+	return &contractReaderSourceFactory{reader, config, log}, nil
+}
+
+type contractReaderSourceFactory struct {
+	reader commontypes.ContractReader
+	config ContractReaderSourceConfig
+	log    Logger
+}
+
+func (c *contractReaderSourceFactory) NewSource(_ ChainConfig, feedConfig FeedConfig) (Source, error) {
+	return &contractReaderSource{
+		c.reader,
+		c.config,
+		commontypes.BoundContract{Address: feedConfig.GetContractAddress(), Name: c.config.ContractName},
+		c.log,
+	}, nil
+}
+
+func (c *contractReaderSourceFactory) GetType() string {
+	return "contract_reader"
+}
+
+type contractReaderSource struct {
+	reader   commontypes.ContractReader
+	config   ContractReaderSourceConfig
+	contract commontypes.BoundContract
+	log      Logger
+}
+
+// Fetch makes all the configured reads in a single batch and returns an Envelope.
+func (c *contractReaderSource) Fetch(ctx context.Context) (interface{}, error) {
+	// Binding an already bound contract is a noop.
+	if err := c.reader.Bind(ctx, []commontypes.BoundContract{c.contract}); err != nil {
+		return nil, fmt.Errorf("failed to bind contract %s: %w", c.contract, err)
+	}
+
+	fields := c.config.fields()
+	var batch commontypes.ContractBatch
+	seen := map[string]bool{}
+	for _, field := range fields {
+		if name := field.read.ReadName; name != "" && !seen[name] {
+			seen[name] = true
+			batch = append(batch, commontypes.BatchRead{ReadName: name, ReturnVal: new(values.Value)})
+		}
+	}
+
+	results, err := c.reader.BatchGetLatestValues(ctx, commontypes.BatchGetLatestValuesRequest{c.contract: batch})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read contract %s: %w", c.contract, err)
+	}
+
+	unwrapped := map[string]any{}
+	for _, result := range results[c.contract] {
+		returnVal, readErr := result.GetResult()
+		if readErr != nil {
+			return nil, fmt.Errorf("read %s of contract %s failed: %w", result.ReadName, c.contract, readErr)
+		}
+
+		value, ok := returnVal.(*values.Value)
+		if !ok || value == nil || *value == nil {
+			return nil, fmt.Errorf("read %s of contract %s returned no value", result.ReadName, c.contract)
+		}
+
+		if unwrapped[result.ReadName], err = (*value).Unwrap(); err != nil {
+			return nil, fmt.Errorf("failed to unwrap the result of read %s: %w", result.ReadName, err)
+		}
+	}
+
+	envelope := Envelope{
+		LinkBalance:             new(big.Int),
+		LinkAvailableForPayment: new(big.Int),
+		JuelsPerFeeCoin:         new(big.Int),
+	}
+	for _, field := range fields {
+		if field.read.ReadName == "" {
+			continue
+		}
+
+		result, ok := unwrapped[field.read.ReadName]
+		if !ok {
+			return nil, fmt.Errorf("missing result of read %s of contract %s", field.read.ReadName, c.contract)
+		}
+
+		value, err := lookupField(result, field.read.Field)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find %s in read %s: %w", field.name, field.read.ReadName, err)
+		}
+
+		if err = field.set(&envelope, value); err != nil {
+			return nil, fmt.Errorf("invalid %s in read %s: %w", field.name, field.read.ReadName, err)
+		}
+	}
+	return envelope, nil
+}
+
+func lookupField(value any, path string) (any, error) {
+	if path == "" {
+		return value, nil
+	}
+
+	for _, name := range strings.Split(path, ".") {
+		m, ok := value.(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("expected an object with field %s, got %T", name, value)
+		}
+
+		if value, ok = m[name]; !ok {
+			return nil, fmt.Errorf("field %s not found", name)
+		}
+	}
+	return value, nil
+}
+
+func toBigInt(value any) (*big.Int, error) {
+	switch v := value.(type) {
+	case *big.Int:
+		if v == nil {
+			return nil, errors.New("expected an integer, got nil")
+		}
+		return new(big.Int).Set(v), nil
+	case int64:
+		return big.NewInt(v), nil
+	case uint64:
+		return new(big.Int).SetUint64(v), nil
+	case decimal.Decimal:
+		if !v.IsInteger() {
+			return nil, fmt.Errorf("expected an integer, got %s", v)
+		}
+		return v.BigInt(), nil
+	case string:
+		i, ok := new(big.Int).SetString(v, 10)
+		if !ok {
+			return nil, fmt.Errorf("expected an integer, got %q", v)
+		}
+		return i, nil
+	default:
+		return nil, fmt.Errorf("expected an integer, got %T", value)
+	}
+}
+
+func toUint(value any, max uint64) (uint64, error) {
+	i, err := toBigInt(value)
+	if err != nil {
+		return 0, err
+	}
+
+	if !i.IsUint64() || i.Uint64() > max {
+		return 0, fmt.Errorf("%s is out of range [0, %d]", i, max)
+	}
+	return i.Uint64(), nil
+}
+
+// toAccount returns strings as they are and encodes bytes in 0x prefixed hex.
+func toAccount(value any) (types.Account, error) {
+	switch v := value.(type) {
+	case string:
+		return types.Account(v), nil
+	case []byte:
+		return types.Account("0x" + hex.EncodeToString(v)), nil
+	default:
+		return "", fmt.Errorf("expected a string or bytes, got %T", value)
+	}
+}
+
+func toConfigDigest(value any) (types.ConfigDigest, error) {
+	switch v := value.(type) {
+	case []byte:
+		return types.BytesToConfigDigest(v)
+	case string:
+		b, err := hex.DecodeString(strings.TrimPrefix(v, "0x"))
+		if err != nil {
+			return types.ConfigDigest{}, fmt.Errorf("expected hex: %w", err)
+		}
+		return types.BytesToConfigDigest(b)
+	default:
+		return types.ConfigDigest{}, fmt.Errorf("expected bytes or a hex string, got %T", value)
+	}
+}
+
+// toTime accepts times, and integers as unix seconds.
+func toTime(value any) (time.Time, error) {
+	if t, ok := value.(time.Time); ok {
+		return t, nil
+	}
+
+	seconds, err := toUint(value, math.MaxInt64)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("expected a time or unix seconds: %w", err)
+	}
+	return time.Unix(int64(seconds), 0), nil
+}
@@ -0,0 +1,158 @@
+package monitoring
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/smartcontractkit/libocr/offchainreporting2plus/types"
+	"github.com/stretchr/testify/require"
+
+	commontypes "github.com/smartcontractkit/chainlink-common/pkg/types"
+	"github.com/smartcontractkit/chainlink-common/pkg/values"
+)
+
+func TestContractReaderSource(t *testing.T) {
+	digest := types.ConfigDigest{0x00, 0x01, 0x02}
+	transmission := map[string]any{
+		"configDigest":    digest[:],
+		"epoch":           int64(12),
+		"round":           int64(3),
+		"latestAnswer":    big.NewInt(123456),
+		"latestTimestamp": time.Unix(1700000000, 0).UTC(),
+	}
+	config := ContractReaderSourceConfig{
+		ContractName:    "OCR2Aggregator",
+		LatestAnswer:    ContractReaderRead{ReadName: "LatestTransmissionDetails", Field: "latestAnswer"},
+		Round:           ContractReaderRead{ReadName: "LatestTransmissionDetails", Field: "round"},
+		Transmitter:     ContractReaderRead{ReadName: "LatestTransmitter"},
+		ConfigDigest:    ContractReaderRead{ReadName: "LatestTransmissionDetails", Field: "configDigest"},
+		LinkBalance:     ContractReaderRead{ReadName: "LinkBalance", Field: "balance.juels"},
+		LatestTimestamp: ContractReaderRead{ReadName: "LatestTransmissionDetails", Field: "latestTimestamp"},
+		Epoch:           ContractReaderRead{ReadName: "LatestTransmissionDetails", Field: "epoch"},
+	}
+	newReader := func() *fakeContractReader {
+		return &fakeContractReader{results: map[string]any{
+			"LatestTransmissionDetails": transmission,
+			"LatestTransmitter":         []byte{0xab, 0xcd},
+			"LinkBalance":               map[string]any{"balance": map[string]any{"juels": "1000000000000000000000"}},
+		}}
+	}
+	feedConfig := generateFeedConfig()
+
+	t.Run("should map the configured reads onto an envelope", func(t *testing.T) {
+		reader := newReader()
+		factory, err := NewContractReaderSourceFactory(reader, config, newNullLogger())
+		require.NoError(t, err)
+		source, err := factory.NewSource(generateChainConfig(), feedConfig)
+		require.NoError(t, err)
+
+		data, err := source.Fetch(context.Background())
+		require.NoError(t, err)
+		envelope, ok := data.(Envelope)
+		require.True(t, ok)
+
+		linkBalance, _ := new(big.Int).SetString("1000000000000000000000", 10)
+		require.Equal(t, Envelope{
+			ConfigDigest:            digest,
+			Epoch:                   12,
+			Round:                   3,
+			LatestAnswer:            big.NewInt(123456),
+			LatestTimestamp:         time.Unix(1700000000, 0).UTC(),
+			Transmitter:             "0xabcd",
+			LinkBalance:             linkBalance,
+			LinkAvailableForPayment: new(big.Int),
+			JuelsPerFeeCoin:         new(big.Int),
+		}, envelope)
+
+		contract := commontypes.BoundContract{Address: feedConfig.GetContractAddress(), Name: "OCR2Aggregator"}
+		require.Equal(t, []commontypes.BoundContract{contract}, reader.bound)
+		require.Equal(t, []string{"LatestTransmissionDetails", "LatestTransmitter", "LinkBalance"}, reader.reads,
+			"reads used by several fields should only be made once")
+	})
+	t.Run("should fail for invalid results", func(t *testing.T) {
+		for name, modify := range map[string]func(results map[string]any, transmission map[string]any){
+			"missing field":    func(_, tr map[string]any) { delete(tr, "latestAnswer") },
+			"round overflow":   func(_, tr map[string]any) { tr["round"] = int64(256) },
+			"short digest":     func(_, tr map[string]any) { tr["configDigest"] = []byte{0x01} },
+			"negative seconds": func(_, tr map[string]any) { tr["latestTimestamp"] = int64(-1) },
+			"not an object":    func(r, _ map[string]any) { r["LinkBalance"] = "1" },
+			"not an integer": func(r, _ map[string]any) {
+				r["LinkBalance"] = map[string]any{"balance": map[string]any{"juels": "1.5"}}
+			},
+			"wrong type": func(r, _ map[string]any) { r["LatestTransmitter"] = int64(1) },
+		} {
+			t.Run(name, func(t *testing.T) {
+				reader := newReader()
+				modified := map[string]any{}
+				for k, v := range transmission {
+					modified[k] = v
+				}
+				reader.results["LatestTransmissionDetails"] = modified
+				modify(reader.results, modified)
+
+				factory, err := NewContractReaderSourceFactory(reader, config, newNullLogger())
+				require.NoError(t, err)
+				source, err := factory.NewSource(generateChainConfig(), feedConfig)
+				require.NoError(t, err)
+				_, err = source.Fetch(context.Background())
+				require.Error(t, err)
+			})
+		}
+	})
+	t.Run("should fail if a read fails", func(t *testing.T) {
+		reader := newReader()
+		reader.readErr = errors.New("rpc unavailable")
+		factory, err := NewContractReaderSourceFactory(reader, config, newNullLogger())
+		require.NoError(t, err)
+		source, err := factory.NewSource(generateChainConfig(), feedConfig)
+		require.NoError(t, err)
+		_, err = source.Fetch(context.Background())
+		require.ErrorContains(t, err, "rpc unavailable")
+	})
+	t.Run("should require the contract name and reads", func(t *testing.T) {
+		_, err := NewContractReaderSourceFactory(newReader(), ContractReaderSourceConfig{
+			LatestAnswer: ContractReaderRead{ReadName: "LatestAnswer"},
+		}, newNullLogger())
+		require.ErrorContains(t, err, "contractName is required")
+		require.ErrorContains(t, err, "a read for Round is required")
+		require.ErrorContains(t, err, "a read for LinkBalance is required")
+	})
+}
+
+// fakeContractReader returns results for read names as values.Value.
+type fakeContractReader struct {
+	commontypes.UnimplementedContractReader
+	results map[string]any
+	readErr error
+
+	bound []commontypes.BoundContract
+	reads []string
+}
+
+func (f *fakeContractReader) Bind(_ context.Context, bindings []commontypes.BoundContract) error {
+	f.bound = append(f.bound, bindings...)
+	return nil
+}
+
+func (f *fakeContractReader) BatchGetLatestValues(_ context.Context, request commontypes.BatchGetLatestValuesRequest) (commontypes.BatchGetLatestValuesResult, error) {
+	result := commontypes.BatchGetLatestValuesResult{}
+	for contract, batch := range request {
+		for _, read := range batch {
+			f.reads = append(f.reads, read.ReadName)
+
+			wrapped, err := values.Wrap(f.results[read.ReadName])
+			if err != nil {
+				return nil, err
+			}
+			*read.ReturnVal.(*values.Value) = wrapped
+
+			readResult := commontypes.BatchReadResult{ReadName: read.ReadName}
+			readResult.SetResult(read.ReturnVal, f.readErr)
+			result[contract] = append(result[contract], readResult)
+		}
+	}
+	return result, nil
+}
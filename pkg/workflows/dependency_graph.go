@@ -4,10 +4,13 @@ import (
 	"errors"
 	"fmt"
 	"regexp"
+	"slices"
 	"strings"
 
 	"github.com/dominikbraun/graph"
 
+	"github.com/smartcontractkit/chainlink-common/pkg/capabilities"
+	"github.com/smartcontractkit/chainlink-common/pkg/workflows/expr"
 	"github.com/smartcontractkit/chainlink-common/pkg/workflows/sdk"
 )
 
@@ -18,6 +21,15 @@ const (
 type Vertex struct {
 	sdk.StepDefinition
 	Dependencies []string
+	// Branches are the branches of control steps this step depends on.
+	// The step should only be executed if all of them are taken.
+	Branches []BranchRef
+}
+
+// BranchRef references a single branch of a control step, as in $(ref.outputs.branch).
+type BranchRef struct {
+	Ref    string
+	Branch string
 }
 
 // DependencyGraph is an intermediate representation of a workflow wherein all the graph
@@ -97,10 +109,52 @@ func BuildDependencyGraph(spec sdk.WorkflowSpec) (*DependencyGraph, error) {
 			inputs = step.Inputs.Mapping
 		}
 
-		refs, innerErr := findRefs(inputs)
+		keys, innerErr := findKeys(inputs)
 		if innerErr != nil {
 			return nil, innerErr
 		}
+
+		if step.ForEach != nil {
+			itemsKeys, itemsErr := findKeys(step.ForEach.Items)
+			if itemsErr != nil {
+				return nil, itemsErr
+			}
+			if len(itemsKeys) != 1 {
+				return nil, fmt.Errorf("invalid forEach items %q for step %s: must be a single reference", step.ForEach.Items, stepRef)
+			}
+			if step.ForEach.MaxItems < 1 || step.ForEach.MaxItems > sdk.MaxForEachItems {
+				return nil, fmt.Errorf("invalid forEach max items %d for step %s: must be between 1 and %d", step.ForEach.MaxItems, stepRef, sdk.MaxForEachItems)
+			}
+			keys = append(keys, itemsKeys...)
+		}
+
+		if innerErr = validateControlStep(step); innerErr != nil {
+			return nil, innerErr
+		}
+
+		var refs []string
+		for _, key := range keys {
+			parts := strings.Split(key, ".")
+			if parts[0] == sdk.KeywordItem {
+				if step.ForEach == nil {
+					return nil, fmt.Errorf("invalid ref %s for step %s: %s can only be used by forEach steps", key, stepRef, sdk.KeywordItem)
+				}
+				continue
+			}
+
+			dep, depErr := g.Vertex(parts[0])
+			if depErr == nil && dep.IsControlStep() {
+				branch, branchErr := branchFor(dep, parts)
+				if branchErr != nil {
+					return nil, fmt.Errorf("invalid ref %s for step %s: %w", key, stepRef, branchErr)
+				}
+				if !slices.Contains(step.Branches, branch) {
+					step.Branches = append(step.Branches, branch)
+				}
+			}
+
+			refs = append(refs, parts[0])
+		}
 		step.Dependencies = refs
 
 		if stepRef != KeywordTrigger && len(refs) == 0 {
@@ -132,42 +186,110 @@ func BuildDependencyGraph(spec sdk.WorkflowSpec) (*DependencyGraph, error) {
 	return wf, err
 }
 
+// validateControlStep checks the inputs and config of if and switch steps.
+func validateControlStep(step *Vertex) error {
+	if !step.IsControlStep() {
+		return nil
+	}
+
+	if step.CapabilityType != capabilities.CapabilityTypeAction {
+		return fmt.Errorf("control step %s must be an action", step.Ref)
+	}
+
+	if step.ForEach != nil {
+		return fmt.Errorf("control step %s cannot be a forEach step", step.Ref)
+	}
+
+	if _, err := step.StepDefinition.Branches(); err != nil {
+		return err
+	}
+
+	required := sdk.ControlValueInput
+	if step.ID == sdk.IfStepID {
+		required = sdk.IfConditionInput
+	}
+	if _, ok := step.Inputs.Mapping[required]; !ok {
+		return fmt.Errorf("control step %s must have a %s input", step.Ref, required)
+	}
+	return nil
+}
+
+// branchFor returns the branch of control step referenced by the parts of an interpolation key.
+func branchFor(control *Vertex, parts []string) (BranchRef, error) {
+	if len(parts) < 3 || parts[1] != "outputs" {
+		return BranchRef{}, fmt.Errorf("references to control step %s must be of the form %s.outputs.<branch>", control.Ref, control.Ref)
+	}
+
+	branches, err := control.StepDefinition.Branches()
+	if err != nil {
+		return BranchRef{}, err
+	}
+	if !slices.Contains(branches, parts[2]) {
+		return BranchRef{}, fmt.Errorf("control step %s has no branch %s, must be one of %s", control.Ref, parts[2], strings.Join(branches, ", "))
+	}
+
+	return BranchRef{Ref: control.Ref, Branch: parts[2]}, nil
+}
+
 var (
 	InterpolationTokenRe = regexp.MustCompile(`^\$\((\S+)\)$`)
+
+	interpolationExpressionRe = regexp.MustCompile(`^\$\((.+)\)$`)
+	// expressionSyntaxRe matches the characters only found in expressions, not in plain keys
+	expressionSyntaxRe = regexp.MustCompile(`[\s()\[\]'"+*/%<>=!&|?,]`)
 )
 
-// findRefs takes an `inputs` and returns a list of all the step references
-// contained within it.
-func findRefs(inputs any) ([]string, error) {
-	var refs []string
+// ParseInterpolationToken returns the content of s if it is a single interpolation token, as in `$(content)`, and
+// whether the content is an expression rather than a plain key, which is resolved as is.
+// Strings with more than one token, such as `$(a.outputs.x) and $(b.outputs.y)`, are not interpolated.
+func ParseInterpolationToken(s string) (content string, isExpression bool, ok bool) {
+	if strings.Count(s, "$(") > 1 {
+		return "", false, false
+	}
+	if matches := InterpolationTokenRe.FindStringSubmatch(s); len(matches) == 2 && !expressionSyntaxRe.MatchString(matches[1]) {
+		return matches[1], false, true
+	}
+	if matches := interpolationExpressionRe.FindStringSubmatch(s); len(matches) == 2 {
+		return matches[1], true, true
+	}
+	return "", false, false
+}
+
+// findKeys takes an `inputs` and returns a list of all the interpolation keys
+// contained within it, e.g. `step.outputs.value` for `$(step.outputs.value)`
+// or `a.outputs.x` and `b.outputs.y` for `$(a.outputs.x + b.outputs.y)`.
+func findKeys(inputs any) ([]string, error) {
+	var keys []string
 	_, err := DeepMap(
 		inputs,
 		// This function is called for each string in the map
 		// for each string, we iterate over each match of the interpolation token
 		// - if there are no matches, return no reference
-		// - if there is one match, return the reference
-		// - if there are multiple matches (in the case of a multi-part state reference), return just the step ref
+		// - if there is one match, return the keys referenced by the expression
 		func(el any) (any, error) {
 			if _, ok := el.(string); !ok {
 				return el, nil
 			}
 
-			matches := InterpolationTokenRe.FindStringSubmatch(el.(string))
-			if len(matches) < 2 {
+			content, isExpression, ok := ParseInterpolationToken(el.(string))
+			if !ok {
+				return el, nil
+			}
+			if !isExpression {
+				keys = append(keys, content)
 				return el, nil
 			}
 
-			m := matches[1]
-			parts := strings.Split(m, ".")
-			if len(parts) < 1 {
-				return nil, fmt.Errorf("invalid ref %s", m)
+			e, err := expr.Parse(content)
+			if err != nil {
+				return nil, err
 			}
 
-			refs = append(refs, parts[0])
+			keys = append(keys, e.Refs()...)
 			return el, nil
 		},
 	)
-	return refs, err
+	return keys, err
 }
 
 // DeepMap recursively applies a transformation function
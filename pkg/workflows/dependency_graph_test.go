@@ -360,6 +360,272 @@ targets:
 				"a-target": {},
 			},
 		},
+		{
+			name: "expressions",
+			yaml: `
+name: length_ten # exactly 10 characters
+owner: 0x0123456789abcdef0123456789abcdef01234567
+triggers:
+  - id: "a-trigger@1.0.0"
+    config: {}
+actions:
+  - id: "an-action@1.0.0"
+    ref: "an-action"
+    config: {}
+    inputs:
+      name: $(lower(trigger.outputs.name))
+targets:
+  - id: "a-target@1.0.0"
+    ref: "a-target"
+    config: {}
+    inputs:
+      value: $(an-action.outputs.price * 2 ?? trigger.outputs.fallback)
+`,
+			graph: map[string]map[string]struct{}{
+				workflows.KeywordTrigger: {
+					"an-action": struct{}{},
+					"a-target":  struct{}{},
+				},
+				"an-action": {
+					"a-target": struct{}{},
+				},
+				"a-target": {},
+			},
+		},
+		{
+			name: "keys which are not expressions",
+			yaml: `
+name: length_ten # exactly 10 characters
+owner: 0x0123456789abcdef0123456789abcdef01234567
+triggers:
+  - id: "a-trigger@1.0.0"
+    config: {}
+actions:
+  - id: "an-action@1.0.0"
+    ref: "an-action"
+    config: {}
+    inputs:
+      literal: $(trigger.outputs.a) and $(trigger.outputs.b)
+      price: $(trigger.outputs.price)
+targets:
+  - id: "a-target@1.0.0"
+    ref: "a-target"
+    config: {}
+    inputs:
+      value: $(an-action.outputs.key:with)
+`,
+			graph: map[string]map[string]struct{}{
+				workflows.KeywordTrigger: {
+					"an-action": struct{}{},
+				},
+				"an-action": {
+					"a-target": struct{}{},
+				},
+				"a-target": {},
+			},
+		},
+		{
+			name: "invalid expression",
+			yaml: `
+name: length_ten # exactly 10 characters
+owner: 0x0123456789abcdef0123456789abcdef01234567
+triggers:
+  - id: "a-trigger@1.0.0"
+    config: {}
+targets:
+  - id: "a-target@1.0.0"
+    ref: "a-target"
+    config: {}
+    inputs:
+      value: $(trigger.outputs.price *)
+`,
+			errMsg: "invalid expression `trigger.outputs.price *`",
+		},
+		{
+			name: "control flow steps",
+			yaml: `
+name: length_ten # exactly 10 characters
+owner: 0x0123456789abcdef0123456789abcdef01234567
+triggers:
+  - id: "a-trigger@1.0.0"
+    config: {}
+actions:
+  - id: "if@1.0.0"
+    ref: "check"
+    config: {}
+    inputs:
+      condition: $(trigger.outputs.ok)
+      value: $(trigger.outputs)
+  - id: "switch@1.0.0"
+    ref: "route"
+    config:
+      cases: ["ethereum", "polygon"]
+    inputs:
+      value: $(check.outputs.then.chain)
+  - id: "an-action@1.0.0"
+    ref: "an-action"
+    config: {}
+    forEach:
+      items: $(route.outputs.polygon.accounts)
+      maxItems: 10
+    inputs:
+      account: $(item.outputs.address)
+targets:
+  - id: "a-target@1.0.0"
+    ref: "a-target"
+    config: {}
+    inputs:
+      balances: $(an-action.outputs)
+      fallback: $(route.outputs.default)
+`,
+			graph: map[string]map[string]struct{}{
+				workflows.KeywordTrigger: {
+					"check": struct{}{},
+				},
+				"check": {
+					"route": struct{}{},
+				},
+				"route": {
+					"an-action": struct{}{},
+					"a-target":  struct{}{},
+				},
+				"an-action": {
+					"a-target": struct{}{},
+				},
+				"a-target": {},
+			},
+		},
+		{
+			name: "control step referenced without a branch",
+			yaml: `
+name: length_ten # exactly 10 characters
+owner: 0x0123456789abcdef0123456789abcdef01234567
+triggers:
+  - id: "a-trigger@1.0.0"
+    config: {}
+actions:
+  - id: "if@1.0.0"
+    ref: "check"
+    config: {}
+    inputs:
+      condition: $(trigger.outputs.ok)
+targets:
+  - id: "a-target@1.0.0"
+    ref: "a-target"
+    config: {}
+    inputs: $(check.outputs)
+`,
+			errMsg: "references to control step check must be of the form check.outputs.<branch>",
+		},
+		{
+			name: "unknown branch",
+			yaml: `
+name: length_ten # exactly 10 characters
+owner: 0x0123456789abcdef0123456789abcdef01234567
+triggers:
+  - id: "a-trigger@1.0.0"
+    config: {}
+actions:
+  - id: "switch@1.0.0"
+    ref: "route"
+    config:
+      cases: ["ethereum"]
+    inputs:
+      value: $(trigger.outputs.chain)
+targets:
+  - id: "a-target@1.0.0"
+    ref: "a-target"
+    config: {}
+    inputs:
+      value: $(route.outputs.polygon)
+`,
+			errMsg: "control step route has no branch polygon, must be one of ethereum, default",
+		},
+		{
+			name: "if without a condition",
+			yaml: `
+name: length_ten # exactly 10 characters
+owner: 0x0123456789abcdef0123456789abcdef01234567
+triggers:
+  - id: "a-trigger@1.0.0"
+    config: {}
+actions:
+  - id: "if@1.0.0"
+    ref: "check"
+    config: {}
+    inputs:
+      value: $(trigger.outputs)
+targets:
+  - id: "a-target@1.0.0"
+    ref: "a-target"
+    config: {}
+    inputs:
+      value: $(check.outputs.then)
+`,
+			errMsg: "missing properties: 'condition'",
+		},
+		{
+			name: "switch with a default case",
+			yaml: `
+name: length_ten # exactly 10 characters
+owner: 0x0123456789abcdef0123456789abcdef01234567
+triggers:
+  - id: "a-trigger@1.0.0"
+    config: {}
+actions:
+  - id: "switch@1.0.0"
+    ref: "route"
+    config:
+      cases: ["default"]
+    inputs:
+      value: $(trigger.outputs.chain)
+targets:
+  - id: "a-target@1.0.0"
+    ref: "a-target"
+    config: {}
+    inputs:
+      value: $(route.outputs.default)
+`,
+			errMsg: "cases/0' does not validate",
+		},
+		{
+			name: "item outside of forEach",
+			yaml: `
+name: length_ten # exactly 10 characters
+owner: 0x0123456789abcdef0123456789abcdef01234567
+triggers:
+  - id: "a-trigger@1.0.0"
+    config: {}
+targets:
+  - id: "a-target@1.0.0"
+    ref: "a-target"
+    config: {}
+    inputs:
+      value: $(item.outputs)
+      trigger: $(trigger.outputs)
+`,
+			errMsg: "item can only be used by forEach steps",
+		},
+		{
+			name: "forEach with too many items",
+			yaml: `
+name: length_ten # exactly 10 characters
+owner: 0x0123456789abcdef0123456789abcdef01234567
+triggers:
+  - id: "a-trigger@1.0.0"
+    config: {}
+targets:
+  - id: "a-target@1.0.0"
+    ref: "a-target"
+    config: {}
+    forEach:
+      items: $(trigger.outputs.list)
+      maxItems: 1000
+    inputs:
+      value: $(item.outputs)
+`,
+			errMsg: "must be <= 100",
+		},
 	}
 
 	for _, tc := range testCases {
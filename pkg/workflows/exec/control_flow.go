@@ -0,0 +1,105 @@
+package exec
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/values"
+	"github.com/smartcontractkit/chainlink-common/pkg/workflows"
+	"github.com/smartcontractkit/chainlink-common/pkg/workflows/sdk"
+)
+
+// EvaluateControlStep routes the value of an if or switch step to one of its branches.
+// The outputs it returns map the branch taken to the routed value.
+func EvaluateControlStep(step sdk.StepDefinition, inputs *values.Map) (*values.Map, error) {
+	if inputs == nil {
+		return nil, fmt.Errorf("control step %s has no inputs", step.Ref)
+	}
+
+	var branch string
+	value := inputs.Underlying[sdk.ControlValueInput]
+	switch step.ID {
+	case sdk.IfStepID:
+		condition, ok := inputs.Underlying[sdk.IfConditionInput]
+		if !ok {
+			return nil, fmt.Errorf("if step %s has no %s input", step.Ref, sdk.IfConditionInput)
+		}
+
+		var isTrue bool
+		if err := condition.UnwrapTo(&isTrue); err != nil {
+			return nil, fmt.Errorf("condition of if step %s must be a boolean: %w", step.Ref, err)
+		}
+
+		branch = sdk.IfElseBranch
+		if isTrue {
+			branch = sdk.IfThenBranch
+		}
+
+		// without a value, the condition is routed to the branch
+		if value == nil {
+			value = condition
+		}
+	case sdk.SwitchStepID:
+		cases, err := sdk.SwitchCases(step.Config)
+		if err != nil {
+			return nil, fmt.Errorf("invalid switch step %s: %w", step.Ref, err)
+		}
+
+		if value == nil {
+			return nil, fmt.Errorf("switch step %s has no %s input", step.Ref, sdk.ControlValueInput)
+		}
+
+		unwrapped, err := value.Unwrap()
+		if err != nil {
+			return nil, err
+		}
+
+		switch unwrapped.(type) {
+		case string, bool, int64, uint64:
+		default:
+			return nil, fmt.Errorf("value of switch step %s must be a string, integer or boolean, got %T", step.Ref, unwrapped)
+		}
+
+		branch = sdk.SwitchDefaultBranch
+		if matched := fmt.Sprint(unwrapped); slices.Contains(cases, matched) {
+			branch = matched
+		}
+	default:
+		return nil, fmt.Errorf("step %s is not a control step", step.Ref)
+	}
+
+	return &values.Map{Underlying: map[string]values.Value{branch: value}}, nil
+}
+
+// BranchTaken returns true if the branch was taken by the control step that produced the result.
+func BranchTaken(result *Result, branch workflows.BranchRef) bool {
+	if result == nil || result.Error != nil {
+		return false
+	}
+
+	outputs, ok := result.Outputs.(*values.Map)
+	if !ok || outputs == nil {
+		return false
+	}
+
+	_, ok = outputs.Underlying[branch.Branch]
+	return ok
+}
+
+// WithItem returns Results where the element a forEach step is executed for can be referenced
+// with sdk.KeywordItem, as in $(item.outputs).
+func WithItem(results Results, item values.Value) Results {
+	return itemResults{Results: results, item: &Result{Outputs: item}}
+}
+
+type itemResults struct {
+	Results
+	item *Result
+}
+
+func (r itemResults) ResultForStep(s string) (*Result, bool) {
+	if s == sdk.KeywordItem {
+		return r.item, true
+	}
+	return r.Results.ResultForStep(s)
+}
@@ -0,0 +1,123 @@
+package exec_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/values"
+	"github.com/smartcontractkit/chainlink-common/pkg/workflows"
+	"github.com/smartcontractkit/chainlink-common/pkg/workflows/exec"
+	"github.com/smartcontractkit/chainlink-common/pkg/workflows/sdk"
+)
+
+func TestEvaluateControlStep(t *testing.T) {
+	t.Parallel()
+	ifStep := sdk.StepDefinition{ID: sdk.IfStepID, Ref: "check"}
+	switchStep := sdk.StepDefinition{
+		ID:     sdk.SwitchStepID,
+		Ref:    "route",
+		Config: map[string]any{sdk.SwitchCasesConfig: []any{"ethereum", "1"}},
+	}
+
+	testCases := []struct {
+		name     string
+		step     sdk.StepDefinition
+		inputs   map[string]any
+		expected map[string]any
+		errMsg   string
+	}{
+		{
+			name:     "if true",
+			step:     ifStep,
+			inputs:   map[string]any{"condition": true, "value": "foo"},
+			expected: map[string]any{"then": "foo"},
+		},
+		{
+			name:     "if false",
+			step:     ifStep,
+			inputs:   map[string]any{"condition": false, "value": "foo"},
+			expected: map[string]any{"else": "foo"},
+		},
+		{
+			name:     "if without value routes the condition",
+			step:     ifStep,
+			inputs:   map[string]any{"condition": true},
+			expected: map[string]any{"then": true},
+		},
+		{
+			name:   "if with a non boolean condition",
+			step:   ifStep,
+			inputs: map[string]any{"condition": "yes"},
+			errMsg: "condition of if step check must be a boolean",
+		},
+		{
+			name:     "switch matches a case",
+			step:     switchStep,
+			inputs:   map[string]any{"value": "ethereum"},
+			expected: map[string]any{"ethereum": "ethereum"},
+		},
+		{
+			name:     "switch matches an integer by its string representation",
+			step:     switchStep,
+			inputs:   map[string]any{"value": int64(1)},
+			expected: map[string]any{"1": int64(1)},
+		},
+		{
+			name:     "switch falls back to default",
+			step:     switchStep,
+			inputs:   map[string]any{"value": "polygon"},
+			expected: map[string]any{"default": "polygon"},
+		},
+		{
+			name:   "switch on a map",
+			step:   switchStep,
+			inputs: map[string]any{"value": map[string]any{"a": "b"}},
+			errMsg: "value of switch step route must be a string, integer or boolean",
+		},
+		{
+			name:   "not a control step",
+			step:   sdk.StepDefinition{ID: "an-action@1.0.0", Ref: "an-action"},
+			inputs: map[string]any{},
+			errMsg: "step an-action is not a control step",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			inputs, err := values.NewMap(tc.inputs)
+			require.NoError(t, err)
+
+			outputs, err := exec.EvaluateControlStep(tc.step, inputs)
+			if tc.errMsg != "" {
+				assert.ErrorContains(t, err, tc.errMsg)
+				return
+			}
+			require.NoError(t, err)
+
+			unwrapped, err := outputs.Unwrap()
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, unwrapped)
+
+			for branch := range tc.expected {
+				assert.True(t, exec.BranchTaken(&exec.Result{Outputs: outputs}, workflows.BranchRef{Ref: tc.step.Ref, Branch: branch}))
+			}
+			assert.False(t, exec.BranchTaken(&exec.Result{Outputs: outputs}, workflows.BranchRef{Ref: tc.step.Ref, Branch: "other"}))
+		})
+	}
+}
+
+func TestWithItem(t *testing.T) {
+	t.Parallel()
+	item, err := values.Wrap(map[string]any{"address": "0x1"})
+	require.NoError(t, err)
+
+	results := exec.WithItem(fakeResults{"trigger": {Outputs: values.NewString("foo")}}, item)
+	got, err := exec.FindAndInterpolateAllKeys(map[string]any{
+		"address": "$(item.outputs.address)",
+		"trigger": "$(trigger.outputs)",
+	}, results)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{"address": "0x1", "trigger": "foo"}, got)
+}
@@ -7,6 +7,7 @@ import (
 
 	"github.com/smartcontractkit/chainlink-common/pkg/values"
 	"github.com/smartcontractkit/chainlink-common/pkg/workflows"
+	"github.com/smartcontractkit/chainlink-common/pkg/workflows/expr"
 )
 
 // InterpolateKey takes a multi-part, dot-separated key and attempts to replace
@@ -80,10 +81,25 @@ func InterpolateKey(key string, state Results) (any, error) {
 	return val, nil
 }
 
+// EvaluateExpression evaluates an expression against `state`, see package expr.
+//
+// References within the expression are resolved with InterpolateKey.
+func EvaluateExpression(expression string, state Results) (any, error) {
+	e, err := expr.Parse(expression)
+	if err != nil {
+		return nil, err
+	}
+
+	return e.Evaluate(func(key string) (any, error) {
+		return InterpolateKey(key, state)
+	})
+}
+
 // FindAndInterpolateAllKeys takes an `input` any value, and recursively
 // identifies any values that should be replaced from `state`.
 //
-// A value `v` should be replaced if it is wrapped as follows: `$(v)`.
+// A value `v` should be replaced if it is wrapped as follows: `$(v)`,
+// where `v` is either a key, as in InterpolateKey, or an expression, as in EvaluateExpression.
 func FindAndInterpolateAllKeys(input any, state Results) (any, error) {
 	return workflows.DeepMap(
 		input,
@@ -92,13 +108,15 @@ func FindAndInterpolateAllKeys(input any, state Results) (any, error) {
 				return el, nil
 			}
 
-			matches := workflows.InterpolationTokenRe.FindStringSubmatch(el.(string))
-			if len(matches) < 2 {
+			content, isExpression, ok := workflows.ParseInterpolationToken(el.(string))
+			if !ok {
 				return el, nil
 			}
+			if !isExpression {
+				return InterpolateKey(content, state)
+			}
 
-			interpolatedVar := matches[1]
-			return InterpolateKey(interpolatedVar, state)
+			return EvaluateExpression(content, state)
 		},
 	)
 }
@@ -162,6 +162,11 @@ func TestInterpolateKey(t *testing.T) {
 
 func TestInterpolateInputsFromState(t *testing.T) {
 	t.Parallel()
+	triggerOutputs, err := values.NewMap(map[string]any{"name": "ETH", "prices": []any{int64(101), int64(99)}})
+	require.NoError(t, err)
+	keyWithColon, err := values.NewMap(map[string]any{"key:with": "ETH"})
+	require.NoError(t, err)
+
 	testCases := []struct {
 		name     string
 		inputs   map[string]any
@@ -201,6 +206,50 @@ func TestInterpolateInputsFromState(t *testing.T) {
 				"foo": "bar",
 			},
 		},
+		{
+			name: "substituting with an expression",
+			inputs: map[string]any{
+				"feed":      "$(lower(trigger.outputs.name) + '-usd')",
+				"count":     "$(len(trigger.outputs.prices) ?? 0)",
+				"threshold": "$(trigger.outputs.threshold ?? 10)",
+				"over":      "$(trigger.outputs.prices.0 > 100)",
+			},
+			state: fakeResults{
+				"trigger": {
+					Outputs: triggerOutputs,
+				},
+			},
+			expected: map[string]any{
+				"feed":      "eth-usd",
+				"count":     int64(2),
+				"threshold": int64(10),
+				"over":      true,
+			},
+		},
+		{
+			name: "keys which are not expressions",
+			inputs: map[string]any{
+				"literal": "$(trigger.outputs.a) and $(trigger.outputs.b)",
+				"key":     "$(trigger.outputs.key:with)",
+			},
+			state: fakeResults{
+				"trigger": {
+					Outputs: keyWithColon,
+				},
+			},
+			expected: map[string]any{
+				"literal": "$(trigger.outputs.a) and $(trigger.outputs.b)",
+				"key":     "ETH",
+			},
+		},
+		{
+			name: "invalid expression",
+			inputs: map[string]any{
+				"foo": "$(trigger.outputs +)",
+			},
+			state:  fakeResults{},
+			errMsg: "invalid expression `trigger.outputs +`",
+		},
 	}
 
 	for _, tc := range testCases {
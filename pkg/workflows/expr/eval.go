@@ -0,0 +1,382 @@
+package expr
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"math/big"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// divisionPrecision is the number of decimal places kept when dividing decimals.
+const divisionPrecision = 18
+
+// Resolver resolves the value of a reference, e.g. step.outputs.value.
+type Resolver func(key string) (any, error)
+
+// UnresolvedError is returned when a reference, or an index into a value, cannot be resolved.
+// The ?? operator falls back to its right hand side on this error.
+type UnresolvedError struct {
+	Err error
+}
+
+func (e *UnresolvedError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *UnresolvedError) Unwrap() error {
+	return e.Err
+}
+
+// Evaluate evaluates the expression, resolving references with resolve.
+//
+// Integers evaluate to int64, or *big.Int if they overflow it, and decimals to decimal.Decimal.
+// Arithmetic mixing integers and decimals or floats is done with decimals, so results do not depend on float rounding.
+func (e *Expression) Evaluate(resolve Resolver) (any, error) {
+	// references are returned as is so that their errors are unchanged
+	if p, ok := e.root.(*pathNode); ok {
+		return resolve(p.key)
+	}
+
+	v, err := eval(e.root, resolve)
+	if err != nil {
+		return nil, fmt.Errorf("cannot evaluate `%s`: %w", e.src, err)
+	}
+	return v, nil
+}
+
+func eval(n node, resolve Resolver) (any, error) {
+	switch n := n.(type) {
+	case *literalNode:
+		return n.value, nil
+	case *pathNode:
+		v, err := resolve(n.key)
+		if err != nil {
+			return nil, &UnresolvedError{Err: err}
+		}
+		return v, nil
+	case *indexNode:
+		return evalIndex(n, resolve)
+	case *unaryNode:
+		return evalUnary(n, resolve)
+	case *binaryNode:
+		return evalBinary(n, resolve)
+	case *callNode:
+		args := make([]any, len(n.args))
+		for i, a := range n.args {
+			v, err := eval(a, resolve)
+			if err != nil {
+				return nil, err
+			}
+			args[i] = v
+		}
+		v, err := functions[n.name](args...)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", n.name, err)
+		}
+		return v, nil
+	default:
+		return nil, fmt.Errorf("unknown node %T", n)
+	}
+}
+
+func evalIndex(n *indexNode, resolve Resolver) (any, error) {
+	target, err := eval(n.target, resolve)
+	if err != nil {
+		return nil, err
+	}
+	index, err := eval(n.index, resolve)
+	if err != nil {
+		return nil, err
+	}
+
+	switch t := target.(type) {
+	case []any:
+		i, ok := toBigInt(index)
+		if !ok {
+			return nil, fmt.Errorf("lists must be indexed with an integer, got %T", index)
+		}
+		if !i.IsInt64() || i.Int64() < 0 || i.Int64() >= int64(len(t)) {
+			return nil, &UnresolvedError{Err: fmt.Errorf("index %s out of bounds for list of length %d", i, len(t))}
+		}
+		return t[i.Int64()], nil
+	case map[string]any:
+		key, ok := index.(string)
+		if !ok {
+			return nil, fmt.Errorf("maps must be indexed with a string, got %T", index)
+		}
+		v, ok := t[key]
+		if !ok {
+			return nil, &UnresolvedError{Err: fmt.Errorf("could not find key `%s`", key)}
+		}
+		return v, nil
+	default:
+		return nil, fmt.Errorf("cannot index into %T", target)
+	}
+}
+
+func evalUnary(n *unaryNode, resolve Resolver) (any, error) {
+	v, err := eval(n.operand, resolve)
+	if err != nil {
+		return nil, err
+	}
+
+	switch n.op {
+	case "!":
+		b, ok := v.(bool)
+		if !ok {
+			return nil, fmt.Errorf("operator ! requires a boolean, got %T", v)
+		}
+		return !b, nil
+	case "-":
+		if i, ok := toBigInt(v); ok {
+			return fromBigInt(new(big.Int).Neg(i)), nil
+		}
+		if d, ok := toDecimal(v); ok {
+			return d.Neg(), nil
+		}
+		return nil, fmt.Errorf("operator - requires a number, got %T", v)
+	default:
+		return nil, fmt.Errorf("unknown operator %s", n.op)
+	}
+}
+
+func evalBinary(n *binaryNode, resolve Resolver) (any, error) {
+	left, err := eval(n.left, resolve)
+
+	switch n.op {
+	case "??":
+		var unresolved *UnresolvedError
+		if errors.As(err, &unresolved) || (err == nil && left == nil) {
+			return eval(n.right, resolve)
+		}
+		return left, err
+	case "&&", "||":
+		if err != nil {
+			return nil, err
+		}
+		l, ok := left.(bool)
+		if !ok {
+			return nil, fmt.Errorf("operator %s requires booleans, got %T", n.op, left)
+		}
+		// short circuit
+		if (n.op == "&&" && !l) || (n.op == "||" && l) {
+			return l, nil
+		}
+		right, err := eval(n.right, resolve)
+		if err != nil {
+			return nil, err
+		}
+		r, ok := right.(bool)
+		if !ok {
+			return nil, fmt.Errorf("operator %s requires booleans, got %T", n.op, right)
+		}
+		return r, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+	right, err := eval(n.right, resolve)
+	if err != nil {
+		return nil, err
+	}
+
+	switch n.op {
+	case "==":
+		return equal(left, right), nil
+	case "!=":
+		return !equal(left, right), nil
+	case "<", "<=", ">", ">=":
+		c, err := compare(left, right)
+		if err != nil {
+			return nil, fmt.Errorf("operator %s: %w", n.op, err)
+		}
+		switch n.op {
+		case "<":
+			return c < 0, nil
+		case "<=":
+			return c <= 0, nil
+		case ">":
+			return c > 0, nil
+		default:
+			return c >= 0, nil
+		}
+	case "+":
+		if l, ok := left.(string); ok {
+			r, ok := right.(string)
+			if !ok {
+				return nil, fmt.Errorf("operator + cannot concatenate a string with %T, use string()", right)
+			}
+			return l + r, nil
+		}
+		return arithmetic(n.op, left, right)
+	default:
+		return arithmetic(n.op, left, right)
+	}
+}
+
+func arithmetic(op string, left, right any) (any, error) {
+	if l, ok := toBigInt(left); ok {
+		if r, ok := toBigInt(right); ok {
+			switch op {
+			case "+":
+				return fromBigInt(new(big.Int).Add(l, r)), nil
+			case "-":
+				return fromBigInt(new(big.Int).Sub(l, r)), nil
+			case "*":
+				return fromBigInt(new(big.Int).Mul(l, r)), nil
+			case "/", "%":
+				if r.Sign() == 0 {
+					return nil, errors.New("division by zero")
+				}
+				if op == "/" {
+					return fromBigInt(new(big.Int).Quo(l, r)), nil
+				}
+				return fromBigInt(new(big.Int).Rem(l, r)), nil
+			}
+		}
+	}
+
+	l, lok := toDecimal(left)
+	r, rok := toDecimal(right)
+	if !lok || !rok {
+		return nil, fmt.Errorf("operator %s requires numbers, got %T and %T", op, left, right)
+	}
+
+	switch op {
+	case "+":
+		return l.Add(r), nil
+	case "-":
+		return l.Sub(r), nil
+	case "*":
+		return l.Mul(r), nil
+	case "/", "%":
+		if r.IsZero() {
+			return nil, errors.New("division by zero")
+		}
+		if op == "/" {
+			return l.DivRound(r, divisionPrecision), nil
+		}
+		return l.Mod(r), nil
+	default:
+		return nil, fmt.Errorf("unknown operator %s", op)
+	}
+}
+
+func equal(left, right any) bool {
+	if left == nil || right == nil {
+		return left == nil && right == nil
+	}
+
+	if c, err := compare(left, right); err == nil {
+		return c == 0
+	}
+
+	switch l := left.(type) {
+	case bool:
+		r, ok := right.(bool)
+		return ok && l == r
+	case []byte:
+		r, ok := right.([]byte)
+		return ok && bytes.Equal(l, r)
+	}
+
+	return reflect.DeepEqual(left, right)
+}
+
+// compare orders numbers, strings and times.
+func compare(left, right any) (int, error) {
+	if l, ok := toBigInt(left); ok {
+		if r, ok := toBigInt(right); ok {
+			return l.Cmp(r), nil
+		}
+	}
+
+	if l, ok := toDecimal(left); ok {
+		if r, ok := toDecimal(right); ok {
+			return l.Cmp(r), nil
+		}
+	}
+
+	switch l := left.(type) {
+	case string:
+		if r, ok := right.(string); ok {
+			return strings.Compare(l, r), nil
+		}
+	case time.Time:
+		if r, ok := right.(time.Time); ok {
+			return l.Compare(r), nil
+		}
+	}
+
+	return 0, fmt.Errorf("cannot compare %T with %T", left, right)
+}
+
+func parseInteger(s string) (node, error) {
+	i, ok := new(big.Int).SetString(s, 10)
+	if !ok {
+		return nil, fmt.Errorf("invalid integer %s", s)
+	}
+	return &literalNode{value: fromBigInt(i)}, nil
+}
+
+func toBigInt(v any) (*big.Int, bool) {
+	switch n := v.(type) {
+	case int:
+		return big.NewInt(int64(n)), true
+	case int8:
+		return big.NewInt(int64(n)), true
+	case int16:
+		return big.NewInt(int64(n)), true
+	case int32:
+		return big.NewInt(int64(n)), true
+	case int64:
+		return big.NewInt(n), true
+	case uint:
+		return new(big.Int).SetUint64(uint64(n)), true
+	case uint8:
+		return new(big.Int).SetUint64(uint64(n)), true
+	case uint16:
+		return new(big.Int).SetUint64(uint64(n)), true
+	case uint32:
+		return new(big.Int).SetUint64(uint64(n)), true
+	case uint64:
+		return new(big.Int).SetUint64(n), true
+	case *big.Int:
+		if n == nil {
+			return nil, false
+		}
+		return n, true
+	default:
+		return nil, false
+	}
+}
+
+func toDecimal(v any) (decimal.Decimal, bool) {
+	if i, ok := toBigInt(v); ok {
+		return decimal.NewFromBigInt(i, 0), true
+	}
+
+	switch n := v.(type) {
+	case decimal.Decimal:
+		return n, true
+	case float32:
+		return decimal.NewFromFloat32(n), true
+	case float64:
+		return decimal.NewFromFloat(n), true
+	default:
+		return decimal.Decimal{}, false
+	}
+}
+
+func fromBigInt(i *big.Int) any {
+	if i.IsInt64() {
+		return i.Int64()
+	}
+	return i
+}
@@ -0,0 +1,212 @@
+package expr_test
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/workflows/expr"
+)
+
+var state = map[string]any{
+	"trigger.outputs": map[string]any{
+		"name":    "Feed",
+		"price":   int64(100),
+		"ratio":   decimal.RequireFromString("1.5"),
+		"big":     new(big.Int).Lsh(big.NewInt(1), 70),
+		"payload": []byte{0xde, 0xad},
+		"list":    []any{int64(1), int64(2), int64(3)},
+		"enabled": true,
+		"nothing": nil,
+	},
+}
+
+// resolve mimics exec.InterpolateKey for a single step.
+func resolve(key string) (any, error) {
+	parts := strings.Split(key, ".")
+	if len(parts) < 2 {
+		return nil, fmt.Errorf("cannot interpolate %s: must have at least two parts", key)
+	}
+
+	v, ok := state[parts[0]+"."+parts[1]]
+	if !ok {
+		return nil, fmt.Errorf("could not find ref `%s`", parts[0])
+	}
+
+	for _, p := range parts[2:] {
+		m, ok := v.(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("could not find ref part `%s`", p)
+		}
+		if v, ok = m[p]; !ok {
+			return nil, fmt.Errorf("could not find ref part `%s`", p)
+		}
+	}
+	return v, nil
+}
+
+func TestEvaluate(t *testing.T) {
+	t.Parallel()
+	testCases := []struct {
+		expression string
+		expected   any
+	}{
+		{expression: "trigger.outputs.price", expected: int64(100)},
+		{expression: "1 + 2 * 3", expected: int64(7)},
+		{expression: "(1 + 2) * 3", expected: int64(9)},
+		{expression: "7 / 2", expected: int64(3)},
+		{expression: "7 % 2", expected: int64(1)},
+		{expression: "-trigger.outputs.price + 1", expected: int64(-99)},
+		{expression: "trigger.outputs.price - 1", expected: int64(99)},
+		{expression: "trigger.outputs.price * trigger.outputs.ratio", expected: decimal.RequireFromString("150")},
+		{expression: "1 / 3.0", expected: decimal.RequireFromString("0.333333333333333333")},
+		{expression: "trigger.outputs.big * 2", expected: new(big.Int).Lsh(big.NewInt(1), 71)},
+		{expression: "9223372036854775807 + 1", expected: new(big.Int).Lsh(big.NewInt(1), 63)},
+		{expression: "trigger.outputs.name + '-' + \"usd\"", expected: "Feed-usd"},
+		{expression: "trigger.outputs.price > 99 && trigger.outputs.enabled", expected: true},
+		{expression: "trigger.outputs.price <= 99 || !trigger.outputs.enabled", expected: false},
+		{expression: "trigger.outputs.ratio == 1.50", expected: true},
+		{expression: "trigger.outputs.name != 'Feed'", expected: false},
+		{expression: "trigger.outputs.nothing == null", expected: true},
+		{expression: "'a' < 'b'", expected: true},
+		{expression: "trigger.outputs.missing ?? 'default'", expected: "default"},
+		{expression: "trigger.outputs.nothing ?? 1", expected: int64(1)},
+		{expression: "trigger.outputs.list[5] ?? 0", expected: int64(0)},
+		{expression: "other.outputs ?? trigger.outputs.price", expected: int64(100)},
+		{expression: "trigger.outputs.price ?? 1", expected: int64(100)},
+		{expression: "trigger.outputs.list[1]", expected: int64(2)},
+		{expression: "trigger.outputs['name']", expected: "Feed"},
+		{expression: "len(trigger.outputs.list)", expected: int64(3)},
+		{expression: "len('héllo')", expected: int64(5)},
+		{expression: "lower(trigger.outputs.name)", expected: "feed"},
+		{expression: "upper(trim('  feed '))", expected: "FEED"},
+		{expression: "hex(trigger.outputs.payload)", expected: "0xdead"},
+		{expression: "hex(255)", expected: "0xff"},
+		{expression: "string(trigger.outputs.ratio) + '/' + string(2)", expected: "1.5/2"},
+		{expression: "int('0x10') + int(2.9)", expected: int64(18)},
+		{expression: "decimal('0.1') + decimal('0.2')", expected: decimal.RequireFromString("0.3")},
+		{expression: "contains(trigger.outputs.list, 2)", expected: true},
+		{expression: "contains(trigger.outputs.name, 'ee')", expected: true},
+		{expression: "contains(trigger.outputs, 'missing')", expected: false},
+		{expression: "join(trigger.outputs.list, ',')", expected: "1,2,3"},
+		{expression: "min(3, trigger.outputs.ratio, 2)", expected: decimal.RequireFromString("1.5")},
+		{expression: "max(3, trigger.outputs.price, 2)", expected: int64(100)},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.expression, func(t *testing.T) {
+			e, err := expr.Parse(tc.expression)
+			require.NoError(t, err)
+
+			got, err := e.Evaluate(resolve)
+			require.NoError(t, err)
+
+			switch expected := tc.expected.(type) {
+			case decimal.Decimal:
+				d, ok := got.(decimal.Decimal)
+				require.True(t, ok, "expected a decimal, got %T", got)
+				assert.True(t, expected.Equal(d), "expected %s, got %s", expected, d)
+			case *big.Int:
+				b, ok := got.(*big.Int)
+				require.True(t, ok, "expected a big int, got %T", got)
+				assert.Equal(t, 0, expected.Cmp(b), "expected %s, got %s", expected, b)
+			default:
+				assert.Equal(t, tc.expected, got)
+			}
+		})
+	}
+}
+
+func TestEvaluate_Errors(t *testing.T) {
+	t.Parallel()
+	testCases := []struct {
+		expression string
+		errMsg     string
+	}{
+		{expression: "trigger.outputs.missing", errMsg: "could not find ref part `missing`"},
+		{expression: "trigger.outputs.missing + 1", errMsg: "cannot evaluate `trigger.outputs.missing + 1`: could not find ref part `missing`"},
+		{expression: "1 / 0", errMsg: "division by zero"},
+		{expression: "1.5 % 0", errMsg: "division by zero"},
+		{expression: "trigger.outputs.name + 1", errMsg: "cannot concatenate a string with int64"},
+		{expression: "trigger.outputs.enabled + 1", errMsg: "operator + requires numbers"},
+		{expression: "trigger.outputs.price && true", errMsg: "operator && requires booleans"},
+		{expression: "!trigger.outputs.price", errMsg: "operator ! requires a boolean"},
+		{expression: "trigger.outputs.name < 1", errMsg: "cannot compare string with int64"},
+		{expression: "trigger.outputs.list['a']", errMsg: "lists must be indexed with an integer"},
+		{expression: "trigger.outputs.list[3]", errMsg: "index 3 out of bounds"},
+		{expression: "len(1)", errMsg: "len: cannot get the length of int64"},
+		{expression: "lower('a', 'b')", errMsg: "lower: expected 1 arguments, got 2"},
+		{expression: "hex(-1)", errMsg: "hex: cannot hex encode a negative integer"},
+		{expression: "int('abc')", errMsg: `int: invalid integer "abc"`},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.expression, func(t *testing.T) {
+			e, err := expr.Parse(tc.expression)
+			require.NoError(t, err)
+
+			_, err = e.Evaluate(resolve)
+			assert.ErrorContains(t, err, tc.errMsg)
+		})
+	}
+
+	t.Run("short circuits", func(t *testing.T) {
+		e, err := expr.Parse("false && trigger.outputs.missing")
+		require.NoError(t, err)
+		got, err := e.Evaluate(resolve)
+		require.NoError(t, err)
+		assert.Equal(t, false, got)
+	})
+
+	t.Run("?? does not hide other errors", func(t *testing.T) {
+		e, err := expr.Parse("(1 / 0) ?? 1")
+		require.NoError(t, err)
+		_, err = e.Evaluate(resolve)
+		assert.ErrorContains(t, err, "division by zero")
+
+		var unresolved *expr.UnresolvedError
+		assert.False(t, errors.As(err, &unresolved))
+	})
+}
+
+func TestParse(t *testing.T) {
+	t.Parallel()
+	t.Run("refs", func(t *testing.T) {
+		e, err := expr.Parse("len(a-step.outputs.list) + b_step.outputs.value[c.outputs.i] ?? 'x'")
+		require.NoError(t, err)
+		assert.False(t, e.IsPath())
+		assert.Equal(t, []string{"a-step.outputs.list", "b_step.outputs.value", "c.outputs.i"}, e.Refs())
+	})
+
+	t.Run("paths", func(t *testing.T) {
+		e, err := expr.Parse("a-step.outputs.list.0.b")
+		require.NoError(t, err)
+		assert.True(t, e.IsPath())
+		assert.Equal(t, []string{"a-step.outputs.list.0.b"}, e.Refs())
+	})
+
+	for _, tc := range []struct {
+		expression string
+		errMsg     string
+	}{
+		{expression: "1 +", errMsg: "unexpected end of expression"},
+		{expression: "(1 + 2", errMsg: "expected `)`, got end of expression"},
+		{expression: "1 2", errMsg: "unexpected `2`"},
+		{expression: "'abc", errMsg: "unterminated string"},
+		{expression: "'\\x'", errMsg: "invalid escape sequence"},
+		{expression: "a.outputs & b", errMsg: "unexpected character '&'"},
+		{expression: "unknown(1)", errMsg: "unknown function unknown"},
+		{expression: "len(1,", errMsg: "unexpected end of expression"},
+	} {
+		t.Run(tc.expression, func(t *testing.T) {
+			_, err := expr.Parse(tc.expression)
+			assert.ErrorContains(t, err, tc.errMsg)
+		})
+	}
+}
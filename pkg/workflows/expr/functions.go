@@ -0,0 +1,239 @@
+package expr
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/big"
+	"slices"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/shopspring/decimal"
+)
+
+type function func(args ...any) (any, error)
+
+// functions are the functions that can be called from expressions:
+//   - len(x): the number of characters in a string, bytes in a byte string, or elements in a list or map
+//   - lower(s), upper(s), trim(s): change the case of a string or trim its surrounding whitespace
+//   - hex(x): the 0x prefixed hex encoding of bytes, of a string's bytes, or of a non-negative integer
+//   - string(x): the string representation of a number, boolean or string
+//   - int(x): an integer parsed from a string, or a number truncated towards zero
+//   - decimal(x): a decimal parsed from a string, or converted from a number
+//   - contains(x, y): whether string x contains y, list x contains an element equal to y, or map x has key y
+//   - join(list, sep): the string representations of the elements of list joined by sep
+//   - min(a, b, ...), max(a, b, ...): the smallest or largest of numbers
+var functions = map[string]function{
+	"len":      fnLen,
+	"lower":    stringFn(strings.ToLower),
+	"upper":    stringFn(strings.ToUpper),
+	"trim":     stringFn(strings.TrimSpace),
+	"hex":      fnHex,
+	"string":   fnString,
+	"int":      fnInt,
+	"decimal":  fnDecimal,
+	"contains": fnContains,
+	"join":     fnJoin,
+	"min":      extremumFn(-1),
+	"max":      extremumFn(1),
+}
+
+func expectArgs(args []any, n int) error {
+	if len(args) != n {
+		return fmt.Errorf("expected %d arguments, got %d", n, len(args))
+	}
+	return nil
+}
+
+func fnLen(args ...any) (any, error) {
+	if err := expectArgs(args, 1); err != nil {
+		return nil, err
+	}
+
+	switch v := args[0].(type) {
+	case string:
+		return int64(utf8.RuneCountInString(v)), nil
+	case []byte:
+		return int64(len(v)), nil
+	case []any:
+		return int64(len(v)), nil
+	case map[string]any:
+		return int64(len(v)), nil
+	default:
+		return nil, fmt.Errorf("cannot get the length of %T", v)
+	}
+}
+
+func stringFn(fn func(string) string) function {
+	return func(args ...any) (any, error) {
+		if err := expectArgs(args, 1); err != nil {
+			return nil, err
+		}
+		s, ok := args[0].(string)
+		if !ok {
+			return nil, fmt.Errorf("expected a string, got %T", args[0])
+		}
+		return fn(s), nil
+	}
+}
+
+func fnHex(args ...any) (any, error) {
+	if err := expectArgs(args, 1); err != nil {
+		return nil, err
+	}
+
+	switch v := args[0].(type) {
+	case []byte:
+		return "0x" + hex.EncodeToString(v), nil
+	case string:
+		return "0x" + hex.EncodeToString([]byte(v)), nil
+	}
+
+	i, ok := toBigInt(args[0])
+	if !ok {
+		return nil, fmt.Errorf("cannot hex encode %T", args[0])
+	}
+	if i.Sign() < 0 {
+		return nil, errors.New("cannot hex encode a negative integer")
+	}
+	return "0x" + i.Text(16), nil
+}
+
+func toString(v any) (string, error) {
+	switch s := v.(type) {
+	case string:
+		return s, nil
+	case bool:
+		if s {
+			return "true", nil
+		}
+		return "false", nil
+	}
+
+	if i, ok := toBigInt(v); ok {
+		return i.String(), nil
+	}
+	if d, ok := toDecimal(v); ok {
+		return d.String(), nil
+	}
+	return "", fmt.Errorf("cannot convert %T to a string", v)
+}
+
+func fnString(args ...any) (any, error) {
+	if err := expectArgs(args, 1); err != nil {
+		return nil, err
+	}
+	return toString(args[0])
+}
+
+func fnInt(args ...any) (any, error) {
+	if err := expectArgs(args, 1); err != nil {
+		return nil, err
+	}
+
+	if s, ok := args[0].(string); ok {
+		i, ok := new(big.Int).SetString(s, 0)
+		if !ok {
+			return nil, fmt.Errorf("invalid integer %q", s)
+		}
+		return fromBigInt(i), nil
+	}
+
+	if i, ok := toBigInt(args[0]); ok {
+		return fromBigInt(i), nil
+	}
+	if d, ok := toDecimal(args[0]); ok {
+		return fromBigInt(d.Truncate(0).BigInt()), nil
+	}
+	return nil, fmt.Errorf("cannot convert %T to an integer", args[0])
+}
+
+func fnDecimal(args ...any) (any, error) {
+	if err := expectArgs(args, 1); err != nil {
+		return nil, err
+	}
+
+	if s, ok := args[0].(string); ok {
+		return decimal.NewFromString(s)
+	}
+	if d, ok := toDecimal(args[0]); ok {
+		return d, nil
+	}
+	return nil, fmt.Errorf("cannot convert %T to a decimal", args[0])
+}
+
+func fnContains(args ...any) (any, error) {
+	if err := expectArgs(args, 2); err != nil {
+		return nil, err
+	}
+
+	switch v := args[0].(type) {
+	case string:
+		sub, ok := args[1].(string)
+		if !ok {
+			return nil, fmt.Errorf("a string can only contain a string, got %T", args[1])
+		}
+		return strings.Contains(v, sub), nil
+	case []any:
+		return slices.ContainsFunc(v, func(el any) bool { return equal(el, args[1]) }), nil
+	case map[string]any:
+		key, ok := args[1].(string)
+		if !ok {
+			return nil, fmt.Errorf("map keys are strings, got %T", args[1])
+		}
+		_, ok = v[key]
+		return ok, nil
+	default:
+		return nil, fmt.Errorf("cannot look for an element in %T", v)
+	}
+}
+
+func fnJoin(args ...any) (any, error) {
+	if err := expectArgs(args, 2); err != nil {
+		return nil, err
+	}
+
+	list, ok := args[0].([]any)
+	if !ok {
+		return nil, fmt.Errorf("expected a list, got %T", args[0])
+	}
+	sep, ok := args[1].(string)
+	if !ok {
+		return nil, fmt.Errorf("expected a string separator, got %T", args[1])
+	}
+
+	elements := make([]string, len(list))
+	for i, el := range list {
+		s, err := toString(el)
+		if err != nil {
+			return nil, fmt.Errorf("element %d: %w", i, err)
+		}
+		elements[i] = s
+	}
+	return strings.Join(elements, sep), nil
+}
+
+// extremumFn returns the function that finds the number for which compare(number, others) == sign.
+func extremumFn(sign int) function {
+	return func(args ...any) (any, error) {
+		if len(args) == 0 {
+			return nil, errors.New("expected at least one argument")
+		}
+
+		result := args[0]
+		for _, arg := range args {
+			c, err := compare(arg, result)
+			if err != nil {
+				return nil, err
+			}
+			if _, ok := toDecimal(arg); !ok {
+				return nil, fmt.Errorf("expected numbers, got %T", arg)
+			}
+			if c == sign {
+				result = arg
+			}
+		}
+		return result, nil
+	}
+}
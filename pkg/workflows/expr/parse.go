@@ -0,0 +1,428 @@
+// Package expr implements the expression language used within workflow interpolation tokens, as in $(expression).
+//
+// An expression is evaluated deterministically, it supports:
+//   - references to step state, as in step.outputs.a.0.b or step.outputs.list[0]
+//   - integer, decimal, string, boolean and null literals, e.g. 1, 1.5, 'a', "b", true, null
+//   - arithmetic with + - * / %, where + also concatenates strings
+//   - comparisons with == != < <= > >=, and boolean logic with && || !
+//   - default values with ??, where a ?? b evaluates to b if a cannot be resolved or is null
+//   - calls to built-in functions, e.g. len, lower, upper, hex, see functions.go
+//
+// Since step refs may contain hyphens, subtraction must be surrounded by whitespace: a - b.
+package expr
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/shopspring/decimal"
+)
+
+// Expression is a parsed expression.
+type Expression struct {
+	src  string
+	root node
+}
+
+// pathRe matches expressions that are a single dotted reference, which are resolved as is.
+var pathRe = regexp.MustCompile(`^[A-Za-z0-9_-]+(\.[A-Za-z0-9_-]+)*$`)
+
+// Parse parses an expression.
+func Parse(src string) (*Expression, error) {
+	if pathRe.MatchString(src) {
+		return &Expression{src: src, root: &pathNode{key: src}}, nil
+	}
+
+	tokens, err := lex(src)
+	if err != nil {
+		return nil, fmt.Errorf("invalid expression `%s`: %w", src, err)
+	}
+
+	p := &parser{tokens: tokens}
+	root, err := p.parseExpression()
+	if err == nil && p.peek().kind != tokenEOF {
+		err = fmt.Errorf("unexpected %s", p.peek())
+	}
+	if err != nil {
+		return nil, fmt.Errorf("invalid expression `%s`: %w", src, err)
+	}
+
+	return &Expression{src: src, root: root}, nil
+}
+
+// IsPath returns true if the expression is a single reference, e.g. step.outputs.value.
+func (e *Expression) IsPath() bool {
+	_, ok := e.root.(*pathNode)
+	return ok
+}
+
+// Refs returns the keys of all references in the expression, e.g. step.outputs.value.
+func (e *Expression) Refs() []string {
+	var refs []string
+	walk(e.root, func(n node) {
+		if p, ok := n.(*pathNode); ok {
+			refs = append(refs, p.key)
+		}
+	})
+	return refs
+}
+
+func (e *Expression) String() string {
+	return e.src
+}
+
+type node interface {
+	children() []node
+}
+
+type literalNode struct{ value any }
+
+type pathNode struct{ key string }
+
+type indexNode struct{ target, index node }
+
+type unaryNode struct {
+	op      string
+	operand node
+}
+
+type binaryNode struct {
+	op          string
+	left, right node
+}
+
+type callNode struct {
+	name string
+	args []node
+}
+
+func (n *literalNode) children() []node { return nil }
+func (n *pathNode) children() []node    { return nil }
+func (n *indexNode) children() []node   { return []node{n.target, n.index} }
+func (n *unaryNode) children() []node   { return []node{n.operand} }
+func (n *binaryNode) children() []node  { return []node{n.left, n.right} }
+func (n *callNode) children() []node    { return n.args }
+
+func walk(n node, fn func(node)) {
+	fn(n)
+	for _, c := range n.children() {
+		walk(c, fn)
+	}
+}
+
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenPath
+	tokenNumber
+	tokenString
+	tokenOperator
+)
+
+type token struct {
+	kind  tokenKind
+	value string
+}
+
+func (t token) String() string {
+	if t.kind == tokenEOF {
+		return "end of expression"
+	}
+	return fmt.Sprintf("`%s`", t.value)
+}
+
+// operators are ordered so that longer operators are matched first.
+var operators = []string{"??", "==", "!=", "<=", ">=", "&&", "||", "+", "-", "*", "/", "%", "<", ">", "!", "(", ")", "[", "]", ","}
+
+func isIdentStart(c byte) bool {
+	return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+func isIdentChar(c byte) bool {
+	return isIdentStart(c) || (c >= '0' && c <= '9')
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+func lex(src string) ([]token, error) {
+	var tokens []token
+	for i := 0; i < len(src); {
+		c := src[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			i++
+		case isIdentStart(c):
+			n := lexPath(src[i:])
+			tokens = append(tokens, token{kind: tokenPath, value: src[i : i+n]})
+			i += n
+		case isDigit(c):
+			start := i
+			for i < len(src) && isDigit(src[i]) {
+				i++
+			}
+			if i+1 < len(src) && src[i] == '.' && isDigit(src[i+1]) {
+				i++
+				for i < len(src) && isDigit(src[i]) {
+					i++
+				}
+			}
+			tokens = append(tokens, token{kind: tokenNumber, value: src[start:i]})
+		case c == '\'' || c == '"':
+			s, n, err := lexString(src[i:])
+			if err != nil {
+				return nil, err
+			}
+			tokens = append(tokens, token{kind: tokenString, value: s})
+			i += n
+		default:
+			op := lexOperator(src[i:])
+			if op == "" {
+				return nil, fmt.Errorf("unexpected character %q at position %d", c, i)
+			}
+			tokens = append(tokens, token{kind: tokenOperator, value: op})
+			i += len(op)
+		}
+	}
+	return append(tokens, token{kind: tokenEOF}), nil
+}
+
+// lexPath returns the length of the path at the start of src.
+// A path is an identifier followed by dot separated segments,
+// hyphens are part of the path as long as they are followed by an identifier character.
+func lexPath(src string) int {
+	i := 0
+	for i < len(src) {
+		switch {
+		case isIdentChar(src[i]):
+			i++
+		case (src[i] == '-' || src[i] == '.') && i+1 < len(src) && isIdentChar(src[i+1]):
+			i++
+		default:
+			return i
+		}
+	}
+	return i
+}
+
+// lexOperator returns the operator at the start of src, or an empty string if there is none.
+func lexOperator(src string) string {
+	for _, op := range operators {
+		if strings.HasPrefix(src, op) {
+			return op
+		}
+	}
+	return ""
+}
+
+// lexString reads a quoted string at the start of src, returning its value and the number of bytes read.
+func lexString(src string) (string, int, error) {
+	quote := src[0]
+	var sb strings.Builder
+	for i := 1; i < len(src); i++ {
+		c := src[i]
+		switch {
+		case c == quote:
+			return sb.String(), i + 1, nil
+		case c == '\\':
+			i++
+			if i == len(src) {
+				return "", 0, errors.New("unterminated string")
+			}
+			switch src[i] {
+			case 'n':
+				sb.WriteByte('\n')
+			case 't':
+				sb.WriteByte('\t')
+			case '\\', '\'', '"':
+				sb.WriteByte(src[i])
+			default:
+				return "", 0, fmt.Errorf("invalid escape sequence \\%c", src[i])
+			}
+		default:
+			sb.WriteByte(c)
+		}
+	}
+	return "", 0, errors.New("unterminated string")
+}
+
+type parser struct {
+	tokens []token
+	pos    int
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.pos]
+}
+
+func (p *parser) next() token {
+	t := p.tokens[p.pos]
+	if t.kind != tokenEOF {
+		p.pos++
+	}
+	return t
+}
+
+func (p *parser) acceptOperator(ops ...string) (string, bool) {
+	t := p.peek()
+	if t.kind != tokenOperator {
+		return "", false
+	}
+	for _, op := range ops {
+		if t.value == op {
+			p.pos++
+			return op, true
+		}
+	}
+	return "", false
+}
+
+func (p *parser) expectOperator(op string) error {
+	if _, ok := p.acceptOperator(op); !ok {
+		return fmt.Errorf("expected `%s`, got %s", op, p.peek())
+	}
+	return nil
+}
+
+// binaryLevels lists the binary operators from the lowest to the highest precedence.
+var binaryLevels = [][]string{
+	{"??"},
+	{"||"},
+	{"&&"},
+	{"==", "!="},
+	{"<", "<=", ">", ">="},
+	{"+", "-"},
+	{"*", "/", "%"},
+}
+
+func (p *parser) parseExpression() (node, error) {
+	return p.parseBinary(0)
+}
+
+func (p *parser) parseBinary(level int) (node, error) {
+	if level == len(binaryLevels) {
+		return p.parseUnary()
+	}
+
+	left, err := p.parseBinary(level + 1)
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		op, ok := p.acceptOperator(binaryLevels[level]...)
+		if !ok {
+			return left, nil
+		}
+
+		right, err := p.parseBinary(level + 1)
+		if err != nil {
+			return nil, err
+		}
+		left = &binaryNode{op: op, left: left, right: right}
+	}
+}
+
+func (p *parser) parseUnary() (node, error) {
+	if op, ok := p.acceptOperator("!", "-"); ok {
+		operand, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return &unaryNode{op: op, operand: operand}, nil
+	}
+	return p.parsePostfix()
+}
+
+func (p *parser) parsePostfix() (node, error) {
+	n, err := p.parsePrimary()
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		if _, ok := p.acceptOperator("["); !ok {
+			return n, nil
+		}
+
+		index, err := p.parseExpression()
+		if err != nil {
+			return nil, err
+		}
+		if err = p.expectOperator("]"); err != nil {
+			return nil, err
+		}
+		n = &indexNode{target: n, index: index}
+	}
+}
+
+func (p *parser) parsePrimary() (node, error) {
+	t := p.next()
+	switch t.kind {
+	case tokenNumber:
+		if strings.Contains(t.value, ".") {
+			d, err := decimal.NewFromString(t.value)
+			if err != nil {
+				return nil, err
+			}
+			return &literalNode{value: d}, nil
+		}
+		return parseInteger(t.value)
+	case tokenString:
+		return &literalNode{value: t.value}, nil
+	case tokenPath:
+		switch t.value {
+		case "true":
+			return &literalNode{value: true}, nil
+		case "false":
+			return &literalNode{value: false}, nil
+		case "null":
+			return &literalNode{value: nil}, nil
+		}
+
+		if _, ok := p.acceptOperator("("); ok {
+			return p.parseCall(t.value)
+		}
+		return &pathNode{key: t.value}, nil
+	case tokenOperator:
+		if t.value == "(" {
+			n, err := p.parseExpression()
+			if err != nil {
+				return nil, err
+			}
+			return n, p.expectOperator(")")
+		}
+	case tokenEOF:
+	}
+	return nil, fmt.Errorf("unexpected %s", t)
+}
+
+func (p *parser) parseCall(name string) (node, error) {
+	if _, ok := functions[name]; !ok {
+		return nil, fmt.Errorf("unknown function %s", name)
+	}
+
+	call := &callNode{name: name}
+	if _, ok := p.acceptOperator(")"); ok {
+		return call, nil
+	}
+
+	for {
+		arg, err := p.parseExpression()
+		if err != nil {
+			return nil, err
+		}
+		call.args = append(call.args, arg)
+
+		if _, ok := p.acceptOperator(")"); ok {
+			return call, nil
+		}
+		if err = p.expectOperator(","); err != nil {
+			return nil, err
+		}
+	}
+}
@@ -354,12 +354,93 @@ type stepDefinitionYaml struct {
 	//        method: "updateFeedValues(report bytes, role uint8)"
 	//        params: [$(inputs.report), 1]
 	Config Mapping `json:"config" jsonschema:"required"`
+
+	// Capabilities can specify an optional “forEach” property to be executed once per element of a list output.
+	// The element a capability is executed for is referenced with $(item.outputs) from within the “inputs” property,
+	// and the outputs of the step are the list of outputs of each execution.
+	//
+	// Example
+	//  actions:
+	//    - id: read_chain@1.0.0
+	//      ref: read_balances
+	//      forEach:
+	//        items: $(trigger.outputs.accounts)
+	//        maxItems: 10
+	//      inputs:
+	//        account: $(item.outputs.address)
+	//      config: {}
+	ForEach *forEachDefinitionYaml `json:"forEach,omitempty"`
+}
+
+// forEachDefinitionYaml is the YAML representation of sdk.ForEach.
+type forEachDefinitionYaml struct {
+	// Items references the list the step is executed over, it must be of the form $(<ref>.outputs.<path>).
+	Items string `json:"items" jsonschema:"required,pattern=^\\$\\([a-z0-9_-]+\\.outputs(\\.\\S+)?\\)$"`
+	// MaxItems bounds the length of the list, the step fails if the list is longer.
+	MaxItems int `json:"maxItems" jsonschema:"required,minimum=1,maximum=100"`
+}
+
+// JSONSchemaExtend adds the constraints of the built-in control steps to the schema of a step.
+//
+// An “if” step routes its “value” input to its “then” or “else” branch, depending on its “condition” input.
+// A “switch” step routes its “value” input to the branch named after the case in “cases” it matches, or to its “default” branch.
+// Steps reference a branch with $(<ref>.outputs.<branch>) and are only executed if that branch is taken.
+//
+// Example
+//
+//	actions:
+//	  - id: if@1.0.0
+//	    ref: is_large
+//	    inputs:
+//	      condition: $(trigger.outputs.large)
+//	      value: $(trigger.outputs)
+//	    config: {}
+//	  - id: switch@1.0.0
+//	    ref: by_chain
+//	    inputs:
+//	      value: $(trigger.outputs.chain)
+//	    config:
+//	      cases: [ethereum, polygon]
+func (stepDefinitionYaml) JSONSchemaExtend(schema *jsonschema.Schema) {
+	schema.AllOf = append(schema.AllOf,
+		controlStepSchema(sdk.IfStepID, []string{sdk.IfConditionInput}, nil),
+		controlStepSchema(sdk.SwitchStepID, []string{sdk.ControlValueInput}, []string{sdk.SwitchCasesConfig}),
+	)
+}
+
+func controlStepSchema(id string, requiredInputs, requiredConfig []string) *jsonschema.Schema {
+	idProperty := jsonschema.NewProperties()
+	idProperty.Set("id", &jsonschema.Schema{Const: id})
+
+	then := jsonschema.NewProperties()
+	then.Set("inputs", &jsonschema.Schema{Type: "object", Required: requiredInputs})
+	if len(requiredConfig) > 0 {
+		one := uint64(1)
+		config := jsonschema.NewProperties()
+		config.Set(sdk.SwitchCasesConfig, &jsonschema.Schema{
+			Type:        "array",
+			MinItems:    &one,
+			UniqueItems: true,
+			Items:       &jsonschema.Schema{Type: "string", MinLength: &one, Not: &jsonschema.Schema{Const: sdk.SwitchDefaultBranch}},
+		})
+		then.Set("config", &jsonschema.Schema{Type: "object", Required: requiredConfig, Properties: config})
+	}
+
+	return &jsonschema.Schema{
+		If:   &jsonschema.Schema{Properties: idProperty, Required: []string{"id"}},
+		Then: &jsonschema.Schema{Properties: then},
+	}
 }
 
 // toStepDefinition converts a stepDefinitionYaml to a sdk.StepDefinition.
 //
 // `sdk.StepDefinition` is the converged representation of a step in a workflow.
 func (s stepDefinitionYaml) toStepDefinition() sdk.StepDefinition {
+	var forEach *sdk.ForEach
+	if s.ForEach != nil {
+		forEach = &sdk.ForEach{Items: s.ForEach.Items, MaxItems: s.ForEach.MaxItems}
+	}
+
 	return sdk.StepDefinition{
 		Ref: s.Ref,
 		ID:  s.ID.String(),
@@ -367,7 +448,8 @@ func (s stepDefinitionYaml) toStepDefinition() sdk.StepDefinition {
 			OutputRef: s.Inputs.outputRef,
 			Mapping:   s.Inputs.mapping,
 		},
-		Config: s.Config,
+		Config:  s.Config,
+		ForEach: forEach,
 	}
 }
 
@@ -18,6 +18,7 @@ type WorkflowSpecFactory struct {
 	badCapTypes    []string
 	errors         []error
 	fns            map[string]func(runtime Runtime, request capabilities.CapabilityRequest) (capabilities.CapabilityResponse, error)
+	lastStep       *StepDefinition
 }
 
 func (w *WorkflowSpecFactory) GetFn(name string) func(sdk Runtime, request capabilities.CapabilityRequest) (capabilities.CapabilityResponse, error) {
@@ -59,6 +60,12 @@ func ConstantDefinition[O any](o O) CapDefinition[O] {
 	return &capDefinitionImpl[O]{ref: o}
 }
 
+// Expression references the result of an expression evaluated when the workflow runs,
+// e.g. Expression[int64]("len(trigger.outputs.list) * 2"), see package expr for the syntax.
+func Expression[O any](expression string) CapDefinition[O] {
+	return &capDefinitionImpl[O]{ref: fmt.Sprintf("$(%s)", expression)}
+}
+
 func ToListDefinition[O any](c CapDefinition[[]O]) CapListDefinition[O] {
 	if list, ok := c.(CapListDefinition[O]); ok {
 		return list
@@ -157,12 +164,16 @@ func (step *Step[O]) AddTo(w *WorkflowSpecFactory) CapDefinition[O] {
 	switch stepDefinition.CapabilityType {
 	case capabilities.CapabilityTypeTrigger:
 		w.spec.Triggers = append(w.spec.Triggers, stepDefinition)
+		w.lastStep = &w.spec.Triggers[len(w.spec.Triggers)-1]
 	case capabilities.CapabilityTypeAction:
 		w.spec.Actions = append(w.spec.Actions, stepDefinition)
+		w.lastStep = &w.spec.Actions[len(w.spec.Actions)-1]
 	case capabilities.CapabilityTypeConsensus:
 		w.spec.Consensus = append(w.spec.Consensus, stepDefinition)
+		w.lastStep = &w.spec.Consensus[len(w.spec.Consensus)-1]
 	case capabilities.CapabilityTypeTarget:
 		w.spec.Targets = append(w.spec.Targets, stepDefinition)
+		w.lastStep = &w.spec.Targets[len(w.spec.Targets)-1]
 	default:
 		w.badCapTypes = append(w.badCapTypes, stepDefinition.ID)
 	}
@@ -176,6 +187,10 @@ func AccessField[I, O any](c CapDefinition[I], fieldName string) CapDefinition[O
 	return &capDefinitionImpl[O]{ref: originalRef[:len(originalRef)-1] + "." + fieldName + ")"}
 }
 
+func (w *WorkflowSpecFactory) stepCount() int {
+	return len(w.spec.Triggers) + len(w.spec.Actions) + len(w.spec.Consensus) + len(w.spec.Targets)
+}
+
 func (w *WorkflowSpecFactory) AddErr(err error) {
 	w.errors = append(w.errors, err)
 }
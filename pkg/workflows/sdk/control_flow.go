@@ -0,0 +1,246 @@
+package sdk
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/capabilities"
+)
+
+const (
+	// IfStepID is the ID of the built-in step that routes its value to the "then" or "else" branch
+	// depending on its boolean condition input.
+	IfStepID = "if@1.0.0"
+	// SwitchStepID is the ID of the built-in step that routes its value to the branch
+	// named after the case it matches, or to the "default" branch if it matches none.
+	SwitchStepID = "switch@1.0.0"
+
+	IfThenBranch        = "then"
+	IfElseBranch        = "else"
+	SwitchDefaultBranch = "default"
+
+	// IfConditionInput, ControlValueInput and SwitchCasesConfig are the keys used by control steps
+	// in their inputs and config.
+	IfConditionInput  = "condition"
+	ControlValueInput = "value"
+	SwitchCasesConfig = "cases"
+
+	// KeywordItem is the ref used by a forEach step to reference the list item it is executed for,
+	// as in $(item.outputs) or $(item.outputs.field).
+	KeywordItem = "item"
+
+	// MaxForEachItems is the upper bound for ForEach.MaxItems.
+	MaxForEachItems = 100
+)
+
+// ForEach fans a step out over a list, executing its capability once per item.
+// The outputs of the step are the list of outputs of each execution, in the order of the items.
+type ForEach struct {
+	// Items is a reference to the list to iterate over, e.g. $(step.outputs.list).
+	Items string
+	// MaxItems bounds the length of the list, executing the step fails if the list is longer.
+	MaxItems int
+}
+
+// IsControlStep returns true if the step is a built-in if or switch step,
+// which route execution instead of calling a capability.
+func (s StepDefinition) IsControlStep() bool {
+	return s.ID == IfStepID || s.ID == SwitchStepID
+}
+
+// Branches returns the names of the branches a control step can route to.
+// It returns nil for steps that are not control steps.
+func (s StepDefinition) Branches() ([]string, error) {
+	switch s.ID {
+	case IfStepID:
+		return []string{IfThenBranch, IfElseBranch}, nil
+	case SwitchStepID:
+		cases, err := SwitchCases(s.Config)
+		if err != nil {
+			return nil, fmt.Errorf("invalid switch step %s: %w", s.Ref, err)
+		}
+		return append(cases, SwitchDefaultBranch), nil
+	default:
+		return nil, nil
+	}
+}
+
+// SwitchCases reads the cases out of the config of a switch step.
+func SwitchCases(config map[string]any) ([]string, error) {
+	var raw []any
+	switch c := config[SwitchCasesConfig].(type) {
+	case []any:
+		raw = c
+	case []string:
+		for _, s := range c {
+			raw = append(raw, s)
+		}
+	default:
+		return nil, fmt.Errorf("%s must be a list of strings, got %T", SwitchCasesConfig, c)
+	}
+
+	if len(raw) == 0 {
+		return nil, fmt.Errorf("%s cannot be empty", SwitchCasesConfig)
+	}
+
+	cases := make([]string, 0, len(raw))
+	for _, r := range raw {
+		c, ok := r.(string)
+		if !ok {
+			return nil, fmt.Errorf("%s must be a list of strings, got element of type %T", SwitchCasesConfig, r)
+		}
+		if c == "" || c == SwitchDefaultBranch {
+			return nil, fmt.Errorf("invalid case %q", c)
+		}
+		if slices.Contains(cases, c) {
+			return nil, fmt.Errorf("duplicate case %q", c)
+		}
+		cases = append(cases, c)
+	}
+	return cases, nil
+}
+
+type IfCap[O any] interface {
+	// Then is the value of the if step, only available when the condition is true.
+	Then() CapDefinition[O]
+	// Else is the value of the if step, only available when the condition is false.
+	Else() CapDefinition[O]
+}
+
+type SwitchCap[O any] interface {
+	// Case is the value of the switch step, only available when it matches the named case.
+	Case(name string) CapDefinition[O]
+	// Default is the value of the switch step, only available when it matches none of the cases.
+	Default() CapDefinition[O]
+}
+
+type ifCap[O any] struct {
+	CapDefinition[map[string]O]
+}
+
+func (c *ifCap[O]) Then() CapDefinition[O] {
+	return AccessField[map[string]O, O](c.CapDefinition, IfThenBranch)
+}
+
+func (c *ifCap[O]) Else() CapDefinition[O] {
+	return AccessField[map[string]O, O](c.CapDefinition, IfElseBranch)
+}
+
+type switchCap[O any] struct {
+	CapDefinition[map[string]O]
+	w     *WorkflowSpecFactory
+	ref   string
+	cases []string
+}
+
+func (c *switchCap[O]) Case(name string) CapDefinition[O] {
+	if !slices.Contains(c.cases, name) {
+		c.w.AddErr(fmt.Errorf("switch step %s has no case %q", c.ref, name))
+	}
+	return AccessField[map[string]O, O](c.CapDefinition, name)
+}
+
+func (c *switchCap[O]) Default() CapDefinition[O] {
+	return AccessField[map[string]O, O](c.CapDefinition, SwitchDefaultBranch)
+}
+
+// If adds an if step that routes value to its Then or Else branch depending on condition.
+// Steps that use a branch are only executed if that branch is taken.
+func If[O any](w *WorkflowSpecFactory, ref string, condition CapDefinition[bool], value CapDefinition[O]) IfCap[O] {
+	step := &Step[map[string]O]{
+		Definition: StepDefinition{
+			ID:  IfStepID,
+			Ref: ref,
+			Inputs: StepInputs{
+				Mapping: map[string]any{
+					IfConditionInput:  condition.Ref(),
+					ControlValueInput: value.Ref(),
+				},
+			},
+			Config:         map[string]any{},
+			CapabilityType: capabilities.CapabilityTypeAction,
+		},
+	}
+	return &ifCap[O]{CapDefinition: step.AddTo(w)}
+}
+
+// Switch adds a switch step that routes value to the branch of the case it is equal to, or to its Default branch.
+// Values are compared to cases by their string representation.
+// Steps that use a branch are only executed if that branch is taken.
+func Switch[O any](w *WorkflowSpecFactory, ref string, value CapDefinition[O], cases ...string) SwitchCap[O] {
+	rawCases := make([]any, len(cases))
+	for i, c := range cases {
+		rawCases[i] = c
+	}
+
+	step := &Step[map[string]O]{
+		Definition: StepDefinition{
+			ID:  SwitchStepID,
+			Ref: ref,
+			Inputs: StepInputs{
+				Mapping: map[string]any{
+					ControlValueInput: value.Ref(),
+				},
+			},
+			Config:         map[string]any{SwitchCasesConfig: rawCases},
+			CapabilityType: capabilities.CapabilityTypeAction,
+		},
+	}
+
+	if _, err := SwitchCases(step.Definition.Config); err != nil {
+		w.AddErr(fmt.Errorf("invalid switch step %s: %w", ref, err))
+	}
+
+	return &switchCap[O]{CapDefinition: step.AddTo(w), w: w, ref: ref, cases: cases}
+}
+
+// ForEachItem adds the step created by build as a forEach step, executing it once per element of items.
+// build must add exactly one step to the workflow, using item as the current element of the list,
+// and return the outputs of that step. The returned list holds the outputs of each execution.
+func ForEachItem[I, O any](w *WorkflowSpecFactory, items CapDefinition[[]I], maxItems int, build func(item CapDefinition[I]) CapDefinition[O]) CapListDefinition[O] {
+	itemsRef, ok := items.Ref().(string)
+	if !ok {
+		return forEachErr[O](w, errors.New("forEach items must reference the output of a step"))
+	}
+
+	if maxItems < 1 || maxItems > MaxForEachItems {
+		return forEachErr[O](w, fmt.Errorf("forEach max items must be between 1 and %d, got %d", MaxForEachItems, maxItems))
+	}
+
+	before := w.stepCount()
+	build(&capDefinitionImpl[I]{ref: fmt.Sprintf("$(%s.outputs)", KeywordItem)})
+	if w.stepCount() != before+1 || w.lastStep == nil {
+		return forEachErr[O](w, errors.New("forEach must add exactly one step"))
+	}
+
+	w.lastStep.ForEach = &ForEach{Items: itemsRef, MaxItems: maxItems}
+	return ToListDefinition[O](&capDefinitionImpl[[]O]{ref: fmt.Sprintf("$(%s.outputs)", w.lastStep.Ref)})
+}
+
+// forEachErr adds err to w and returns a list that carries err in place of its references,
+// so the caller can keep building the workflow, whose Spec returns err.
+func forEachErr[O any](w *WorkflowSpecFactory, err error) CapListDefinition[O] {
+	w.AddErr(err)
+	return &errCapList[O]{err: err}
+}
+
+type errCapList[O any] struct {
+	err error
+}
+
+func (c *errCapList[O]) Index(int) CapDefinition[O] {
+	return &capDefinitionImpl[O]{ref: c.err}
+}
+
+func (c *errCapList[O]) Ref() any {
+	return c.err
+}
+
+func (c *errCapList[O]) private() {}
+
+// self is required to implement CapDefinition, complication fails without it, false positive.
+// nolint
+func (c *errCapList[O]) self() CapDefinition[[]O] {
+	return c
+}
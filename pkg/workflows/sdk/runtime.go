@@ -1,6 +1,8 @@
 package sdk
 
 import (
+	"time"
+
 	"github.com/smartcontractkit/chainlink-common/pkg/capabilities"
 	"github.com/smartcontractkit/chainlink-common/pkg/logger"
 )
@@ -23,6 +25,17 @@ type Runtime interface {
 
 	// Emitter sends the given message and labels to the configured collector.
 	Emitter() MessageEmitter
+
+	// GetState returns the value stored under key by a previous execution of the workflow, or nil if there is none.
+	// State is namespaced per workflow, so workflows cannot read each other's state.
+	GetState(key string) ([]byte, error)
+
+	// SetState stores value under key, making it available to later executions of the workflow.
+	SetState(key string, value []byte) error
+
+	// Now returns the time of the request being executed, e.g. the trigger's timestamp, so that it is the same
+	// on every node executing the request. If the request has no time, Now returns the current time.
+	Now() time.Time
 }
 
 type FetchRequest struct {
@@ -19,8 +19,10 @@ func NewRunner(ctx context.Context) *Runner {
 		ctx:          ctx,
 		registry:     map[string]capabilities.ExecutableCapability{},
 		results:      runnerResults{},
+		skipped:      map[string]bool{},
 		idToStep:     map[string]sdk.StepDefinition{},
 		dependencies: map[string][]string{},
+		branches:     map[string][]workflows.BranchRef{},
 		runtime:      &NoopRuntime{},
 	}
 }
@@ -36,8 +38,10 @@ type Runner struct {
 	registry     map[string]capabilities.ExecutableCapability
 	am           map[string]map[string]graph.Edge[string]
 	results      runnerResults
+	skipped      map[string]bool
 	idToStep     map[string]sdk.StepDefinition
 	dependencies map[string][]string
+	branches     map[string][]workflows.BranchRef
 	runtime      sdk.Runtime
 	errors       []error
 }
@@ -93,6 +97,18 @@ func (r *Runner) ensureGraph(spec sdk.WorkflowSpec) error {
 		r.dependencies[edge.Target] = append(r.dependencies[edge.Target], edge.Source)
 	}
 
+	for _, step := range spec.Steps() {
+		if step.Ref == "" {
+			step.Ref = step.ID
+		}
+
+		vertex, err := g.Vertex(step.Ref)
+		if err != nil {
+			return err
+		}
+		r.branches[step.Ref] = vertex.Branches
+	}
+
 	r.am, err = g.AdjacencyMap()
 	return err
 }
@@ -159,35 +175,109 @@ func (r *Runner) GetRegisteredMock(name string, step string) capabilities.Action
 
 func (r *Runner) walk(spec sdk.WorkflowSpec, ref string) error {
 	capability := r.idToStep[ref]
-	mock := r.GetRegisteredMock(capability.ID, ref)
+
+	// Steps on a branch that was not taken, and the steps that depend on them, are skipped.
+	if r.shouldSkip(ref) {
+		r.skipped[ref] = true
+	} else {
+		result, err := r.execute(spec, capability)
+		if err != nil {
+			return err
+		}
+		r.results[ref] = result
+	}
+
+	edges, ok := r.am[ref]
+	if !ok {
+		return nil
+	}
+
+	return r.walkNext(spec, edges)
+}
+
+func (r *Runner) execute(spec sdk.WorkflowSpec, capability sdk.StepDefinition) (*exec.Result, error) {
+	if capability.IsControlStep() {
+		request, err := r.buildRequest(spec, capability, r.results)
+		if err != nil {
+			return nil, err
+		}
+
+		outputs, err := exec.EvaluateControlStep(capability, request.Inputs)
+		if err != nil {
+			return nil, err
+		}
+
+		return &exec.Result{Inputs: request.Inputs, Outputs: outputs}, nil
+	}
+
+	mock := r.GetRegisteredMock(capability.ID, capability.Ref)
 	if mock == nil {
-		return fmt.Errorf("no mock found for capability %s on step %s", capability.ID, ref)
+		return nil, fmt.Errorf("no mock found for capability %s on step %s", capability.ID, capability.Ref)
+	}
+
+	if capability.ForEach != nil {
+		return r.executeForEach(spec, capability, mock)
 	}
 
-	request, err := r.buildRequest(spec, capability)
+	request, err := r.buildRequest(spec, capability, r.results)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	results, err := mock.Execute(r.ctx, request)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	r.results[ref] = &exec.Result{
+	return &exec.Result{
 		Inputs:  request.Inputs,
 		Outputs: results.Value,
+	}, nil
+}
+
+// executeForEach executes the capability once per item, the inputs and outputs of the step are lists
+// with the inputs and outputs of each execution.
+func (r *Runner) executeForEach(spec sdk.WorkflowSpec, capability sdk.StepDefinition, mock capabilities.ExecutableCapability) (*exec.Result, error) {
+	items, err := exec.FindAndInterpolateAllKeys(capability.ForEach.Items, r.results)
+	if err != nil {
+		return nil, err
 	}
 
-	edges, ok := r.am[ref]
+	list, ok := items.([]any)
 	if !ok {
-		return nil
+		return nil, fmt.Errorf("forEach items of step %s must be a list, got %T", capability.Ref, items)
 	}
 
-	return r.walkNext(spec, edges)
+	if len(list) > capability.ForEach.MaxItems {
+		return nil, fmt.Errorf("forEach step %s has %d items, more than the maximum of %d", capability.Ref, len(list), capability.ForEach.MaxItems)
+	}
+
+	inputs := &values.List{Underlying: make([]values.Value, 0, len(list))}
+	outputs := &values.List{Underlying: make([]values.Value, 0, len(list))}
+	for i, item := range list {
+		wrapped, err := values.Wrap(item)
+		if err != nil {
+			return nil, err
+		}
+
+		request, err := r.buildRequest(spec, capability, exec.WithItem(r.results, wrapped))
+		if err != nil {
+			return nil, fmt.Errorf("item %d of forEach step %s: %w", i, capability.Ref, err)
+		}
+
+		response, err := mock.Execute(r.ctx, request)
+		if err != nil {
+			return nil, fmt.Errorf("item %d of forEach step %s: %w", i, capability.Ref, err)
+		}
+
+		inputs.Underlying = append(inputs.Underlying, request.Inputs)
+		outputs.Underlying = append(outputs.Underlying, response.Value)
+	}
+
+	return &exec.Result{Inputs: inputs, Outputs: outputs}, nil
 }
 
-func (r *Runner) buildRequest(spec sdk.WorkflowSpec, capability sdk.StepDefinition) (capabilities.CapabilityRequest, error) {
+func (r *Runner) buildRequest(spec sdk.WorkflowSpec, capability sdk.StepDefinition, results exec.Results) (capabilities.CapabilityRequest, error) {
 	env := exec.Env{
 		Config:  r.RawConfig,
 		Binary:  []byte{},
@@ -203,7 +293,7 @@ func (r *Runner) buildRequest(spec sdk.WorkflowSpec, capability sdk.StepDefiniti
 		return capabilities.CapabilityRequest{}, err
 	}
 
-	inputs, err := r.buildInput(capability)
+	inputs, err := r.buildInput(capability, results)
 	if err != nil {
 		return capabilities.CapabilityRequest{}, err
 	}
@@ -223,6 +313,10 @@ func (r *Runner) buildRequest(spec sdk.WorkflowSpec, capability sdk.StepDefiniti
 func (r *Runner) walkNext(spec sdk.WorkflowSpec, edges map[string]graph.Edge[string]) error {
 	var errs []error
 	for edgeRef := range edges {
+		if r.isDone(edgeRef) {
+			continue
+		}
+
 		if r.isReady(edgeRef) {
 			if err := r.walk(spec, edgeRef); err != nil {
 				errs = append(errs, err)
@@ -233,7 +327,7 @@ func (r *Runner) walkNext(spec sdk.WorkflowSpec, edges map[string]graph.Edge[str
 	return errors.Join(errs...)
 }
 
-func (r *Runner) buildInput(capability sdk.StepDefinition) (*values.Map, error) {
+func (r *Runner) buildInput(capability sdk.StepDefinition, results exec.Results) (*values.Map, error) {
 	var input any
 	if capability.Inputs.OutputRef != "" {
 		input = capability.Inputs.OutputRef
@@ -241,7 +335,7 @@ func (r *Runner) buildInput(capability sdk.StepDefinition) (*values.Map, error)
 		input = capability.Inputs.Mapping
 	}
 
-	val, err := exec.FindAndInterpolateAllKeys(input, r.results)
+	val, err := exec.FindAndInterpolateAllKeys(input, results)
 	if err != nil {
 		return nil, err
 	}
@@ -250,7 +344,7 @@ func (r *Runner) buildInput(capability sdk.StepDefinition) (*values.Map, error)
 
 func (r *Runner) isReady(ref string) bool {
 	for _, dep := range r.dependencies[ref] {
-		if _, ok := r.results[dep]; !ok {
+		if !r.isDone(dep) {
 			return false
 		}
 	}
@@ -258,6 +352,28 @@ func (r *Runner) isReady(ref string) bool {
 	return true
 }
 
+func (r *Runner) isDone(ref string) bool {
+	_, ok := r.results[ref]
+	return ok || r.skipped[ref]
+}
+
+// shouldSkip returns true if a dependency of the step was skipped, or a branch it depends on was not taken.
+func (r *Runner) shouldSkip(ref string) bool {
+	for _, dep := range r.dependencies[ref] {
+		if r.skipped[dep] {
+			return true
+		}
+	}
+
+	for _, branch := range r.branches[ref] {
+		if !exec.BranchTaken(r.results[branch.Ref], branch) {
+			return true
+		}
+	}
+
+	return false
+}
+
 func (r *Runner) registerStep(step sdk.StepDefinition) {
 	mock := r.GetRegisteredMock(step.ID, step.Ref)
 	if mock == nil {
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"reflect"
 	"strconv"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -318,6 +319,35 @@ func TestCompute(t *testing.T) {
 		require.NoError(t, runner.Err())
 		assert.Equal(t, gotC.Fidelity, sdk.SecretValue(secretToken))
 	})
+
+	t.Run("State set by a step can be read by later steps", func(t *testing.T) {
+		workflow := sdk.NewWorkflowSpecFactory()
+		trigger := basictrigger.TriggerConfig{Name: "foo", Number: 100}.New(workflow)
+		set := sdk.Compute1(workflow, "set", sdk.Compute1Inputs[string]{Arg0: trigger.CoolOutput()}, func(runtime sdk.Runtime, i0 string) (bool, error) {
+			unset, err := runtime.GetState("key")
+			if err != nil || unset != nil {
+				return false, fmt.Errorf("expected no state, got %v, %v", unset, err)
+			}
+			return true, runtime.SetState("key", []byte(i0))
+		})
+
+		var got []byte
+		sdk.Compute1(workflow, "get", sdk.Compute1Inputs[bool]{Arg0: set.Value()}, func(runtime sdk.Runtime, _ bool) (bool, error) {
+			var err error
+			got, err = runtime.GetState("key")
+			return true, err
+		})
+
+		runner := testutils.NewRunner(tests.Context(t))
+		basictriggertest.Trigger(runner, func() (basictrigger.TriggerOutputs, error) {
+			return basictrigger.TriggerOutputs{CoolOutput: "100"}, nil
+		})
+
+		runner.Run(workflow)
+
+		require.NoError(t, runner.Err())
+		assert.Equal(t, []byte("100"), got)
+	})
 }
 
 func registrationWorkflow() (*sdk.WorkflowSpecFactory, map[string]any, map[string]any) {
@@ -474,3 +504,177 @@ func (m *mockRegistrationTester) verifyConfig(wrappedConfig *values.Map) {
 	require.NoError(m.t, err)
 	assert.True(m.t, reflect.DeepEqual(m.expected, actual))
 }
+
+func TestRunner_ControlFlow(t *testing.T) {
+	t.Parallel()
+
+	newTrigger := func(runner *testutils.Runner, output string) {
+		basictriggertest.Trigger(runner, func() (basictrigger.TriggerOutputs, error) {
+			return basictrigger.TriggerOutputs{CoolOutput: output}, nil
+		})
+	}
+
+	t.Run("if only runs the branch that was taken and its dependents", func(t *testing.T) {
+		var ran []string
+		record := func(name string) func(sdk.Runtime, string) (string, error) {
+			return func(_ sdk.Runtime, s string) (string, error) {
+				ran = append(ran, name)
+				return s, nil
+			}
+		}
+
+		workflow := sdk.NewWorkflowSpecFactory()
+		trigger := basictrigger.TriggerConfig{Name: "trigger", Number: 100}.New(workflow)
+		isCool := sdk.Compute1(workflow, "is_cool", sdk.Compute1Inputs[string]{Arg0: trigger.CoolOutput()},
+			func(_ sdk.Runtime, s string) (bool, error) { return s == "cool", nil })
+		check := sdk.If(workflow, "check", isCool.Value(), trigger.CoolOutput())
+		onThen := sdk.Compute1(workflow, "on_then", sdk.Compute1Inputs[string]{Arg0: check.Then()}, record("on_then"))
+		sdk.Compute1(workflow, "after_then", sdk.Compute1Inputs[string]{Arg0: onThen.Value()}, record("after_then"))
+		onElse := sdk.Compute1(workflow, "on_else", sdk.Compute1Inputs[string]{Arg0: check.Else()}, record("on_else"))
+		sdk.Compute1(workflow, "after_else", sdk.Compute1Inputs[string]{Arg0: onElse.Value()}, record("after_else"))
+
+		runner := testutils.NewRunner(tests.Context(t))
+		newTrigger(runner, "cool")
+		runner.Run(workflow)
+		require.NoError(t, runner.Err())
+		assert.Equal(t, []string{"on_then", "after_then"}, ran)
+	})
+
+	t.Run("switch routes to the matching case or default", func(t *testing.T) {
+		for _, tc := range []struct {
+			output   string
+			expected string
+		}{
+			{output: "cool", expected: "cool"},
+			{output: "hot", expected: "hot"},
+			{output: "lukewarm", expected: "default"},
+		} {
+			t.Run(tc.output, func(t *testing.T) {
+				var ran []string
+				record := func(name string) func(sdk.Runtime, string) (string, error) {
+					return func(_ sdk.Runtime, s string) (string, error) {
+						assert.Equal(t, tc.output, s)
+						ran = append(ran, name)
+						return s, nil
+					}
+				}
+
+				workflow := sdk.NewWorkflowSpecFactory()
+				trigger := basictrigger.TriggerConfig{Name: "trigger", Number: 100}.New(workflow)
+				route := sdk.Switch(workflow, "route", trigger.CoolOutput(), "cool", "hot")
+				sdk.Compute1(workflow, "on_cool", sdk.Compute1Inputs[string]{Arg0: route.Case("cool")}, record("cool"))
+				sdk.Compute1(workflow, "on_hot", sdk.Compute1Inputs[string]{Arg0: route.Case("hot")}, record("hot"))
+				sdk.Compute1(workflow, "on_default", sdk.Compute1Inputs[string]{Arg0: route.Default()}, record("default"))
+
+				runner := testutils.NewRunner(tests.Context(t))
+				newTrigger(runner, tc.output)
+				runner.Run(workflow)
+				require.NoError(t, runner.Err())
+				assert.Equal(t, []string{tc.expected}, ran)
+			})
+		}
+	})
+
+	t.Run("switch rejects unknown cases", func(t *testing.T) {
+		workflow := sdk.NewWorkflowSpecFactory()
+		trigger := basictrigger.TriggerConfig{Name: "trigger", Number: 100}.New(workflow)
+		route := sdk.Switch(workflow, "route", trigger.CoolOutput(), "cool")
+		sdk.Compute1(workflow, "on_hot", sdk.Compute1Inputs[string]{Arg0: route.Case("hot")},
+			func(_ sdk.Runtime, s string) (string, error) { return s, nil })
+
+		_, err := workflow.Spec()
+		require.ErrorContains(t, err, `switch step route has no case "hot"`)
+	})
+
+	forEachWorkflow := func(items []string, maxItems int, joined *string) *sdk.WorkflowSpecFactory {
+		workflow := sdk.NewWorkflowSpecFactory()
+		trigger := basictrigger.TriggerConfig{Name: "trigger", Number: 100}.New(workflow)
+		list := sdk.Compute1(workflow, "list", sdk.Compute1Inputs[string]{Arg0: trigger.CoolOutput()},
+			func(_ sdk.Runtime, _ string) ([]string, error) { return items, nil })
+		upper := sdk.ForEachItem(workflow, list.Value(), maxItems, func(item sdk.CapDefinition[string]) sdk.CapDefinition[sdk.ComputeOutput[string]] {
+			return sdk.Compute1(workflow, "upper", sdk.Compute1Inputs[string]{Arg0: item},
+				func(_ sdk.Runtime, s string) (string, error) { return strings.ToUpper(s), nil })
+		})
+		sdk.Compute1(workflow, "join", sdk.Compute1Inputs[[]sdk.ComputeOutput[string]]{Arg0: upper},
+			func(_ sdk.Runtime, outputs []sdk.ComputeOutput[string]) (string, error) {
+				values := make([]string, len(outputs))
+				for i, o := range outputs {
+					values[i] = o.Value
+				}
+				*joined = strings.Join(values, ",")
+				return *joined, nil
+			})
+		return workflow
+	}
+
+	t.Run("forEach executes a step once per item", func(t *testing.T) {
+		var joined string
+		workflow := forEachWorkflow([]string{"a", "b", "c"}, 5, &joined)
+
+		runner := testutils.NewRunner(tests.Context(t))
+		newTrigger(runner, "cool")
+		runner.Run(workflow)
+		require.NoError(t, runner.Err())
+		assert.Equal(t, "A,B,C", joined)
+	})
+
+	t.Run("forEach fails when there are more items than allowed", func(t *testing.T) {
+		var joined string
+		workflow := forEachWorkflow([]string{"a", "b", "c"}, 2, &joined)
+
+		runner := testutils.NewRunner(tests.Context(t))
+		newTrigger(runner, "cool")
+		runner.Run(workflow)
+		require.ErrorContains(t, runner.Err(), "forEach step upper has 3 items, more than the maximum of 2")
+		assert.Empty(t, joined)
+	})
+
+	t.Run("forEach must add exactly one step", func(t *testing.T) {
+		workflow := sdk.NewWorkflowSpecFactory()
+		trigger := basictrigger.TriggerConfig{Name: "trigger", Number: 100}.New(workflow)
+		list := sdk.Compute1(workflow, "list", sdk.Compute1Inputs[string]{Arg0: trigger.CoolOutput()},
+			func(_ sdk.Runtime, _ string) ([]string, error) { return nil, nil })
+		sdk.ForEachItem(workflow, list.Value(), 5, func(item sdk.CapDefinition[string]) sdk.CapDefinition[string] {
+			return item
+		})
+
+		_, err := workflow.Spec()
+		require.ErrorContains(t, err, "forEach must add exactly one step")
+	})
+
+	t.Run("forEach returns a usable list when it fails", func(t *testing.T) {
+		workflow := sdk.NewWorkflowSpecFactory()
+		trigger := basictrigger.TriggerConfig{Name: "trigger", Number: 100}.New(workflow)
+		list := sdk.Compute1(workflow, "list", sdk.Compute1Inputs[string]{Arg0: trigger.CoolOutput()},
+			func(_ sdk.Runtime, _ string) ([]string, error) { return nil, nil })
+		upper := sdk.ForEachItem(workflow, list.Value(), 0, func(item sdk.CapDefinition[string]) sdk.CapDefinition[string] {
+			return item
+		})
+		require.NotNil(t, upper)
+		sdk.Compute1(workflow, "first", sdk.Compute1Inputs[string]{Arg0: upper.Index(0)},
+			func(_ sdk.Runtime, s string) (string, error) { return s, nil })
+
+		_, err := workflow.Spec()
+		require.ErrorContains(t, err, "forEach max items must be between 1 and")
+	})
+}
+
+func TestRunner_Expressions(t *testing.T) {
+	t.Parallel()
+	var got string
+	workflow := sdk.NewWorkflowSpecFactory()
+	basictrigger.TriggerConfig{Name: "trigger", Number: 100}.New(workflow)
+	sdk.Compute1(workflow, "compute", sdk.Compute1Inputs[string]{Arg0: sdk.Expression[string]("upper(trigger.outputs.cool_output) + '!'")},
+		func(_ sdk.Runtime, s string) (string, error) {
+			got = s
+			return s, nil
+		})
+
+	runner := testutils.NewRunner(tests.Context(t))
+	basictriggertest.Trigger(runner, func() (basictrigger.TriggerOutputs, error) {
+		return basictrigger.TriggerOutputs{CoolOutput: "cool"}, nil
+	})
+	runner.Run(workflow)
+	require.NoError(t, runner.Err())
+	assert.Equal(t, "COOL!", got)
+}
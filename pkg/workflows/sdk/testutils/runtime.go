@@ -1,11 +1,17 @@
 package testutils
 
 import (
+	"sync"
+	"time"
+
 	"github.com/smartcontractkit/chainlink-common/pkg/logger"
 	"github.com/smartcontractkit/chainlink-common/pkg/workflows/sdk"
 )
 
-type NoopRuntime struct{}
+type NoopRuntime struct {
+	// state is kept in memory so that workflows can read back the state they set.
+	state sync.Map
+}
 
 var _ sdk.Runtime = &NoopRuntime{}
 
@@ -21,3 +27,20 @@ func (nr *NoopRuntime) Logger() logger.Logger {
 func (nr *NoopRuntime) Emitter() sdk.MessageEmitter {
 	return nil
 }
+
+func (nr *NoopRuntime) Now() time.Time {
+	return time.Now()
+}
+
+func (nr *NoopRuntime) GetState(key string) ([]byte, error) {
+	value, ok := nr.state.Load(key)
+	if !ok {
+		return nil, nil
+	}
+	return append([]byte(nil), value.([]byte)...), nil
+}
+
+func (nr *NoopRuntime) SetState(key string, value []byte) error {
+	nr.state.Store(key, append([]byte(nil), value...))
+	return nil
+}
@@ -15,6 +15,8 @@ type StepDefinition struct {
 	Ref    string
 	Inputs StepInputs
 	Config map[string]any
+	// ForEach is set when the step is executed once per element of a list.
+	ForEach *ForEach
 
 	CapabilityType capabilities.CapabilityType
 }
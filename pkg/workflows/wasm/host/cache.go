@@ -0,0 +1,124 @@
+package host
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/bytecodealliance/wasmtime-go/v23"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/logger"
+)
+
+const compiledModuleExt = ".cwasm"
+
+// engineConfig is the configuration of the wasmtime engine which compiled modules depend on.
+type engineConfig struct {
+	consumeFuel bool
+	optLevel    wasmtime.OptLevel
+	// noCache disables the wasmtime disk cache, it doesn't change compiled modules
+	noCache bool
+}
+
+func newEngineConfig(modCfg *ModuleConfig) engineConfig {
+	return engineConfig{
+		consumeFuel: modCfg.InitialFuel > 0,
+		optLevel:    wasmtime.OptLevelSpeedAndSize,
+	}
+}
+
+func (c engineConfig) wasmtimeConfig() *wasmtime.Config {
+	cfg := wasmtime.NewConfig()
+	cfg.SetEpochInterruption(true)
+	if c.consumeFuel {
+		cfg.SetConsumeFuel(true)
+	}
+
+	if !c.noCache {
+		cfg.CacheConfigLoadDefault()
+	}
+	cfg.SetCraneliftOptLevel(c.optLevel)
+	return cfg
+}
+
+// compiledModuleCache stores modules compiled by wasmtime on disk, so that creating a module from a binary
+// that was already compiled only needs to deserialize it.
+//
+// Compiled modules are keyed by the hash of the binary and the configuration of the engine. wasmtime checks
+// on deserialization that a compiled module matches its version and the host, and modules which don't are
+// compiled again.
+//
+// Deserializing a compiled module trusts that it was produced by wasmtime, so the cache directory must not be
+// writable by anyone else than the node.
+type compiledModuleCache struct {
+	dir    string
+	lggr   logger.Logger
+	config engineConfig
+}
+
+func (c *compiledModuleCache) path(binary []byte) string {
+	h := sha256.New()
+	fmt.Fprintf(h, "epoch=true,fuel=%t,opt=%d\n", c.config.consumeFuel, c.config.optLevel)
+	h.Write(binary)
+	return filepath.Join(c.dir, hex.EncodeToString(h.Sum(nil))+compiledModuleExt)
+}
+
+// module returns the module compiled from binary, loading it from the cache if it was compiled before
+// and storing it in the cache otherwise. Failing to use the cache is logged, since the module can still be
+// compiled without it.
+func (c *compiledModuleCache) module(engine *wasmtime.Engine, binary []byte) (*wasmtime.Module, error) {
+	path := c.path(binary)
+
+	// The compiled module is read in memory rather than mapped by wasmtime, so that the module doesn't depend
+	// on the file once loaded.
+	if serialized, err := os.ReadFile(path); err == nil {
+		mod, err := wasmtime.NewModuleDeserialize(engine, serialized)
+		if err == nil {
+			return mod, nil
+		}
+
+		c.lggr.Warnw("failed to load compiled module from cache, compiling it again", "path", path, "err", err)
+	}
+
+	mod, err := wasmtime.NewModule(engine, binary)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := c.store(path, mod); err != nil {
+		c.lggr.Warnw("failed to store compiled module in cache", "path", path, "err", err)
+	}
+
+	return mod, nil
+}
+
+// store writes the compiled module to a temporary file first, so that concurrent readers never see a
+// partially written module.
+func (c *compiledModuleCache) store(path string, mod *wasmtime.Module) error {
+	serialized, err := mod.Serialize()
+	if err != nil {
+		return fmt.Errorf("failed to serialize module: %w", err)
+	}
+
+	if err = os.MkdirAll(c.dir, 0o700); err != nil {
+		return fmt.Errorf("failed to create cache directory: %w", err)
+	}
+
+	f, err := os.CreateTemp(c.dir, "*.tmp")
+	if err != nil {
+		return fmt.Errorf("failed to create temporary file: %w", err)
+	}
+	defer os.Remove(f.Name())
+
+	_, err = f.Write(serialized)
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		return fmt.Errorf("failed to write compiled module: %w", err)
+	}
+
+	return os.Rename(f.Name(), path)
+}
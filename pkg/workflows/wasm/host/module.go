@@ -8,12 +8,14 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"hash/fnv"
 	"io"
 	"math"
 
 	"strings"
 	"sync"
 	"time"
+	"unsafe"
 
 	"github.com/andybalholm/brotli"
 	"github.com/bytecodealliance/wasmtime-go/v23"
@@ -21,6 +23,7 @@ import (
 
 	"github.com/smartcontractkit/chainlink-common/pkg/custmsg"
 	"github.com/smartcontractkit/chainlink-common/pkg/logger"
+	"github.com/smartcontractkit/chainlink-common/pkg/types/core"
 	"github.com/smartcontractkit/chainlink-common/pkg/values"
 	"github.com/smartcontractkit/chainlink-common/pkg/workflows/wasm"
 	wasmpb "github.com/smartcontractkit/chainlink-common/pkg/workflows/wasm/pb"
@@ -28,6 +31,7 @@ import (
 
 type RequestData struct {
 	fetchRequestsCounter int
+	workflowID           string
 	response             *wasmpb.Response
 	ctx                  func() context.Context
 }
@@ -75,11 +79,26 @@ var (
 	defaultMinMemoryMBs     = 128
 	DefaultInitialFuel      = uint64(100_000_000)
 	defaultMaxFetchRequests = 5
+
+	defaultMaxStateKeySizeBytes = 256
+	// defaultMaxStateValueSizeBytes leaves room for the rest of the response within the
+	// guest's default response buffer.
+	defaultMaxStateValueSizeBytes = 4 * 1024
+	defaultMaxStateKeys           = 64
+	defaultMaxStateTotalSizeBytes = 64 * 1024
 )
 
+// ErrStateNotFound is returned by a StateStore for a key that was never stored.
+var ErrStateNotFound = errors.New("state not found")
+
 type DeterminismConfig struct {
 	// Seed is the seed used to generate cryptographically insecure random numbers in the module.
 	Seed int64
+
+	// If Time is set, each Run gets its own clock in the WASI API, starting at the time of the request
+	// rather than at the time the host started, so that the time seen by the module only depends on
+	// its request. Requests must then set their time.
+	Time bool
 }
 type ModuleConfig struct {
 	TickInterval     time.Duration
@@ -95,9 +114,30 @@ type ModuleConfig struct {
 	// Labeler is used to emit messages from the module.
 	Labeler custmsg.MessageEmitter
 
+	// StateStore persists the state set by the module between executions.
+	// Keys are namespaced per workflow ID, so workflows cannot read each other's state.
+	// Get must return ErrStateNotFound, or a nil value and no error, for a key that was never stored.
+	StateStore             core.KeyValueStore
+	MaxStateKeySizeBytes   int
+	MaxStateValueSizeBytes int
+	// MaxStateKeys and MaxStateTotalSizeBytes bound the number of keys and the total size of the values
+	// each workflow can keep in the StateStore.
+	MaxStateKeys           int
+	MaxStateTotalSizeBytes int
+
 	// If Determinism is set, the module will override the random_get function in the WASI API with
 	// the provided seed to ensure deterministic behavior.
 	Determinism *DeterminismConfig
+
+	// If CompiledCacheDir is set, compiled modules are cached in it, keyed by the hash of the binary and the
+	// configuration of the engine, so that creating a module from the same binary again skips compilation.
+	// The directory must only be writable by the node, since cached modules are trusted when loaded.
+	CompiledCacheDir string
+
+	// PrewarmInstances is the number of instances of the module instantiated ahead of time after Start, so that
+	// Run doesn't need to instantiate the module. Each instance is run once and then replaced, instances are not
+	// reused across runs. Prewarming is disabled if PrewarmInstances is 0.
+	PrewarmInstances int
 }
 
 type Module struct {
@@ -107,6 +147,8 @@ type Module struct {
 	wconfig *wasmtime.Config
 
 	requestStore *store
+	clocks       *runClocks
+	prewarmed    chan *instance
 
 	cfg *ModuleConfig
 
@@ -129,6 +171,12 @@ func WithDeterminism() func(*ModuleConfig) {
 }
 
 func NewModule(modCfg *ModuleConfig, binary []byte, opts ...func(*ModuleConfig)) (*Module, error) {
+	return newModule(modCfg, binary, true, opts...)
+}
+
+// newModule creates a module like NewModule. Benchmarks disable wasmtimeCache, the wasmtime disk cache,
+// so that they measure compilation.
+func newModule(modCfg *ModuleConfig, binary []byte, wasmtimeCache bool, opts ...func(*ModuleConfig)) (*Module, error) {
 	// Apply options to the module config.
 	for _, opt := range opts {
 		opt(modCfg)
@@ -152,6 +200,30 @@ func NewModule(modCfg *ModuleConfig, binary []byte, opts ...func(*ModuleConfig))
 		modCfg.Labeler = &unimplementedMessageEmitter{}
 	}
 
+	if modCfg.StateStore == nil {
+		modCfg.StateStore = &unimplementedStateStore{}
+	}
+
+	if modCfg.MaxStateKeySizeBytes == 0 {
+		modCfg.MaxStateKeySizeBytes = defaultMaxStateKeySizeBytes
+	}
+
+	if modCfg.MaxStateValueSizeBytes == 0 {
+		modCfg.MaxStateValueSizeBytes = defaultMaxStateValueSizeBytes
+	}
+
+	if modCfg.MaxStateKeys == 0 {
+		modCfg.MaxStateKeys = defaultMaxStateKeys
+	}
+
+	if modCfg.MaxStateTotalSizeBytes == 0 {
+		modCfg.MaxStateTotalSizeBytes = defaultMaxStateTotalSizeBytes
+	}
+
+	if modCfg.PrewarmInstances < 0 {
+		return nil, fmt.Errorf("invalid PrewarmInstances %d: must not be negative", modCfg.PrewarmInstances)
+	}
+
 	logger := modCfg.Logger
 
 	if modCfg.TickInterval == 0 {
@@ -172,14 +244,9 @@ func NewModule(modCfg *ModuleConfig, binary []byte, opts ...func(*ModuleConfig))
 	// binaries may error sporadically.
 	modCfg.MaxMemoryMBs = int64(math.Max(float64(modCfg.MinMemoryMBs), float64(modCfg.MaxMemoryMBs)))
 
-	cfg := wasmtime.NewConfig()
-	cfg.SetEpochInterruption(true)
-	if modCfg.InitialFuel > 0 {
-		cfg.SetConsumeFuel(true)
-	}
-
-	cfg.CacheConfigLoadDefault()
-	cfg.SetCraneliftOptLevel(wasmtime.OptLevelSpeedAndSize)
+	engineCfg := newEngineConfig(modCfg)
+	engineCfg.noCache = !wasmtimeCache
+	cfg := engineCfg.wasmtimeConfig()
 
 	engine := wasmtime.NewEngineWithConfig(cfg)
 	if !modCfg.IsUncompressed {
@@ -192,12 +259,24 @@ func NewModule(modCfg *ModuleConfig, binary []byte, opts ...func(*ModuleConfig))
 		binary = decompedBinary
 	}
 
-	mod, err := wasmtime.NewModule(engine, binary)
+	var mod *wasmtime.Module
+	var err error
+	if modCfg.CompiledCacheDir != "" {
+		cache := &compiledModuleCache{dir: modCfg.CompiledCacheDir, lggr: logger, config: engineCfg}
+		mod, err = cache.module(engine, binary)
+	} else {
+		mod, err = wasmtime.NewModule(engine, binary)
+	}
 	if err != nil {
 		return nil, fmt.Errorf("error creating wasmtime module: %w", err)
 	}
 
-	linker, err := newWasiLinker(modCfg, engine)
+	var clocks *runClocks
+	if modCfg.Determinism != nil && modCfg.Determinism.Time {
+		clocks = &runClocks{m: map[unsafe.Pointer]runClock{}}
+	}
+
+	linker, err := newWasiLinker(modCfg, engine, clocks)
 	if err != nil {
 		return nil, fmt.Errorf("error creating wasi linker: %w", err)
 	}
@@ -242,6 +321,24 @@ func NewModule(modCfg *ModuleConfig, binary []byte, opts ...func(*ModuleConfig))
 		return nil, fmt.Errorf("error wrapping emit func: %w", err)
 	}
 
+	err = linker.FuncWrap(
+		"env",
+		"getState",
+		createGetStateFn(logger, requestStore, modCfg, wasmRead, wasmWrite, wasmReadUInt32, wasmWriteUInt32),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("error wrapping getState func: %w", err)
+	}
+
+	err = linker.FuncWrap(
+		"env",
+		"setState",
+		createSetStateFn(logger, requestStore, modCfg, wasmRead, wasmWrite, wasmReadUInt32, wasmWriteUInt32),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("error wrapping setState func: %w", err)
+	}
+
 	m := &Module{
 		engine:  engine,
 		module:  mod,
@@ -249,6 +346,8 @@ func NewModule(modCfg *ModuleConfig, binary []byte, opts ...func(*ModuleConfig))
 		wconfig: cfg,
 
 		requestStore: requestStore,
+		clocks:       clocks,
+		prewarmed:    make(chan *instance, modCfg.PrewarmInstances),
 
 		cfg: modCfg,
 
@@ -273,12 +372,22 @@ func (m *Module) Start() {
 			}
 		}
 	}()
+
+	if m.cfg.PrewarmInstances > 0 {
+		m.wg.Add(1)
+		go func() {
+			defer m.wg.Done()
+			m.prewarm()
+		}()
+	}
 }
 
 func (m *Module) Close() {
 	close(m.stopCh)
 	m.wg.Wait()
 
+	m.drainPrewarmed()
+
 	m.linker.Close()
 	m.engine.Close()
 	m.module.Close()
@@ -297,16 +406,34 @@ func (m *Module) Run(ctx context.Context, request *wasmpb.Request) (*wasmpb.Resp
 		return nil, fmt.Errorf("invalid request: can't be empty")
 	}
 
-	// we add the request context to the store to make it available to the Fetch fn
-	err := m.requestStore.add(request.Id, &RequestData{ctx: func() context.Context { return ctxWithTimeout }})
+	if m.clocks != nil && request.Time == nil {
+		return nil, fmt.Errorf("invalid request: time is required when time is deterministic")
+	}
+
+	// we add the request context to the store to make it available to the Fetch fn,
+	// along with the workflow ID the state of the request is namespaced by
+	err := m.requestStore.add(request.Id, &RequestData{
+		ctx:        func() context.Context { return ctxWithTimeout },
+		workflowID: request.GetComputeRequest().GetRequest().GetMetadata().GetWorkflowId(),
+	})
 	if err != nil {
 		return nil, fmt.Errorf("error adding ctx to the store: %w", err)
 	}
 	// we delete the request data from the store when we're done
 	defer m.requestStore.delete(request.Id)
 
-	store := wasmtime.NewStore(m.engine)
-	defer store.Close()
+	inst, err := m.takeInstance()
+	if err != nil {
+		return nil, err
+	}
+	defer inst.close()
+
+	store := inst.store
+
+	if m.clocks != nil {
+		m.clocks.add(store, request.Time.AsTime())
+		defer m.clocks.delete(store)
+	}
 
 	reqpb, err := proto.Marshal(request)
 	if err != nil {
@@ -329,24 +456,10 @@ func (m *Module) Run(ctx context.Context, request *wasmpb.Request) (*wasmpb.Resp
 		}
 	}
 
-	// Limit memory to max memory megabytes per instance.
-	store.Limiter(
-		m.cfg.MaxMemoryMBs*int64(math.Pow(10, 6)),
-		-1, // tableElements, -1 == default
-		1,  // instances
-		1,  // tables
-		1,  // memories
-	)
-
 	deadline := *m.cfg.Timeout / m.cfg.TickInterval
 	store.SetEpochDeadline(uint64(deadline))
 
-	instance, err := m.linker.Instantiate(store, m.module)
-	if err != nil {
-		return nil, err
-	}
-
-	start := instance.GetFunc(store, "_start")
+	start := inst.instance.GetFunc(store, "_start")
 	if start == nil {
 		return nil, errors.New("could not get start function")
 	}
@@ -569,6 +682,272 @@ func createEmitFn(
 	}
 }
 
+// createGetStateFn injects dependencies and builds the getState function exposed by the WASM.  Errors
+// in GetState, if any, are returned in the Error Message of the response.
+func createGetStateFn(
+	l logger.Logger,
+	requestStore *store,
+	modCfg *ModuleConfig,
+	reader unsafeReaderFunc,
+	writer unsafeWriterFunc,
+	sizeReader unsafeFixedLengthReaderFunc,
+	sizeWriter unsafeFixedLengthWriterFunc,
+) func(caller *wasmtime.Caller, respptr, resplenptr, reqptr, reqptrlen int32) int32 {
+	logErr := func(err error) {
+		l.Errorf("error calling getState: %s", err)
+	}
+
+	return func(caller *wasmtime.Caller, respptr, resplenptr, reqptr, reqptrlen int32) int32 {
+		writeResp := func(resp *wasmpb.GetStateResponse) int32 {
+			return writeStateResponse(caller, sizeReader, writer, sizeWriter, respptr, resplenptr, resp, func(err error) proto.Message {
+				return &wasmpb.GetStateResponse{Error: &wasmpb.Error{Message: err.Error()}}
+			}, logErr)
+		}
+
+		writeErr := func(err error) int32 {
+			logErr(err)
+			return writeResp(&wasmpb.GetStateResponse{Error: &wasmpb.Error{Message: err.Error()}})
+		}
+
+		b, err := reader(caller, reqptr, reqptrlen)
+		if err != nil {
+			return writeErr(err)
+		}
+
+		req := &wasmpb.GetStateRequest{}
+		if err = proto.Unmarshal(b, req); err != nil {
+			return writeErr(err)
+		}
+
+		storedRequest, err := requestStore.get(req.RequestId)
+		if err != nil {
+			return writeErr(err)
+		}
+
+		key, err := toStateKey(storedRequest, req.Key, modCfg)
+		if err != nil {
+			return writeErr(err)
+		}
+
+		value, err := getState(storedRequest.ctx(), modCfg, key)
+		if err != nil {
+			return writeErr(err)
+		}
+
+		return writeResp(&wasmpb.GetStateResponse{Value: value})
+	}
+}
+
+// createSetStateFn injects dependencies and builds the setState function exposed by the WASM.  Errors
+// in SetState, if any, are returned in the Error Message of the response.
+func createSetStateFn(
+	l logger.Logger,
+	requestStore *store,
+	modCfg *ModuleConfig,
+	reader unsafeReaderFunc,
+	writer unsafeWriterFunc,
+	sizeReader unsafeFixedLengthReaderFunc,
+	sizeWriter unsafeFixedLengthWriterFunc,
+) func(caller *wasmtime.Caller, respptr, resplenptr, reqptr, reqptrlen int32) int32 {
+	logErr := func(err error) {
+		l.Errorf("error calling setState: %s", err)
+	}
+
+	return func(caller *wasmtime.Caller, respptr, resplenptr, reqptr, reqptrlen int32) int32 {
+		writeResp := func(resp *wasmpb.SetStateResponse) int32 {
+			return writeStateResponse(caller, sizeReader, writer, sizeWriter, respptr, resplenptr, resp, func(err error) proto.Message {
+				return &wasmpb.SetStateResponse{Error: &wasmpb.Error{Message: err.Error()}}
+			}, logErr)
+		}
+
+		writeErr := func(err error) int32 {
+			logErr(err)
+			return writeResp(&wasmpb.SetStateResponse{Error: &wasmpb.Error{Message: err.Error()}})
+		}
+
+		b, err := reader(caller, reqptr, reqptrlen)
+		if err != nil {
+			return writeErr(err)
+		}
+
+		req := &wasmpb.SetStateRequest{}
+		if err = proto.Unmarshal(b, req); err != nil {
+			return writeErr(err)
+		}
+
+		storedRequest, err := requestStore.get(req.RequestId)
+		if err != nil {
+			return writeErr(err)
+		}
+
+		key, err := toStateKey(storedRequest, req.Key, modCfg)
+		if err != nil {
+			return writeErr(err)
+		}
+
+		if len(req.Value) > modCfg.MaxStateValueSizeBytes {
+			return writeErr(fmt.Errorf("state value of %d bytes exceeds the max size of %d bytes", len(req.Value), modCfg.MaxStateValueSizeBytes))
+		}
+
+		if err = storeState(storedRequest.ctx(), modCfg, storedRequest.workflowID, key, req.Value); err != nil {
+			return writeErr(err)
+		}
+
+		return writeResp(&wasmpb.SetStateResponse{})
+	}
+}
+
+// toStateKey validates a key requested by the module and namespaces it by the workflow ID of the request.
+func toStateKey(req *RequestData, key string, modCfg *ModuleConfig) (string, error) {
+	if req.workflowID == "" {
+		return "", errors.New("state is only available to requests with a workflow ID")
+	}
+
+	if key == "" {
+		return "", errors.New("state key cannot be empty")
+	}
+
+	if len(key) > modCfg.MaxStateKeySizeBytes {
+		return "", fmt.Errorf("state key of %d bytes exceeds the max size of %d bytes", len(key), modCfg.MaxStateKeySizeBytes)
+	}
+
+	return req.workflowID + "/" + key, nil
+}
+
+// stateUsage is the number of keys and the total size of the values a workflow keeps in the StateStore.
+type stateUsage struct {
+	Keys  int `json:"keys"`
+	Bytes int `json:"bytes"`
+}
+
+// stateUsageKey is the key the stateUsage of a workflow is stored at. It cannot collide with the keys of the
+// module, which are separated from the workflow ID by a slash.
+func stateUsageKey(workflowID string) string {
+	return workflowID + "#usage"
+}
+
+// stateLocks serialize the state updates of each workflow, so that its usage stays consistent with its state.
+// They are shared by all the modules of the process, since the modules of a workflow can share a StateStore.
+// Modules in other processes sharing the StateStore are not serialized.
+var stateLocks [64]sync.Mutex
+
+func stateLock(workflowID string) *sync.Mutex {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(workflowID))
+	return &stateLocks[h.Sum32()%uint32(len(stateLocks))]
+}
+
+// getState returns the value stored at key, or nil if there is none.
+func getState(ctx context.Context, modCfg *ModuleConfig, key string) ([]byte, error) {
+	value, err := modCfg.StateStore.Get(ctx, key)
+	if errors.Is(err, ErrStateNotFound) {
+		return nil, nil
+	}
+	return value, err
+}
+
+// storeState stores value at key, unless it takes the state of the workflow over MaxStateKeys or
+// MaxStateTotalSizeBytes. The usage of each workflow is stored along its state.
+// An empty value doesn't count as a key.
+func storeState(ctx context.Context, modCfg *ModuleConfig, workflowID, key string, value []byte) error {
+	mu := stateLock(workflowID)
+	mu.Lock()
+	defer mu.Unlock()
+
+	var usage stateUsage
+	b, err := getState(ctx, modCfg, stateUsageKey(workflowID))
+	if err != nil {
+		return err
+	}
+	if len(b) > 0 {
+		if err = json.Unmarshal(b, &usage); err != nil {
+			return fmt.Errorf("invalid state usage: %w", err)
+		}
+	}
+
+	prev, err := getState(ctx, modCfg, key)
+	if err != nil {
+		return err
+	}
+
+	updated := usage
+	if len(prev) > 0 {
+		updated.Keys--
+		updated.Bytes -= len(prev)
+	}
+	if len(value) > 0 {
+		updated.Keys++
+		updated.Bytes += len(value)
+	}
+
+	// a workflow over its quota, since it was lowered, can still shrink its state
+	if updated.Keys > modCfg.MaxStateKeys && updated.Keys > usage.Keys {
+		return fmt.Errorf("state keys exceed the max of %d keys", modCfg.MaxStateKeys)
+	}
+	if updated.Bytes > modCfg.MaxStateTotalSizeBytes && updated.Bytes > usage.Bytes {
+		return fmt.Errorf("state of %d bytes exceeds the max total size of %d bytes", updated.Bytes, modCfg.MaxStateTotalSizeBytes)
+	}
+
+	if err = modCfg.StateStore.Store(ctx, key, value); err != nil {
+		return err
+	}
+
+	if b, err = json.Marshal(updated); err != nil {
+		return err
+	}
+	return modCfg.StateStore.Store(ctx, stateUsageKey(workflowID), b)
+}
+
+// writeStateResponse marshals and writes the response of a state call to wasm.
+// The guest passes the size of its response buffer in the response length, a response which doesn't fit
+// in it is replaced by the error response returned by toErr.
+func writeStateResponse(
+	caller *wasmtime.Caller,
+	sizeReader unsafeFixedLengthReaderFunc,
+	writer unsafeWriterFunc,
+	sizeWriter unsafeFixedLengthWriterFunc,
+	respptr, resplenptr int32,
+	resp proto.Message,
+	toErr func(error) proto.Message,
+	logErr func(error),
+) int32 {
+	bufferSize, err := sizeReader(caller, resplenptr)
+	if err != nil {
+		logErr(err)
+		return ErrnoFault
+	}
+
+	respBytes, err := proto.Marshal(resp)
+	if err != nil {
+		logErr(err)
+		return ErrnoFault
+	}
+
+	if len(respBytes) > int(bufferSize) {
+		err = fmt.Errorf("state response of %d bytes exceeds the guest buffer of %d bytes", len(respBytes), bufferSize)
+		logErr(err)
+		if respBytes, err = proto.Marshal(toErr(err)); err != nil {
+			logErr(err)
+			return ErrnoFault
+		}
+		if len(respBytes) > int(bufferSize) {
+			return ErrnoFault
+		}
+	}
+
+	if size := writer(caller, respBytes, respptr, int32(len(respBytes))); size == -1 {
+		logErr(errors.New("failed to write response"))
+		return ErrnoFault
+	}
+
+	if size := sizeWriter(caller, resplenptr, uint32(len(respBytes))); size == -1 {
+		logErr(errors.New("failed to write response length"))
+		return ErrnoFault
+	}
+
+	return ErrnoSuccess
+}
+
 // createLogFn injects dependencies and builds the log function exposed by the WASM.
 func createLogFn(logger logger.Logger) func(caller *wasmtime.Caller, ptr int32, ptrlen int32) {
 	return func(caller *wasmtime.Caller, ptr int32, ptrlen int32) {
@@ -633,6 +1012,16 @@ func (u *unimplementedMessageEmitter) Labels() map[string]string {
 	return nil
 }
 
+type unimplementedStateStore struct{}
+
+func (u *unimplementedStateStore) Store(context.Context, string, []byte) error {
+	return errors.New("state not implemented")
+}
+
+func (u *unimplementedStateStore) Get(context.Context, string) ([]byte, error) {
+	return nil, errors.New("state not implemented")
+}
+
 func toEmissible(b []byte) (string, string, map[string]string, error) {
 	msg := &wasmpb.EmitMessageRequest{}
 	if err := proto.Unmarshal(b, msg); err != nil {
@@ -674,6 +1063,10 @@ type unsafeWriterFunc func(c *wasmtime.Caller, src []byte, ptr, len int32) int64
 // by the ptr.
 type unsafeFixedLengthWriterFunc func(c *wasmtime.Caller, ptr int32, val uint32) int64
 
+// unsafeFixedLengthReaderFunc defines behavior for reading a uint32 value from wasm memory at the location defined
+// by the ptr.
+type unsafeFixedLengthReaderFunc func(c *wasmtime.Caller, ptr int32) (uint32, error)
+
 // unsafeReaderFunc abstractly defines the behavior of reading from WASM memory.  Returns a copy of
 // the memory at the given pointer and size.
 type unsafeReaderFunc func(c *wasmtime.Caller, ptr, len int32) ([]byte, error)
@@ -688,6 +1081,20 @@ func wasmRead(caller *wasmtime.Caller, ptr int32, size int32) ([]byte, error) {
 	return read(wasmMemoryAccessor(caller), ptr, size)
 }
 
+// wasmReadUInt32 reads a binary encoded uint32 from the wasm module memory at the given pointer.
+func wasmReadUInt32(caller *wasmtime.Caller, ptr int32) (uint32, error) {
+	return readUInt32(wasmMemoryAccessor(caller), ptr)
+}
+
+// readUInt32 reads a binary encoded uint32 from the memory at the given pointer.
+func readUInt32(memory []byte, ptr int32) (uint32, error) {
+	b, err := read(memory, ptr, 4)
+	if err != nil {
+		return 0, err
+	}
+	return binary.LittleEndian.Uint32(b), nil
+}
+
 // Read acts on a byte slice that should represent an unsafely accessed slice of memory.  It returns
 // a copy of the memory at the given pointer and size.
 func read(memory []byte, ptr int32, size int32) ([]byte, error) {
@@ -0,0 +1,79 @@
+package host
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/emptypb"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/logger"
+	"github.com/smartcontractkit/chainlink-common/pkg/utils/tests"
+	wasmpb "github.com/smartcontractkit/chainlink-common/pkg/workflows/wasm/pb"
+)
+
+// BenchmarkNewModule compares creating a module by compiling its binary with loading it from the compiled cache.
+// The wasmtime disk cache is disabled, so that "compiled" measures compilation.
+func BenchmarkNewModule(b *testing.B) {
+	binary := createTestBinary(successBinaryCmd, successBinaryLocation, true, b)
+
+	b.Run("compiled", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			m, err := newModule(&ModuleConfig{Logger: logger.Test(b), IsUncompressed: true}, binary, false)
+			require.NoError(b, err)
+			m.Close()
+		}
+	})
+
+	b.Run("cached", func(b *testing.B) {
+		dir := b.TempDir()
+		newModule := func() {
+			m, err := newModule(&ModuleConfig{Logger: logger.Test(b), IsUncompressed: true, CompiledCacheDir: dir}, binary, false)
+			require.NoError(b, err)
+			m.Close()
+		}
+
+		// compile the module into the cache first
+		newModule()
+
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			newModule()
+		}
+	})
+}
+
+// BenchmarkModule_Run compares running requests on instances created for each run with prewarmed ones.
+func BenchmarkModule_Run(b *testing.B) {
+	binary := createTestBinary(successBinaryCmd, successBinaryLocation, true, b)
+
+	req := &wasmpb.Request{
+		Message: &wasmpb.Request_SpecRequest{
+			SpecRequest: &emptypb.Empty{},
+		},
+	}
+
+	for _, bc := range []struct {
+		name    string
+		prewarm int
+	}{
+		{name: "cold", prewarm: 0},
+		{name: "prewarmed", prewarm: 4},
+	} {
+		b.Run(bc.name, func(b *testing.B) {
+			m, err := NewModule(&ModuleConfig{Logger: logger.Test(b), IsUncompressed: true, PrewarmInstances: bc.prewarm}, binary)
+			require.NoError(b, err)
+
+			m.Start()
+			defer m.Close()
+
+			ctx := tests.Context(b)
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				req.Id = uuid.New().String()
+				_, err := m.Run(ctx, req)
+				require.NoError(b, err)
+			}
+		})
+	}
+}
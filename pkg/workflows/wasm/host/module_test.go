@@ -1,8 +1,11 @@
 package host
 
 import (
+	"bytes"
 	"context"
 	"encoding/binary"
+	"fmt"
+	"strconv"
 	"sync"
 	"testing"
 
@@ -515,6 +518,159 @@ func TestCreateFetchFn(t *testing.T) {
 	})
 }
 
+func Test_createStateFns(t *testing.T) {
+	ctx := tests.Context(t)
+	store := &store{
+		m:  make(map[string]*RequestData),
+		mu: sync.RWMutex{},
+	}
+	reqID := "random-id"
+	err := store.add(reqID, &RequestData{ctx: func() context.Context { return ctx }, workflowID: "workflow-id"})
+	require.NoError(t, err)
+
+	modCfg := &ModuleConfig{
+		StateStore:             &mockStateStore{m: map[string][]byte{}},
+		MaxStateKeySizeBytes:   8,
+		MaxStateValueSizeBytes: 8,
+		MaxStateKeys:           3,
+		MaxStateTotalSizeBytes: 20,
+	}
+	getStateWithBuffer := func(key string, bufferSize uint32) *wasmpb.GetStateResponse {
+		resp := &wasmpb.GetStateResponse{}
+		fn := createGetStateFn(logger.Test(t), store, modCfg, readerOf(t, &wasmpb.GetStateRequest{RequestId: reqID, Key: key}), writerTo(t, resp), sizeReaderOf(bufferSize), noopSizeWriter)
+		assert.Equal(t, ErrnoSuccess, fn(new(wasmtime.Caller), 0, 0, 0, 0))
+		return resp
+	}
+
+	getState := func(key string) *wasmpb.GetStateResponse {
+		return getStateWithBuffer(key, 1024)
+	}
+
+	setState := func(key string, value []byte) *wasmpb.SetStateResponse {
+		resp := &wasmpb.SetStateResponse{}
+		fn := createSetStateFn(logger.Test(t), store, modCfg, readerOf(t, &wasmpb.SetStateRequest{RequestId: reqID, Key: key, Value: value}), writerTo(t, resp), sizeReaderOf(1024), noopSizeWriter)
+		assert.Equal(t, ErrnoSuccess, fn(new(wasmtime.Caller), 0, 0, 0, 0))
+		return resp
+	}
+
+	t.Run("set then get", func(t *testing.T) {
+		assert.Nil(t, setState("key", []byte("value")).Error)
+
+		resp := getState("key")
+		assert.Nil(t, resp.Error)
+		assert.Equal(t, []byte("value"), resp.Value)
+		assert.Equal(t, []byte("value"), modCfg.StateStore.(*mockStateStore).m["workflow-id/key"])
+	})
+
+	t.Run("get unset key", func(t *testing.T) {
+		resp := getState("unset")
+		assert.Nil(t, resp.Error)
+		assert.Nil(t, resp.Value)
+	})
+
+	t.Run("quotas", func(t *testing.T) {
+		assert.Equal(t, "state key cannot be empty", getState("").Error.GetMessage())
+		assert.Equal(t, "state key of 9 bytes exceeds the max size of 8 bytes", getState("too-long!").Error.GetMessage())
+		assert.Equal(t, "state key of 9 bytes exceeds the max size of 8 bytes", setState("too-long!", nil).Error.GetMessage())
+		assert.Equal(t, "state value of 9 bytes exceeds the max size of 8 bytes", setState("key", []byte("too-long!")).Error.GetMessage())
+	})
+
+	t.Run("get value larger than the guest buffer", func(t *testing.T) {
+		modCfg.StateStore.(*mockStateStore).m["workflow-id/big"] = bytes.Repeat([]byte("a"), 200)
+
+		resp := getStateWithBuffer("big", 100)
+		assert.Nil(t, resp.Value)
+		assert.Equal(t, "state response of 203 bytes exceeds the guest buffer of 100 bytes", resp.Error.GetMessage())
+
+		fn := createGetStateFn(logger.Test(t), store, modCfg, readerOf(t, &wasmpb.GetStateRequest{RequestId: reqID, Key: "big"}), writerTo(t, &wasmpb.GetStateResponse{}), sizeReaderOf(4), noopSizeWriter)
+		assert.Equal(t, ErrnoFault, fn(new(wasmtime.Caller), 0, 0, 0, 0), "the error must fit in the buffer too")
+	})
+
+	t.Run("workflow quotas", func(t *testing.T) {
+		stateStore := modCfg.StateStore.(*mockStateStore)
+		stateStore.m = map[string][]byte{}
+
+		assert.Nil(t, setState("a", []byte("12345678")).Error)
+		assert.Nil(t, setState("b", []byte("12345678")).Error)
+		assert.Equal(t, "state of 24 bytes exceeds the max total size of 20 bytes", setState("c", []byte("12345678")).Error.GetMessage())
+		assert.Nil(t, setState("c", []byte("1234")).Error)
+		assert.Equal(t, "state keys exceed the max of 3 keys", setState("d", []byte("1")).Error.GetMessage())
+
+		// overwriting or clearing keys frees up the quota
+		assert.Nil(t, setState("a", []byte("1")).Error)
+		assert.Nil(t, setState("b", nil).Error)
+		assert.Nil(t, setState("d", []byte("12345678")).Error)
+		assert.Equal(t, []byte(`{"keys":3,"bytes":13}`), stateStore.m[stateUsageKey("workflow-id")])
+	})
+
+	t.Run("unknown request", func(t *testing.T) {
+		resp := &wasmpb.GetStateResponse{}
+		fn := createGetStateFn(logger.Test(t), store, modCfg, readerOf(t, &wasmpb.GetStateRequest{RequestId: "unknown", Key: "key"}), writerTo(t, resp), sizeReaderOf(1024), noopSizeWriter)
+		assert.Equal(t, ErrnoSuccess, fn(new(wasmtime.Caller), 0, 0, 0, 0))
+		assert.Equal(t, "could not find request data for id unknown", resp.Error.GetMessage())
+	})
+
+	t.Run("store returning ErrStateNotFound", func(t *testing.T) {
+		modCfg.StateStore = &mockStateStore{m: map[string][]byte{}, notFound: fmt.Errorf("no such key: %w", ErrStateNotFound)}
+
+		resp := getState("unset")
+		assert.Nil(t, resp.Error)
+		assert.Nil(t, resp.Value)
+
+		assert.Nil(t, setState("key", []byte("value")).Error)
+		assert.Equal(t, []byte("value"), getState("key").Value)
+		assert.Equal(t, []byte(`{"keys":1,"bytes":5}`), modCfg.StateStore.(*mockStateStore).m[stateUsageKey("workflow-id")])
+	})
+
+	t.Run("modules sharing a store keep the usage consistent", func(t *testing.T) {
+		stateStore := &mockStateStore{m: map[string][]byte{}}
+		modCfg.StateStore = stateStore
+		modCfg.MaxStateKeys = 100
+		modCfg.MaxStateTotalSizeBytes = 100
+
+		// each setState function stands for a different module of the same workflow
+		var wg sync.WaitGroup
+		for i := 0; i < 10; i++ {
+			fn := createSetStateFn(logger.Test(t), store, modCfg, readerOf(t, &wasmpb.SetStateRequest{RequestId: reqID, Key: strconv.Itoa(i), Value: []byte("1")}), writerTo(t, &wasmpb.SetStateResponse{}), sizeReaderOf(1024), noopSizeWriter)
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				fn(new(wasmtime.Caller), 0, 0, 0, 0)
+			}()
+		}
+		wg.Wait()
+		assert.Equal(t, []byte(`{"keys":10,"bytes":10}`), stateStore.m[stateUsageKey("workflow-id")])
+	})
+}
+
+// readerOf returns a reader of the marshaled msg.
+func readerOf(t *testing.T, msg proto.Message) unsafeReaderFunc {
+	return func(_ *wasmtime.Caller, _, _ int32) ([]byte, error) {
+		b, err := proto.Marshal(msg)
+		require.NoError(t, err)
+		return b, nil
+	}
+}
+
+// writerTo returns a writer that unmarshals what is written into msg.
+func writerTo(t *testing.T, msg proto.Message) unsafeWriterFunc {
+	return func(_ *wasmtime.Caller, src []byte, _, _ int32) int64 {
+		require.NoError(t, proto.Unmarshal(src, msg))
+		return int64(len(src))
+	}
+}
+
+func noopSizeWriter(_ *wasmtime.Caller, _ int32, _ uint32) int64 {
+	return 0
+}
+
+// sizeReaderOf returns a reader of size, the size of the guest response buffer.
+func sizeReaderOf(size uint32) unsafeFixedLengthReaderFunc {
+	return func(_ *wasmtime.Caller, _ int32) (uint32, error) {
+		return size, nil
+	}
+}
+
 func Test_read(t *testing.T) {
 	t.Run("successfully read from slice", func(t *testing.T) {
 		memory := []byte("hello, world")
@@ -0,0 +1,88 @@
+package host
+
+import (
+	"math"
+	"time"
+
+	"github.com/bytecodealliance/wasmtime-go/v23"
+)
+
+// instance is a module instantiated in its own store, ready to be run.
+type instance struct {
+	store    *wasmtime.Store
+	instance *wasmtime.Instance
+}
+
+func (i *instance) close() {
+	i.store.Close()
+}
+
+// newInstance creates a store limited to the memory of the module and instantiates the module in it.
+// The parts of the store that depend on the request, such as WASI, fuel and the epoch deadline, are set by Run.
+func (m *Module) newInstance() (*instance, error) {
+	store := wasmtime.NewStore(m.engine)
+
+	// Limit memory to max memory megabytes per instance.
+	store.Limiter(
+		m.cfg.MaxMemoryMBs*int64(math.Pow(10, 6)),
+		-1, // tableElements, -1 == default
+		1,  // instances
+		1,  // tables
+		1,  // memories
+	)
+
+	inst, err := m.linker.Instantiate(store, m.module)
+	if err != nil {
+		store.Close()
+		return nil, err
+	}
+
+	return &instance{store: store, instance: inst}, nil
+}
+
+// takeInstance returns a prewarmed instance, or instantiates the module if none is ready.
+func (m *Module) takeInstance() (*instance, error) {
+	select {
+	case inst := <-m.prewarmed:
+		return inst, nil
+	default:
+		return m.newInstance()
+	}
+}
+
+// prewarm keeps the queue of prewarmed instances full until the module is closed.
+// Instances are never reused, since the guest exits at the end of its execution and leaves its memory behind,
+// so a new instance is created for each one Run takes from the queue.
+func (m *Module) prewarm() {
+	for {
+		inst, err := m.newInstance()
+		if err != nil {
+			m.cfg.Logger.Errorw("failed to prewarm module instance", "err", err)
+			select {
+			case <-m.stopCh:
+				return
+			case <-time.After(m.cfg.TickInterval):
+				continue
+			}
+		}
+
+		select {
+		case <-m.stopCh:
+			inst.close()
+			return
+		case m.prewarmed <- inst:
+		}
+	}
+}
+
+// drainPrewarmed closes the prewarmed instances left in the queue.
+func (m *Module) drainPrewarmed() {
+	for {
+		select {
+		case inst := <-m.prewarmed:
+			inst.close()
+		default:
+			return
+		}
+	}
+}
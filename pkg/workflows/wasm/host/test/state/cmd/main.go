@@ -0,0 +1,52 @@
+//go:build wasip1
+
+package main
+
+import (
+	"strconv"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/workflows/wasm"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/capabilities/cli/cmd/testdata/fixtures/capabilities/basictrigger"
+	"github.com/smartcontractkit/chainlink-common/pkg/workflows/sdk"
+)
+
+func BuildWorkflow(config []byte) *sdk.WorkflowSpecFactory {
+	workflow := sdk.NewWorkflowSpecFactory()
+
+	triggerCfg := basictrigger.TriggerConfig{Name: "trigger", Number: 100}
+	trigger := triggerCfg.New(workflow)
+
+	sdk.Compute1[basictrigger.TriggerOutputs, int](
+		workflow,
+		"transform",
+		sdk.Compute1Inputs[basictrigger.TriggerOutputs]{Arg0: trigger},
+		func(rsdk sdk.Runtime, outputs basictrigger.TriggerOutputs) (int, error) {
+			// count the executions of the workflow
+			b, err := rsdk.GetState("counter")
+			if err != nil {
+				return 0, err
+			}
+
+			counter := 0
+			if b != nil {
+				if counter, err = strconv.Atoi(string(b)); err != nil {
+					return 0, err
+				}
+			}
+			counter++
+
+			if err = rsdk.SetState("counter", []byte(strconv.Itoa(counter))); err != nil {
+				return 0, err
+			}
+			return counter, nil
+		})
+
+	return workflow
+}
+
+func main() {
+	runner := wasm.NewRunner()
+	workflow := BuildWorkflow(runner.Config())
+	runner.Run(workflow)
+}
@@ -4,7 +4,9 @@ import (
 	"encoding/binary"
 	"io"
 	"math/rand"
+	"sync"
 	"time"
+	"unsafe"
 
 	"github.com/bytecodealliance/wasmtime-go/v23"
 	"github.com/jonboulle/clockwork"
@@ -16,7 +18,50 @@ var (
 	tick     = 100 * time.Millisecond
 )
 
-func newWasiLinker(modCfg *ModuleConfig, engine *wasmtime.Engine) (*wasmtime.Linker, error) {
+// clockFunc returns the fake clock of the caller along with the time its monotonic clock counts from.
+type clockFunc func(caller *wasmtime.Caller) (c clockwork.FakeClock, base time.Time, found bool)
+
+// sharedClock is the clockFunc of modules without deterministic time, which share the same clock.
+func sharedClock(*wasmtime.Caller) (clockwork.FakeClock, time.Time, bool) {
+	return clock, nanoBase, true
+}
+
+type runClock struct {
+	clock clockwork.FakeClock
+	start time.Time
+}
+
+// runClocks holds the clocks of the Run calls of a module with deterministic time, each starting at the time of
+// its request. WASI functions can't tell which Run they are called for, so clocks are keyed by the context of the
+// store of the Run.
+type runClocks struct {
+	m  map[unsafe.Pointer]runClock
+	mu sync.RWMutex
+}
+
+func (r *runClocks) add(store wasmtime.Storelike, start time.Time) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.m[unsafe.Pointer(store.Context())] = runClock{clock: clockwork.NewFakeClockAt(start), start: start}
+}
+
+func (r *runClocks) get(caller *wasmtime.Caller) (clockwork.FakeClock, time.Time, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	rc, found := r.m[unsafe.Pointer(caller.Context())]
+	return rc.clock, rc.start, found
+}
+
+func (r *runClocks) delete(store wasmtime.Storelike) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.m, unsafe.Pointer(store.Context()))
+}
+
+func newWasiLinker(modCfg *ModuleConfig, engine *wasmtime.Engine, clocks *runClocks) (*wasmtime.Linker, error) {
 	linker := wasmtime.NewLinker(engine)
 	linker.AllowShadowing(true)
 
@@ -25,10 +70,15 @@ func newWasiLinker(modCfg *ModuleConfig, engine *wasmtime.Engine) (*wasmtime.Lin
 		return nil, err
 	}
 
+	clockFor := clockFunc(sharedClock)
+	if clocks != nil {
+		clockFor = clocks.get
+	}
+
 	err = linker.FuncWrap(
 		"wasi_snapshot_preview1",
 		"poll_oneoff",
-		pollOneoff,
+		createPollOneoff(clockFor),
 	)
 	if err != nil {
 		return nil, err
@@ -37,7 +87,7 @@ func newWasiLinker(modCfg *ModuleConfig, engine *wasmtime.Engine) (*wasmtime.Lin
 	err = linker.FuncWrap(
 		"wasi_snapshot_preview1",
 		"clock_time_get",
-		clockTimeGet,
+		createClockTimeGet(clockFor),
 	)
 	if err != nil {
 		return nil, err
@@ -64,25 +114,32 @@ const (
 
 // Loosely based off the implementation here:
 // https://github.com/tetratelabs/wazero/blob/main/imports/wasi_snapshot_preview1/clock.go#L42
-// Each call to clockTimeGet increments our fake clock by `tick`.
-func clockTimeGet(caller *wasmtime.Caller, id int32, precision int64, resultTimestamp int32) int32 {
-	var val int64
-	switch id {
-	case clockIDMonotonic:
-		clock.Advance(tick)
-		val = clock.Since(nanoBase).Nanoseconds()
-	case clockIDRealtime:
-		clock.Advance(tick)
-		val = clock.Now().UnixNano()
-	default:
-		return ErrnoInval
-	}
+// Each call to clock_time_get increments the fake clock of the caller by `tick`.
+func createClockTimeGet(clockFor clockFunc) func(caller *wasmtime.Caller, id int32, precision int64, resultTimestamp int32) int32 {
+	return func(caller *wasmtime.Caller, id int32, precision int64, resultTimestamp int32) int32 {
+		c, base, found := clockFor(caller)
+		if !found {
+			return ErrnoFault
+		}
+
+		var val int64
+		switch id {
+		case clockIDMonotonic:
+			c.Advance(tick)
+			val = c.Since(base).Nanoseconds()
+		case clockIDRealtime:
+			c.Advance(tick)
+			val = c.Now().UnixNano()
+		default:
+			return ErrnoInval
+		}
 
-	uint64Size := int32(8)
-	trg := make([]byte, uint64Size)
-	binary.LittleEndian.PutUint64(trg, uint64(val))
-	wasmWrite(caller, trg, resultTimestamp, uint64Size)
-	return ErrnoSuccess
+		uint64Size := int32(8)
+		trg := make([]byte, uint64Size)
+		binary.LittleEndian.PutUint64(trg, uint64(val))
+		wasmWrite(caller, trg, resultTimestamp, uint64Size)
+		return ErrnoSuccess
+	}
 }
 
 const (
@@ -99,95 +156,101 @@ const (
 // For an overview of the spec, including the datatypes being referred to, see:
 // https://github.com/WebAssembly/WASI/blob/snapshot-01/phases/snapshot/docs.md
 // This implementation only responds to clock events, not to file descriptor notifications.
-// It doesn't actually sleep though, and will instead advance our fake clock by the sleep duration.
-func pollOneoff(caller *wasmtime.Caller, subscriptionptr int32, eventsptr int32, nsubscriptions int32, resultNevents int32) int32 {
-	if nsubscriptions == 0 {
-		return ErrnoInval
-	}
+// It doesn't actually sleep though, and will instead advance the fake clock of the caller by the sleep duration.
+func createPollOneoff(clockFor clockFunc) func(caller *wasmtime.Caller, subscriptionptr int32, eventsptr int32, nsubscriptions int32, resultNevents int32) int32 {
+	return func(caller *wasmtime.Caller, subscriptionptr int32, eventsptr int32, nsubscriptions int32, resultNevents int32) int32 {
+		if nsubscriptions == 0 {
+			return ErrnoInval
+		}
 
-	subs, err := wasmRead(caller, subscriptionptr, nsubscriptions*subscriptionLen)
-	if err != nil {
-		return ErrnoFault
-	}
+		subs, err := wasmRead(caller, subscriptionptr, nsubscriptions*subscriptionLen)
+		if err != nil {
+			return ErrnoFault
+		}
 
-	// Each subscription should have an event
-	events := make([]byte, nsubscriptions*eventsLen)
-
-	timeout := time.Duration(0)
-	for i := int32(0); i < nsubscriptions; i++ {
-		// First, let's read the subscription
-		inOffset := i * subscriptionLen
-
-		userData := subs[inOffset : inOffset+8]
-		eventType := subs[inOffset+8]
-		argBuf := subs[inOffset+8+8:]
-
-		outOffset := events[i*eventsLen]
-
-		slot := events[outOffset:]
-		switch eventType {
-		case eventTypeClock:
-			// We want to stub out clock events,
-			// so let's just return success, and
-			// we'll advance the clock by the timeout duration
-			// below.
-
-			// Structure of event, per:
-			// https://github.com/WebAssembly/WASI/blob/snapshot-01/phases/snapshot/docs.md#-subscription_clock-struct
-			// - 0-8: clock id
-			// - 8-16: timeout
-			// - 16-24: precision
-			// - 24-32: flag
-			newTimeout := binary.LittleEndian.Uint16(argBuf[8:16])
-			flag := binary.LittleEndian.Uint16(argBuf[24:32])
-
-			var errno Errno
-			switch flag {
-			case 0: // relative time
-				errno = ErrnoSuccess
-				if timeout < time.Duration(newTimeout) {
-					timeout = time.Duration(newTimeout)
+		// Each subscription should have an event
+		events := make([]byte, nsubscriptions*eventsLen)
+
+		timeout := time.Duration(0)
+		for i := int32(0); i < nsubscriptions; i++ {
+			// First, let's read the subscription
+			inOffset := i * subscriptionLen
+
+			userData := subs[inOffset : inOffset+8]
+			eventType := subs[inOffset+8]
+			argBuf := subs[inOffset+8+8:]
+
+			outOffset := events[i*eventsLen]
+
+			slot := events[outOffset:]
+			switch eventType {
+			case eventTypeClock:
+				// We want to stub out clock events,
+				// so let's just return success, and
+				// we'll advance the clock by the timeout duration
+				// below.
+
+				// Structure of event, per:
+				// https://github.com/WebAssembly/WASI/blob/snapshot-01/phases/snapshot/docs.md#-subscription_clock-struct
+				// - 0-8: clock id
+				// - 8-16: timeout
+				// - 16-24: precision
+				// - 24-32: flag
+				newTimeout := binary.LittleEndian.Uint16(argBuf[8:16])
+				flag := binary.LittleEndian.Uint16(argBuf[24:32])
+
+				var errno Errno
+				switch flag {
+				case 0: // relative time
+					errno = ErrnoSuccess
+					if timeout < time.Duration(newTimeout) {
+						timeout = time.Duration(newTimeout)
+					}
+				default:
+					errno = ErrnoNotsup
 				}
+				writeEvent(slot, userData, errno, eventTypeClock)
+			case eventTypeFDRead:
+				// Our sandbox doesn't allow access to the filesystem,
+				// so let's just error these events
+				writeEvent(slot, userData, ErrnoBadf, eventTypeFDRead)
+			case eventTypeFDWrite:
+				// Our sandbox doesn't allow access to the filesystem,
+				// so let's just error these events
+				writeEvent(slot, userData, ErrnoBadf, eventTypeFDWrite)
 			default:
-				errno = ErrnoNotsup
+				writeEvent(slot, userData, ErrnoInval, int(eventType))
 			}
-			writeEvent(slot, userData, errno, eventTypeClock)
-		case eventTypeFDRead:
-			// Our sandbox doesn't allow access to the filesystem,
-			// so let's just error these events
-			writeEvent(slot, userData, ErrnoBadf, eventTypeFDRead)
-		case eventTypeFDWrite:
-			// Our sandbox doesn't allow access to the filesystem,
-			// so let's just error these events
-			writeEvent(slot, userData, ErrnoBadf, eventTypeFDWrite)
-		default:
-			writeEvent(slot, userData, ErrnoInval, int(eventType))
 		}
-	}
 
-	// Advance the clock by timeout.
-	// This will make it seem like we've slept by timeout.
-	if timeout > 0 {
-		clock.Advance(timeout)
-	}
+		// Advance the clock by timeout.
+		// This will make it seem like we've slept by timeout.
+		if timeout > 0 {
+			c, _, found := clockFor(caller)
+			if !found {
+				return ErrnoFault
+			}
+			c.Advance(timeout)
+		}
 
-	uint32Size := int32(4)
-	rne := make([]byte, uint32Size)
-	binary.LittleEndian.PutUint32(rne, uint32(nsubscriptions))
+		uint32Size := int32(4)
+		rne := make([]byte, uint32Size)
+		binary.LittleEndian.PutUint32(rne, uint32(nsubscriptions))
 
-	// Write the number of events to `resultNevents`
-	size := wasmWrite(caller, rne, resultNevents, uint32Size)
-	if size == -1 {
-		return ErrnoFault
-	}
+		// Write the number of events to `resultNevents`
+		size := wasmWrite(caller, rne, resultNevents, uint32Size)
+		if size == -1 {
+			return ErrnoFault
+		}
 
-	// Write the events to `events`
-	size = wasmWrite(caller, events, eventsptr, nsubscriptions*eventsLen)
-	if size == -1 {
-		return ErrnoFault
-	}
+		// Write the events to `events`
+		size = wasmWrite(caller, events, eventsptr, nsubscriptions*eventsLen)
+		if size == -1 {
+			return ErrnoFault
+		}
 
-	return ErrnoSuccess
+		return ErrnoSuccess
+	}
 }
 
 func writeEvent(slot []byte, userData []byte, errno Errno, eventType int) {
@@ -6,9 +6,12 @@ import (
 	_ "embed"
 	"fmt"
 	"io"
+	"math"
 	"net/http"
 	"os"
 	"os/exec"
+	"path/filepath"
+	"sync"
 	"testing"
 	"time"
 
@@ -18,6 +21,7 @@ import (
 	"github.com/stretchr/testify/require"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
+	"google.golang.org/protobuf/types/known/timestamppb"
 
 	"github.com/smartcontractkit/chainlink-common/pkg/capabilities/pb"
 	capabilitiespb "github.com/smartcontractkit/chainlink-common/pkg/capabilities/pb"
@@ -55,13 +59,17 @@ const (
 	randBinaryCmd              = "test/rand/cmd"
 	emitBinaryLocation         = "test/emit/cmd/testmodule.wasm"
 	emitBinaryCmd              = "test/emit/cmd"
+	stateBinaryLocation        = "test/state/cmd/testmodule.wasm"
+	stateBinaryCmd             = "test/state/cmd"
+	timeBinaryLocation         = "test/time/cmd/testmodule.wasm"
+	timeBinaryCmd              = "test/time/cmd"
 	computePanicBinaryLocation = "test/computepanic/cmd/testmodule.wasm"
 	computePanicBinaryCmd      = "test/computepanic/cmd"
 	buildErrorBinaryLocation   = "test/builderr/cmd/testmodule.wasm"
 	buildErrorBinaryCmd        = "test/builderr/cmd"
 )
 
-func createTestBinary(outputPath, path string, uncompressed bool, t *testing.T) []byte {
+func createTestBinary(outputPath, path string, uncompressed bool, t testing.TB) []byte {
 	cmd := exec.Command("go", "build", "-o", path, fmt.Sprintf("github.com/smartcontractkit/chainlink-common/pkg/workflows/wasm/host/%s", outputPath)) // #nosec
 	cmd.Env = append(os.Environ(), "GOOS=wasip1", "GOARCH=wasm")
 
@@ -365,6 +373,170 @@ func Test_Compute_Emit(t *testing.T) {
 	})
 }
 
+type mockStateStore struct {
+	mu sync.Mutex
+	m  map[string][]byte
+	// notFound is returned for missing keys if set
+	notFound error
+}
+
+func (s *mockStateStore) Store(_ context.Context, key string, val []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.m[key] = val
+	return nil
+}
+
+func (s *mockStateStore) Get(_ context.Context, key string) ([]byte, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	val, ok := s.m[key]
+	if !ok && s.notFound != nil {
+		return nil, s.notFound
+	}
+	return val, nil
+}
+
+func Test_Compute_State(t *testing.T) {
+	t.Parallel()
+	binary := createTestBinary(stateBinaryCmd, stateBinaryLocation, true, t)
+
+	newRequest := func(workflowID string) *wasmpb.Request {
+		return &wasmpb.Request{
+			Id: uuid.New().String(),
+			Message: &wasmpb.Request_ComputeRequest{
+				ComputeRequest: &wasmpb.ComputeRequest{
+					Request: &capabilitiespb.CapabilityRequest{
+						Inputs: &valuespb.Map{},
+						Config: &valuespb.Map{},
+						Metadata: &capabilitiespb.RequestMetadata{
+							ReferenceId: "transform",
+							WorkflowId:  workflowID,
+						},
+					},
+				},
+			},
+		}
+	}
+
+	counter := func(t *testing.T, response *wasmpb.Response) int {
+		r, err := pb.CapabilityResponseFromProto(response.GetComputeResponse().GetResponse())
+		require.NoError(t, err)
+
+		var c int
+		require.NoError(t, r.Value.Underlying["Value"].UnwrapTo(&c))
+		return c
+	}
+
+	t.Run("state is kept between executions per workflow", func(t *testing.T) {
+		t.Parallel()
+		ctx := tests.Context(t)
+		stateStore := &mockStateStore{m: map[string][]byte{}}
+
+		m, err := NewModule(&ModuleConfig{
+			Logger:         logger.Test(t),
+			IsUncompressed: true,
+			StateStore:     stateStore,
+		}, binary)
+		require.NoError(t, err)
+
+		m.Start()
+		defer m.Close()
+
+		for i := 1; i <= 3; i++ {
+			response, err := m.Run(ctx, newRequest("workflow-id"))
+			require.NoError(t, err)
+			assert.Equal(t, i, counter(t, response))
+		}
+
+		response, err := m.Run(ctx, newRequest("other-workflow-id"))
+		require.NoError(t, err)
+		assert.Equal(t, 1, counter(t, response))
+
+		assert.Equal(t, map[string][]byte{
+			"workflow-id/counter":       []byte("3"),
+			"workflow-id#usage":         []byte(`{"keys":1,"bytes":1}`),
+			"other-workflow-id/counter": []byte("1"),
+			"other-workflow-id#usage":   []byte(`{"keys":1,"bytes":1}`),
+		}, stateStore.m)
+	})
+
+	t.Run("value larger than the guest buffer is not returned", func(t *testing.T) {
+		t.Parallel()
+		ctx := tests.Context(t)
+		stateStore := &mockStateStore{m: map[string][]byte{"workflow-id/counter": bytes.Repeat([]byte("9"), 10*1024)}}
+
+		m, err := NewModule(&ModuleConfig{
+			Logger:         logger.Test(t),
+			IsUncompressed: true,
+			StateStore:     stateStore,
+		}, binary)
+		require.NoError(t, err)
+
+		m.Start()
+		defer m.Close()
+
+		_, err = m.Run(ctx, newRequest("workflow-id"))
+		assert.ErrorContains(t, err, "exceeds the guest buffer")
+	})
+
+	t.Run("value exceeding max size is rejected", func(t *testing.T) {
+		t.Parallel()
+		ctx := tests.Context(t)
+		stateStore := &mockStateStore{m: map[string][]byte{"workflow-id/counter": []byte("9")}}
+
+		m, err := NewModule(&ModuleConfig{
+			Logger:                 logger.Test(t),
+			IsUncompressed:         true,
+			StateStore:             stateStore,
+			MaxStateValueSizeBytes: 1,
+		}, binary)
+		require.NoError(t, err)
+
+		m.Start()
+		defer m.Close()
+
+		_, err = m.Run(ctx, newRequest("workflow-id"))
+		assert.ErrorContains(t, err, "state value of 2 bytes exceeds the max size of 1 bytes")
+		assert.Equal(t, []byte("9"), stateStore.m["workflow-id/counter"])
+	})
+
+	t.Run("state requires a workflow ID", func(t *testing.T) {
+		t.Parallel()
+		ctx := tests.Context(t)
+
+		m, err := NewModule(&ModuleConfig{
+			Logger:         logger.Test(t),
+			IsUncompressed: true,
+			StateStore:     &mockStateStore{m: map[string][]byte{}},
+		}, binary)
+		require.NoError(t, err)
+
+		m.Start()
+		defer m.Close()
+
+		_, err = m.Run(ctx, newRequest(""))
+		assert.ErrorContains(t, err, "state is only available to requests with a workflow ID")
+	})
+
+	t.Run("state is not implemented without a store", func(t *testing.T) {
+		t.Parallel()
+		ctx := tests.Context(t)
+
+		m, err := NewModule(&ModuleConfig{
+			Logger:         logger.Test(t),
+			IsUncompressed: true,
+		}, binary)
+		require.NoError(t, err)
+
+		m.Start()
+		defer m.Close()
+
+		_, err = m.Run(ctx, newRequest("workflow-id"))
+		assert.ErrorContains(t, err, "state not implemented")
+	})
+}
+
 func Test_Compute_PanicIsRecovered(t *testing.T) {
 	t.Parallel()
 	binary := createTestBinary(computePanicBinaryCmd, computePanicBinaryLocation, true, t)
@@ -1123,3 +1295,205 @@ type Entry struct {
 	Log    zapcore.Entry
 	Fields []zapcore.Field
 }
+
+func TestModule_Sandbox_DeterministicTime(t *testing.T) {
+	t.Parallel()
+	binary := createTestBinary(timeBinaryCmd, timeBinaryLocation, true, t)
+
+	m, err := NewModule(&ModuleConfig{
+		Logger:         logger.Test(t),
+		IsUncompressed: true,
+		Determinism:    &DeterminismConfig{Time: true},
+	}, binary)
+	require.NoError(t, err)
+
+	m.Start()
+	t.Cleanup(m.Close)
+
+	run := func(t *testing.T, requestTime *timestamppb.Timestamp) ([]string, error) {
+		req := &wasmpb.Request{
+			Id: uuid.New().String(),
+			Message: &wasmpb.Request_ComputeRequest{
+				ComputeRequest: &wasmpb.ComputeRequest{
+					Request: &capabilitiespb.CapabilityRequest{
+						Inputs: &valuespb.Map{},
+						Config: &valuespb.Map{},
+						Metadata: &capabilitiespb.RequestMetadata{
+							ReferenceId: "transform",
+						},
+					},
+				},
+			},
+			Time: requestTime,
+		}
+
+		response, err := m.Run(tests.Context(t), req)
+		if err != nil {
+			return nil, err
+		}
+
+		r, err := pb.CapabilityResponseFromProto(response.GetComputeResponse().GetResponse())
+		require.NoError(t, err)
+
+		var times []string
+		require.NoError(t, r.Value.Underlying["Value"].UnwrapTo(&times))
+		return times, nil
+	}
+
+	triggerTime := time.Date(2024, 11, 5, 12, 30, 0, 0, time.UTC)
+
+	t.Run("time is the same across runs", func(t *testing.T) {
+		t.Parallel()
+		expected, err := run(t, timestamppb.New(triggerTime))
+		require.NoError(t, err)
+		require.Len(t, expected, 3)
+		assert.Equal(t, "2024-11-05T12:30:00Z", expected[0])
+
+		start, err := time.Parse(time.RFC3339Nano, expected[1])
+		require.NoError(t, err)
+		end, err := time.Parse(time.RFC3339Nano, expected[2])
+		require.NoError(t, err)
+		assert.False(t, start.Before(triggerTime))
+		assert.GreaterOrEqual(t, end.Sub(start), 2500*time.Millisecond)
+
+		for i := 0; i < 3; i++ {
+			times, err := run(t, timestamppb.New(triggerTime))
+			require.NoError(t, err)
+			assert.Equal(t, expected, times)
+		}
+	})
+
+	t.Run("time follows the request", func(t *testing.T) {
+		t.Parallel()
+		times, err := run(t, timestamppb.New(triggerTime.Add(time.Hour)))
+		require.NoError(t, err)
+		require.Len(t, times, 3)
+		assert.Equal(t, "2024-11-05T13:30:00Z", times[0])
+
+		start, err := time.Parse(time.RFC3339Nano, times[1])
+		require.NoError(t, err)
+		assert.False(t, start.Before(triggerTime.Add(time.Hour)))
+	})
+
+	t.Run("request without time", func(t *testing.T) {
+		t.Parallel()
+		_, err := run(t, nil)
+		assert.ErrorContains(t, err, "time is required when time is deterministic")
+	})
+}
+
+func TestModule_CompiledCache(t *testing.T) {
+	t.Parallel()
+	ctx := tests.Context(t)
+	binary := createTestBinary(successBinaryCmd, successBinaryLocation, true, t)
+
+	dir := t.TempDir()
+	modCfg := func() *ModuleConfig {
+		return &ModuleConfig{
+			Logger:           logger.Test(t),
+			IsUncompressed:   true,
+			CompiledCacheDir: dir,
+		}
+	}
+
+	_, err := GetWorkflowSpec(ctx, modCfg(), binary, []byte(""))
+	require.NoError(t, err)
+
+	cached, err := filepath.Glob(filepath.Join(dir, "*"+compiledModuleExt))
+	require.NoError(t, err)
+	require.Len(t, cached, 1)
+
+	compiled, err := os.ReadFile(cached[0])
+	require.NoError(t, err)
+
+	t.Run("cached module is used", func(t *testing.T) {
+		_, err := GetWorkflowSpec(ctx, modCfg(), binary, []byte(""))
+		require.NoError(t, err)
+
+		entries, err := os.ReadDir(dir)
+		require.NoError(t, err)
+		assert.Len(t, entries, 1)
+	})
+
+	t.Run("corrupted module is compiled again", func(t *testing.T) {
+		require.NoError(t, os.WriteFile(cached[0], []byte("not a compiled module"), 0o600))
+
+		_, err := GetWorkflowSpec(ctx, modCfg(), binary, []byte(""))
+		require.NoError(t, err)
+
+		recompiled, err := os.ReadFile(cached[0])
+		require.NoError(t, err)
+		assert.Equal(t, compiled, recompiled)
+	})
+
+	t.Run("module compiled with another config is cached separately", func(t *testing.T) {
+		cfg := modCfg()
+		cfg.InitialFuel = math.MaxUint64
+		_, err := GetWorkflowSpec(ctx, cfg, binary, []byte(""))
+		require.NoError(t, err)
+
+		cached, err := filepath.Glob(filepath.Join(dir, "*"+compiledModuleExt))
+		require.NoError(t, err)
+		assert.Len(t, cached, 2)
+	})
+}
+
+func TestModule_Prewarm(t *testing.T) {
+	t.Parallel()
+	binary := createTestBinary(timeBinaryCmd, timeBinaryLocation, true, t)
+
+	newModule := func(t *testing.T, prewarm int) *Module {
+		m, err := NewModule(&ModuleConfig{
+			Logger:           logger.Test(t),
+			IsUncompressed:   true,
+			Determinism:      &DeterminismConfig{Time: true},
+			PrewarmInstances: prewarm,
+		}, binary)
+		require.NoError(t, err)
+
+		m.Start()
+		t.Cleanup(m.Close)
+		return m
+	}
+
+	run := func(t *testing.T, m *Module) []string {
+		req := &wasmpb.Request{
+			Id: uuid.New().String(),
+			Message: &wasmpb.Request_ComputeRequest{
+				ComputeRequest: &wasmpb.ComputeRequest{
+					Request: &capabilitiespb.CapabilityRequest{
+						Inputs: &valuespb.Map{},
+						Config: &valuespb.Map{},
+						Metadata: &capabilitiespb.RequestMetadata{
+							ReferenceId: "transform",
+						},
+					},
+				},
+			},
+			Time: timestamppb.New(time.Date(2024, 11, 5, 12, 30, 0, 0, time.UTC)),
+		}
+
+		response, err := m.Run(tests.Context(t), req)
+		require.NoError(t, err)
+
+		r, err := pb.CapabilityResponseFromProto(response.GetComputeResponse().GetResponse())
+		require.NoError(t, err)
+
+		var times []string
+		require.NoError(t, r.Value.Underlying["Value"].UnwrapTo(&times))
+		return times
+	}
+
+	// Prewarmed instances must start from scratch, so they see the same time as an instance created for the run.
+	expected := run(t, newModule(t, 0))
+
+	m := newModule(t, 2)
+	for i := 0; i < 5; i++ {
+		assert.Equal(t, expected, run(t, m))
+	}
+
+	t.Run("rejects a negative number of instances", func(t *testing.T) {
+		_, err := NewModule(&ModuleConfig{Logger: logger.Test(t), IsUncompressed: true, PrewarmInstances: -1}, binary)
+		assert.ErrorContains(t, err, "invalid PrewarmInstances")
+	})
+}
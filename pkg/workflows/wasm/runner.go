@@ -189,6 +189,10 @@ func (r *Runner) handleComputeRequest(factory *sdk.WorkflowSpecFactory, id strin
 
 	// Extract the config from the request
 	drc := defaultRuntimeConfig(id, &creq.Metadata)
+	if t := r.req.GetTime(); t != nil {
+		requestTime := t.AsTime()
+		drc.Time = &requestTime
+	}
 	if rc := computeReq.GetRuntimeConfig(); rc != nil {
 		if rc.MaxFetchResponseSizeBytes != 0 {
 			drc.MaxFetchResponseSizeBytes = rc.MaxFetchResponseSizeBytes
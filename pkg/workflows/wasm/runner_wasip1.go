@@ -22,6 +22,12 @@ func fetch(respptr unsafe.Pointer, resplenptr unsafe.Pointer, reqptr unsafe.Poin
 //go:wasmimport env emit
 func emit(respptr unsafe.Pointer, resplenptr unsafe.Pointer, reqptr unsafe.Pointer, reqptrlen int32) int32
 
+//go:wasmimport env getState
+func getState(respptr unsafe.Pointer, resplenptr unsafe.Pointer, reqptr unsafe.Pointer, reqptrlen int32) int32
+
+//go:wasmimport env setState
+func setState(respptr unsafe.Pointer, resplenptr unsafe.Pointer, reqptr unsafe.Pointer, reqptrlen int32) int32
+
 func NewRunner() *Runner {
 	l := logger.NewWithSync(&wasmWriteSyncer{})
 
@@ -33,9 +39,12 @@ func NewRunner() *Runner {
 			}
 
 			return &Runtime{
-				logger:  l,
-				fetchFn: createFetchFn(sdkConfig, l, fetch),
-				emitFn:  createEmitFn(sdkConfig, l, emit),
+				logger:     l,
+				fetchFn:    createFetchFn(sdkConfig, l, fetch),
+				emitFn:     createEmitFn(sdkConfig, l, emit),
+				getStateFn: createGetStateFn(sdkConfig, getState),
+				setStateFn: createSetStateFn(sdkConfig, setState),
+				nowFn:      createNowFn(sdkConfig),
 			}
 		},
 		args: os.Args,
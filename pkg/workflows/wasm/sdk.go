@@ -4,6 +4,7 @@ import (
 	"encoding/binary"
 	"errors"
 	"fmt"
+	"time"
 	"unsafe"
 
 	"google.golang.org/protobuf/proto"
@@ -22,15 +23,20 @@ import (
 const uint32Size = int32(4)
 
 type Runtime struct {
-	fetchFn func(req sdk.FetchRequest) (sdk.FetchResponse, error)
-	emitFn  func(msg string, labels map[string]string) error
-	logger  logger.Logger
+	fetchFn    func(req sdk.FetchRequest) (sdk.FetchResponse, error)
+	emitFn     func(msg string, labels map[string]string) error
+	getStateFn func(key string) ([]byte, error)
+	setStateFn func(key string, value []byte) error
+	nowFn      func() time.Time
+	logger     logger.Logger
 }
 
 type RuntimeConfig struct {
 	MaxFetchResponseSizeBytes int64
 	RequestID                 *string
 	Metadata                  *capabilities.RequestMetadata
+	// Time is the time of the request, if the host provided one.
+	Time *time.Time
 }
 
 const (
@@ -59,6 +65,18 @@ func (r *Runtime) Emitter() sdk.MessageEmitter {
 	return newWasmGuestEmitter(r.emitFn)
 }
 
+func (r *Runtime) GetState(key string) ([]byte, error) {
+	return r.getStateFn(key)
+}
+
+func (r *Runtime) SetState(key string, value []byte) error {
+	return r.setStateFn(key, value)
+}
+
+func (r *Runtime) Now() time.Time {
+	return r.nowFn()
+}
+
 type wasmGuestEmitter struct {
 	base   custmsg.MessageEmitter
 	emitFn func(string, map[string]string) error
@@ -228,6 +246,113 @@ func createFetchFn(
 	return fetchFn
 }
 
+// createGetStateFn injects dependencies and creates a getState function that can be used by the WASM
+// binary.
+func createGetStateFn(
+	sdkConfig *RuntimeConfig,
+	getState func(respptr unsafe.Pointer, resplenptr unsafe.Pointer, reqptr unsafe.Pointer, reqptrlen int32) int32,
+) func(string) ([]byte, error) {
+	return func(key string) ([]byte, error) {
+		if sdkConfig.RequestID == nil {
+			return nil, fmt.Errorf("request ID is required to get state")
+		}
+
+		b, err := proto.Marshal(&wasmpb.GetStateRequest{
+			RequestId: *sdkConfig.RequestID,
+			Key:       key,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal get state request: %w", err)
+		}
+
+		response := &wasmpb.GetStateResponse{}
+		if err = callStateFn(sdkConfig, getState, b, response); err != nil {
+			return nil, fmt.Errorf("get state failed: %w", err)
+		}
+
+		if response.Error != nil && response.Error.Message != "" {
+			return nil, errors.New(response.Error.Message)
+		}
+
+		return response.Value, nil
+	}
+}
+
+// createSetStateFn injects dependencies and creates a setState function that can be used by the WASM
+// binary.
+func createSetStateFn(
+	sdkConfig *RuntimeConfig,
+	setState func(respptr unsafe.Pointer, resplenptr unsafe.Pointer, reqptr unsafe.Pointer, reqptrlen int32) int32,
+) func(string, []byte) error {
+	return func(key string, value []byte) error {
+		if sdkConfig.RequestID == nil {
+			return fmt.Errorf("request ID is required to set state")
+		}
+
+		b, err := proto.Marshal(&wasmpb.SetStateRequest{
+			RequestId: *sdkConfig.RequestID,
+			Key:       key,
+			Value:     value,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to marshal set state request: %w", err)
+		}
+
+		response := &wasmpb.SetStateResponse{}
+		if err = callStateFn(sdkConfig, setState, b, response); err != nil {
+			return fmt.Errorf("set state failed: %w", err)
+		}
+
+		if response.Error != nil && response.Error.Message != "" {
+			return errors.New(response.Error.Message)
+		}
+
+		return nil
+	}
+}
+
+// callStateFn sends the marshaled request to the host via the given state function,
+// and unmarshals the host's response into response.
+func callStateFn(
+	sdkConfig *RuntimeConfig,
+	fn func(respptr unsafe.Pointer, resplenptr unsafe.Pointer, reqptr unsafe.Pointer, reqptrlen int32) int32,
+	req []byte,
+	response proto.Message,
+) error {
+	reqptr, reqptrlen := bufferToPointerLen(req)
+
+	respBuffer := make([]byte, sdkConfig.MaxFetchResponseSizeBytes)
+	respptr, _ := bufferToPointerLen(respBuffer)
+
+	// the host reads the size of the response buffer from the response length
+	resplenBuffer := make([]byte, uint32Size)
+	binary.LittleEndian.PutUint32(resplenBuffer, uint32(len(respBuffer)))
+	resplenptr, _ := bufferToPointerLen(resplenBuffer)
+
+	errno := fn(respptr, resplenptr, reqptr, reqptrlen)
+	if errno != 0 {
+		return fmt.Errorf("errno %d", errno)
+	}
+
+	responseSize := binary.LittleEndian.Uint32(resplenBuffer)
+	if err := proto.Unmarshal(respBuffer[:responseSize], response); err != nil {
+		return fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+
+	return nil
+}
+
+// createNowFn creates a function returning the time of the request, or the time of the WASI clock if the
+// request has none.
+func createNowFn(sdkConfig *RuntimeConfig) func() time.Time {
+	return func() time.Time {
+		if sdkConfig.Time != nil {
+			return *sdkConfig.Time
+		}
+		return time.Now()
+	}
+}
+
 // bufferToPointerLen returns a pointer to the first element of the buffer and the length of the buffer.
 func bufferToPointerLen(buf []byte) (unsafe.Pointer, int32) {
 	return unsafe.Pointer(&buf[0]), int32(len(buf))